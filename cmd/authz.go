@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/derailed/popeye/internal/authz"
+	"github.com/derailed/popeye/pkg"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+func whoCanCmd() *cobra.Command {
+	var out string
+	cmd := cobra.Command{
+		Use:   "who-can VERB RESOURCE",
+		Short: "Lists subjects allowed to perform an action",
+		Long:  "Resolves roles and bindings, including aggregated ClusterRoles, and lists the subjects allowed to perform a verb on a resource ie who-can create pods/exec -n fred",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			popeye := newStandAlonePopeye()
+			var ns string
+			if flags.Namespace != nil {
+				ns = *flags.Namespace
+			}
+			gg, err := popeye.WhoCan(args[0], args[1], ns)
+			if err != nil {
+				bomb(err.Error())
+			}
+			if err := authz.Dump(os.Stdout, out, gg); err != nil {
+				bomb(err.Error())
+			}
+		},
+	}
+	cmd.Flags().StringVarP(&out, "out", "o",
+		authz.TableFormat,
+		"Specify the output format (table, json)",
+	)
+
+	return &cmd
+}
+
+func canISubjectCmd() *cobra.Command {
+	var out string
+	cmd := cobra.Command{
+		Use:   "can-i-subject SUBJECT",
+		Short: "Lists the permissions granted to a subject",
+		Long:  "Resolves roles and bindings, including aggregated ClusterRoles and implicit groups, and lists the rules granted to a subject ie can-i-subject sa:fred/default",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			popeye := newStandAlonePopeye()
+			gg, err := popeye.CanISubject(args[0])
+			if err != nil {
+				bomb(err.Error())
+			}
+			if err := authz.Dump(os.Stdout, out, gg); err != nil {
+				bomb(err.Error())
+			}
+		},
+	}
+	cmd.Flags().StringVarP(&out, "out", "o",
+		authz.TableFormat,
+		"Specify the output format (table, json)",
+	)
+
+	return &cmd
+}
+
+func newStandAlonePopeye() *pkg.Popeye {
+	flags.StandAlone = true
+	popeye, err := pkg.NewPopeye(flags, &log.Logger)
+	if err != nil {
+		bomb(fmt.Sprintf("Popeye configuration load failed %v", err))
+	}
+
+	return popeye
+}
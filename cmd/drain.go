@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/derailed/popeye/internal/drain"
+	"github.com/spf13/cobra"
+)
+
+func drainSimCmd() *cobra.Command {
+	var out string
+	cmd := cobra.Command{
+		Use:   "drain-sim NODE",
+		Short: "Simulates draining a node",
+		Long:  "Simulates evicting all pods from a node and reports workloads that would be blocked by PodDisruptionBudgets or lose all their ready replicas ie drain-sim node-1",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			popeye := newStandAlonePopeye()
+			oo, err := popeye.DrainSim(args[0])
+			if err != nil {
+				bomb(err.Error())
+			}
+			if err := drain.Dump(os.Stdout, out, oo); err != nil {
+				bomb(err.Error())
+			}
+		},
+	}
+	cmd.Flags().StringVarP(&out, "out", "o",
+		drain.TableFormat,
+		"Specify the output format (table, json)",
+	)
+
+	return &cmd
+}
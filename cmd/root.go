@@ -38,7 +38,7 @@ func execName() string {
 }
 
 func init() {
-	rootCmd.AddCommand(versionCmd())
+	rootCmd.AddCommand(versionCmd(), snapshotCmd(), serveCmd(), webhookCmd(), whoCanCmd(), canISubjectCmd(), drainSimCmd())
 	initFlags()
 }
 
@@ -169,6 +169,41 @@ func initPopeyeFlags() {
 		"Use a spinach YAML configuration file",
 	)
 
+	rootCmd.Flags().StringVarP(flags.Manifests, "manifests", "",
+		"",
+		"Sanitize manifests from a file, a directory or stdin (-) instead of a live cluster",
+	)
+
+	rootCmd.Flags().StringVarP(flags.FromSnapshot, "from-snapshot", "",
+		"",
+		"Sanitize a cluster snapshot archive instead of a live cluster",
+	)
+
+	rootCmd.Flags().StringVarP(flags.TargetVersion, "target-version", "",
+		"",
+		"Report resources using apis removed in the given Kubernetes version ie --target-version 1.25",
+	)
+
+	rootCmd.Flags().StringVarP(flags.VulnReports, "vuln-reports", "",
+		"",
+		"Correlate Trivy or Grype json image reports found in the given directory with running containers",
+	)
+
+	rootCmd.Flags().BoolVarP(flags.AllContexts, "all-contexts", "",
+		false,
+		"Sanitize all kubeconfig contexts and produce a fleet report",
+	)
+
+	rootCmd.Flags().StringSliceVarP(flags.Contexts, "contexts", "",
+		[]string{},
+		"Specifies which kubeconfig contexts to sanitize ie --contexts a,b,c",
+	)
+
+	rootCmd.Flags().IntVarP(flags.MaxConcurrency, "max-concurrency", "",
+		config.DefaultMaxConcurrency,
+		"Specify the maximum number of contexts sanitized in parallel",
+	)
+
 	rootCmd.Flags().StringSliceVarP(flags.Sections, "sections", "s",
 		[]string{},
 		"Specifies which resources to include in the scan ie -s po,svc",
@@ -176,42 +211,42 @@ func initPopeyeFlags() {
 }
 
 func initKubeConfigFlags() {
-	rootCmd.Flags().StringVar(
+	rootCmd.PersistentFlags().StringVar(
 		flags.KubeConfig,
 		"kubeconfig",
 		"",
 		"Path to the kubeconfig file to use for CLI requests",
 	)
 
-	rootCmd.Flags().StringVar(
+	rootCmd.PersistentFlags().StringVar(
 		flags.Context,
 		"context",
 		"",
 		"The name of the kubeconfig context to use",
 	)
 
-	rootCmd.Flags().StringVar(
+	rootCmd.PersistentFlags().StringVar(
 		flags.ClusterName,
 		"cluster",
 		"",
 		"The name of the kubeconfig cluster to use",
 	)
 
-	rootCmd.Flags().StringVar(
+	rootCmd.PersistentFlags().StringVar(
 		flags.AuthInfoName,
 		"user",
 		"",
 		"The name of the kubeconfig user to use",
 	)
 
-	rootCmd.Flags().StringVar(
+	rootCmd.PersistentFlags().StringVar(
 		flags.Impersonate,
 		"as",
 		"",
 		"Username to impersonate for the operation",
 	)
 
-	rootCmd.Flags().StringArrayVar(
+	rootCmd.PersistentFlags().StringArrayVar(
 		flags.ImpersonateGroup,
 		"as-group",
 		[]string{},
@@ -223,49 +258,49 @@ func initFlags() {
 	initPopeyeFlags()
 	initKubeConfigFlags()
 
-	rootCmd.Flags().StringVar(
+	rootCmd.PersistentFlags().StringVar(
 		flags.Timeout,
 		"request-timeout",
 		"",
 		"The length of time to wait before giving up on a single server request",
 	)
 
-	rootCmd.Flags().BoolVar(
+	rootCmd.PersistentFlags().BoolVar(
 		flags.Insecure,
 		"insecure-skip-tls-verify",
 		false,
 		"If true, the server's caCertFile will not be checked for validity",
 	)
 
-	rootCmd.Flags().StringVar(
+	rootCmd.PersistentFlags().StringVar(
 		flags.CAFile,
 		"certificate-authority",
 		"",
 		"Path to a cert file for the certificate authority",
 	)
 
-	rootCmd.Flags().StringVar(
+	rootCmd.PersistentFlags().StringVar(
 		flags.KeyFile,
 		"client-key",
 		"",
 		"Path to a client key file for TLS",
 	)
 
-	rootCmd.Flags().StringVar(
+	rootCmd.PersistentFlags().StringVar(
 		flags.CertFile,
 		"client-certificate",
 		"",
 		"Path to a client certificate file for TLS",
 	)
 
-	rootCmd.Flags().StringVar(
+	rootCmd.PersistentFlags().StringVar(
 		flags.BearerToken,
 		"token",
 		"",
 		"Bearer token for authentication to the API server",
 	)
 
-	rootCmd.Flags().StringVarP(
+	rootCmd.PersistentFlags().StringVarP(
 		flags.Namespace,
 		"namespace",
 		"n",
@@ -300,6 +335,12 @@ func checkFlags() error {
 	if !*flags.Save && *flags.OutputFile != "" {
 		return errors.New("Please set '--save' flag to use 'output-file'.")
 	}
+	if *flags.Manifests != "" && *flags.FromSnapshot != "" {
+		return errors.New("Please use either '--manifests' or '--from-snapshot'.")
+	}
+	if flags.IsFleet() && (*flags.Manifests != "" || *flags.FromSnapshot != "") {
+		return errors.New("Please use '--all-contexts' or '--contexts' against live clusters only.")
+	}
 	return nil
 }
 
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/derailed/popeye/pkg"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+func serveCmd() *cobra.Command {
+	var (
+		addr          string
+		debounce      time.Duration
+		maxScans      int
+		scanRetention int
+	)
+	cmd := cobra.Command{
+		Use:   "serve",
+		Short: "Continuously sanitizes a cluster and serves the latest report",
+		Long:  "Watches the cluster resources, re-runs the affected sanitizers on change and serves the latest report over http",
+		Run: func(cmd *cobra.Command, args []string) {
+			// Resources are served from the factory informers.
+			flags.StandAlone = false
+			popeye, err := pkg.NewPopeye(flags, &log.Logger)
+			if err != nil {
+				bomb(fmt.Sprintf("Popeye configuration load failed %v", err))
+			}
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+			srv := pkg.NewServer(popeye, debounce)
+			srv.SetScans(pkg.NewScans(flags, &log.Logger, maxScans, scanRetention))
+			if err := srv.Serve(ctx, addr); err != nil {
+				bomb(err.Error())
+			}
+		},
+	}
+	cmd.Flags().StringVarP(&addr, "addr", "",
+		pkg.DefaultServeAddr,
+		"Specify the server listen address",
+	)
+	cmd.Flags().DurationVarP(&debounce, "debounce", "",
+		pkg.DefaultDebounce,
+		"Specify how long to wait for resource changes to settle before sanitizing",
+	)
+	cmd.Flags().IntVarP(&maxScans, "max-scans", "",
+		pkg.DefaultMaxScans,
+		"Specify the maximum number of on demand scans running concurrently",
+	)
+	cmd.Flags().IntVarP(&scanRetention, "scans-retention", "",
+		pkg.DefaultScansRetention,
+		"Specify how many on demand scans are kept in memory",
+	)
+	cmd.Flags().StringVarP(flags.Spinach, "file", "f",
+		"",
+		"Use a spinach YAML configuration file",
+	)
+	cmd.Flags().StringSliceVarP(flags.Sections, "sections", "s",
+		[]string{},
+		"Specifies which resources to include in the scan ie -s po,svc",
+	)
+	cmd.Flags().StringVarP(flags.LintLevel, "lint", "l",
+		"ok",
+		"Specify a lint level (ok, info, warn, error)",
+	)
+	cmd.Flags().BoolVarP(flags.CheckOverAllocs, "over-allocs", "",
+		false,
+		"Check for cpu/memory over allocations",
+	)
+	cmd.Flags().StringVarP(flags.InClusterName, "cluster-name", "",
+		"",
+		"Specificy a cluster name when running popeye in cluster",
+	)
+	cmd.Flags().StringVarP(flags.TargetVersion, "target-version", "",
+		"",
+		"Report resources using apis removed in the given Kubernetes version ie --target-version 1.25",
+	)
+
+	return &cmd
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/derailed/popeye/pkg"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+func snapshotCmd() *cobra.Command {
+	var out string
+	cmd := cobra.Command{
+		Use:   "snapshot",
+		Short: "Captures a cluster snapshot",
+		Long:  "Captures the scanned cluster resources, metrics and server version into an archive for later replay",
+		Run: func(cmd *cobra.Command, args []string) {
+			flags.StandAlone = true
+			popeye, err := pkg.NewPopeye(flags, &log.Logger)
+			if err != nil {
+				bomb(fmt.Sprintf("Popeye configuration load failed %v", err))
+			}
+			if err := popeye.Snapshot(out); err != nil {
+				bomb(err.Error())
+			}
+			fmt.Println(out)
+		},
+	}
+	cmd.Flags().StringVarP(&out, "out", "o",
+		"cluster.tgz",
+		"Specify the snapshot archive path",
+	)
+
+	return &cmd
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/derailed/popeye/internal/webhook"
+	"github.com/derailed/popeye/pkg"
+	"github.com/derailed/popeye/pkg/config"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+func webhookCmd() *cobra.Command {
+	var (
+		addr, certFile, keyFile, severity string
+		codes                             []int
+	)
+	cmd := cobra.Command{
+		Use:   "webhook",
+		Short: "Serves a validating admission webhook",
+		Long:  "Sanitizes incoming Pods, Deployments, StatefulSets and DaemonSets at admission time",
+		Run: func(cmd *cobra.Command, args []string) {
+			if severity != "info" && severity != "warn" && severity != "error" {
+				bomb(fmt.Sprintf("Invalid severity %q. Please use info, warn or error", severity))
+			}
+			if (certFile == "") != (keyFile == "") {
+				bomb("Please set both '--tls-cert-file' and '--tls-key-file'.")
+			}
+			popeye, err := pkg.NewPopeye(flags, &log.Logger)
+			if err != nil {
+				bomb(fmt.Sprintf("Popeye configuration load failed %v", err))
+			}
+			ids := make([]config.ID, 0, len(codes))
+			for _, c := range codes {
+				ids = append(ids, config.ID(c))
+			}
+			h, err := popeye.Webhook(config.ToIssueLevel(&severity), ids)
+			if err != nil {
+				bomb(err.Error())
+			}
+			if err := serveWebhook(addr, certFile, keyFile, h); err != nil {
+				bomb(err.Error())
+			}
+		},
+	}
+	cmd.Flags().StringVarP(&addr, "addr", "",
+		":8443",
+		"Specify the webhook listen address",
+	)
+	cmd.Flags().StringVarP(&certFile, "tls-cert-file", "",
+		"",
+		"Specify the webhook TLS certificate file",
+	)
+	cmd.Flags().StringVarP(&keyFile, "tls-key-file", "",
+		"",
+		"Specify the webhook TLS private key file",
+	)
+	cmd.Flags().StringVarP(&severity, "severity", "",
+		"error",
+		"Specify the minimum issue severity denying an admission (info, warn, error). Lower severities are returned as warnings",
+	)
+	defaults := make([]int, 0, len(webhook.DefaultCodes))
+	for _, c := range webhook.DefaultCodes {
+		defaults = append(defaults, int(c))
+	}
+	cmd.Flags().IntSliceVarP(&codes, "codes", "",
+		defaults,
+		"Specify the sanitizer codes evaluated at admission",
+	)
+	cmd.Flags().StringVarP(flags.Spinach, "file", "f",
+		"",
+		"Use a spinach YAML configuration file",
+	)
+
+	return &cmd
+}
+
+func serveWebhook(addr, certFile, keyFile string, h http.Handler) error {
+	mux := http.NewServeMux()
+	mux.Handle("/validate", h)
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+	srv := http.Server{Addr: addr, Handler: mux}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	errChan := make(chan error, 1)
+	go func() {
+		log.Info().Msgf("Popeye webhook serving on %s", addr)
+		if certFile == "" {
+			log.Warn().Msg("No TLS certificate provided. Serving plain http")
+			errChan <- srv.ListenAndServe()
+			return
+		}
+		errChan <- srv.ListenAndServeTLS(certFile, keyFile)
+	}()
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-ctx.Done():
+	}
+	tctx, tcancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer tcancel()
+	if err := srv.Shutdown(tctx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ go 1.18
 require (
 	github.com/aws/aws-sdk-go v1.35.21
 	github.com/fvbommel/sortorder v1.0.1
+	github.com/googleapis/gnostic v0.5.5
 	github.com/magiconair/properties v1.8.5
 	github.com/prometheus/client_golang v1.11.0
 	github.com/prometheus/common v0.28.0
@@ -47,7 +48,6 @@ require (
 	github.com/google/gofuzz v1.1.0 // indirect
 	github.com/google/shlex v0.0.0-20191202100458-e7afc7fbc510 // indirect
 	github.com/google/uuid v1.1.2 // indirect
-	github.com/googleapis/gnostic v0.5.5 // indirect
 	github.com/gregjones/httpcache v0.0.0-20180305231024-9cad4c3443a7 // indirect
 	github.com/imdario/mergo v0.3.5 // indirect
 	github.com/inconshreveable/mousetrap v1.0.0 // indirect
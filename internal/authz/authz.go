@@ -0,0 +1,303 @@
+package authz
+
+import (
+	"sort"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// ClusterScope tracks grants applying to all namespaces.
+const ClusterScope = "*"
+
+// Lister lists rbac resources.
+type Lister interface {
+	ListClusterRoles() map[string]*rbacv1.ClusterRole
+	ListRoles() map[string]*rbacv1.Role
+	ListClusterRoleBindings() map[string]*rbacv1.ClusterRoleBinding
+	ListRoleBindings() map[string]*rbacv1.RoleBinding
+}
+
+// Grant represents a rule granted to a subject along with the binding path granting it.
+type Grant struct {
+	Subject   Subject           `json:"subject"`
+	Namespace string            `json:"namespace"`
+	Path      []string          `json:"path"`
+	Rule      rbacv1.PolicyRule `json:"rule"`
+}
+
+// Resolver resolves bindings to their effective rules.
+type Resolver struct {
+	Lister
+}
+
+// NewResolver returns a new instance.
+func NewResolver(l Lister) *Resolver {
+	return &Resolver{Lister: l}
+}
+
+// WhoCan returns all grants allowing the verb on the resource in the given namespace.
+// An empty namespace considers bindings in all namespaces.
+func (r *Resolver) WhoCan(verb, resource, ns string) []Grant {
+	group, res, anyGroup := parseResource(resource)
+	var gg []Grant
+	for _, b := range r.bindings() {
+		if ns != "" && b.namespace != ClusterScope && b.namespace != ns {
+			continue
+		}
+		for _, rule := range r.rules(b) {
+			if !matchRule(rule.rule, verb, group, res, anyGroup) {
+				continue
+			}
+			for _, s := range b.subjects {
+				gg = append(gg, newGrant(s, b, rule))
+			}
+		}
+	}
+
+	return gg
+}
+
+// SubjectRules returns all grants bound to a subject either directly or via its implicit groups.
+func (r *Resolver) SubjectRules(s Subject) []Grant {
+	var gg []Grant
+	for _, b := range r.bindings() {
+		for _, bs := range b.subjects {
+			if !s.Matches(bs) {
+				continue
+			}
+			for _, rule := range r.rules(b) {
+				gg = append(gg, newGrant(bs, b, rule))
+			}
+		}
+	}
+
+	return gg
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+type binding struct {
+	kind, namespace, name string
+	ref                   rbacv1.RoleRef
+	subjects              []rbacv1.Subject
+}
+
+func (b binding) String() string {
+	if b.namespace == ClusterScope {
+		return b.kind + "/" + b.name
+	}
+
+	return b.kind + "/" + b.namespace + "/" + b.name
+}
+
+type sourcedRule struct {
+	path []string
+	rule rbacv1.PolicyRule
+}
+
+func newGrant(s rbacv1.Subject, b binding, r sourcedRule) Grant {
+	return Grant{
+		Subject:   toSubject(s),
+		Namespace: b.namespace,
+		Path:      append([]string{b.String()}, r.path...),
+		Rule:      r.rule,
+	}
+}
+
+// Bindings returns all cluster and namespaced bindings sorted by name.
+func (r *Resolver) bindings() []binding {
+	crbs := r.ListClusterRoleBindings()
+	bb := make([]binding, 0, len(crbs))
+	for _, k := range ClusterRoleBindingKeys(crbs) {
+		crb := crbs[k]
+		bb = append(bb, binding{
+			kind:      "ClusterRoleBinding",
+			namespace: ClusterScope,
+			name:      crb.Name,
+			ref:       crb.RoleRef,
+			subjects:  crb.Subjects,
+		})
+	}
+
+	rbs := r.ListRoleBindings()
+	for _, k := range RoleBindingKeys(rbs) {
+		rb := rbs[k]
+		bb = append(bb, binding{
+			kind:      "RoleBinding",
+			namespace: rb.Namespace,
+			name:      rb.Name,
+			ref:       rb.RoleRef,
+			subjects:  rb.Subjects,
+		})
+	}
+
+	return bb
+}
+
+// Rules returns the rules granted by a binding role reference.
+func (r *Resolver) rules(b binding) []sourcedRule {
+	switch b.ref.Kind {
+	case "ClusterRole":
+		return r.clusterRoleRules(b.ref.Name, nil, map[string]struct{}{})
+	case "Role":
+		ro, ok := r.ListRoles()[b.namespace+"/"+b.ref.Name]
+		if !ok {
+			return nil
+		}
+		path := []string{"Role/" + ro.Namespace + "/" + ro.Name}
+		rr := make([]sourcedRule, 0, len(ro.Rules))
+		for _, rule := range ro.Rules {
+			rr = append(rr, sourcedRule{path: path, rule: rule})
+		}
+		return rr
+	default:
+		return nil
+	}
+}
+
+// ClusterRoleRules returns a ClusterRole rules including the ones aggregated from
+// ClusterRoles matching its aggregation selectors.
+func (r *Resolver) clusterRoleRules(name string, path []string, seen map[string]struct{}) []sourcedRule {
+	if _, ok := seen[name]; ok {
+		return nil
+	}
+	seen[name] = struct{}{}
+	cr, ok := r.ListClusterRoles()[name]
+	if !ok {
+		return nil
+	}
+	path = append(append([]string{}, path...), "ClusterRole/"+name)
+
+	var rr []sourcedRule
+	if cr.AggregationRule != nil {
+		crs := r.ListClusterRoles()
+		for _, k := range crKeys(crs) {
+			if aggregates(cr.AggregationRule, crs[k]) {
+				rr = append(rr, r.clusterRoleRules(k, path, seen)...)
+			}
+		}
+	}
+	// The aggregation controller copies aggregated rules onto the ClusterRole.
+	// Only keep the ones not already attributed to an aggregated ClusterRole.
+	for _, rule := range cr.Rules {
+		if !hasRule(rr, rule) {
+			rr = append(rr, sourcedRule{path: path, rule: rule})
+		}
+	}
+
+	return rr
+}
+
+func aggregates(agg *rbacv1.AggregationRule, cr *rbacv1.ClusterRole) bool {
+	for i := range agg.ClusterRoleSelectors {
+		sel, err := metav1.LabelSelectorAsSelector(&agg.ClusterRoleSelectors[i])
+		if err != nil || sel.Empty() {
+			continue
+		}
+		if sel.Matches(labels.Set(cr.Labels)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasRule(rr []sourcedRule, rule rbacv1.PolicyRule) bool {
+	for _, r := range rr {
+		if equality.Semantic.DeepEqual(r.rule, rule) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseResource splits a resource[.group][/subresource] spec. A resource without a group
+// matches rules in any api group.
+func parseResource(s string) (string, string, bool) {
+	res, sub := s, ""
+	if i := strings.Index(s, "/"); i >= 0 {
+		res, sub = s[:i], s[i:]
+	}
+	tokens := strings.SplitN(res, ".", 2)
+	if len(tokens) == 1 {
+		return "", res + sub, true
+	}
+	if tokens[1] == "core" {
+		tokens[1] = ""
+	}
+
+	return tokens[1], tokens[0] + sub, false
+}
+
+func matchRule(r rbacv1.PolicyRule, verb, group, res string, anyGroup bool) bool {
+	if !In(r.Verbs, rbacv1.VerbAll) && !In(r.Verbs, verb) {
+		return false
+	}
+	if !anyGroup && !In(r.APIGroups, rbacv1.APIGroupAll) && !In(r.APIGroups, group) {
+		return false
+	}
+
+	return MatchResource(r.Resources, res)
+}
+
+// MatchResource checks if a rule resources grant access to a resource or subresource.
+func MatchResource(rr []string, res string) bool {
+	if In(rr, rbacv1.ResourceAll) || In(rr, res) {
+		return true
+	}
+	tokens := strings.SplitN(res, "/", 2)
+	if len(tokens) < 2 {
+		return false
+	}
+
+	return In(rr, tokens[0]+"/*") || In(rr, "*/"+tokens[1])
+}
+
+// In checks if a string is in a list of strings.
+func In(ll []string, s string) bool {
+	for _, l := range ll {
+		if l == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ClusterRoleBindingKeys returns the ClusterRoleBindings keys sorted by name.
+func ClusterRoleBindingKeys(crbs map[string]*rbacv1.ClusterRoleBinding) []string {
+	kk := make([]string, 0, len(crbs))
+	for k := range crbs {
+		kk = append(kk, k)
+	}
+	sort.Strings(kk)
+
+	return kk
+}
+
+// RoleBindingKeys returns the RoleBindings keys sorted by name.
+func RoleBindingKeys(rbs map[string]*rbacv1.RoleBinding) []string {
+	kk := make([]string, 0, len(rbs))
+	for k := range rbs {
+		kk = append(kk, k)
+	}
+	sort.Strings(kk)
+
+	return kk
+}
+
+func crKeys(crs map[string]*rbacv1.ClusterRole) []string {
+	kk := make([]string, 0, len(crs))
+	for k := range crs {
+		kk = append(kk, k)
+	}
+	sort.Strings(kk)
+
+	return kk
+}
@@ -0,0 +1,291 @@
+package authz
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWhoCan(t *testing.T) {
+	uu := map[string]struct {
+		verb, res, ns string
+		e             []string
+	}{
+		"none": {
+			verb: "delete",
+			res:  "nodes",
+		},
+		"secrets": {
+			verb: "get",
+			res:  "secrets",
+			e: []string{
+				"Group:admins ClusterRoleBinding/crb1 -> ClusterRole/admin",
+				"User:fred RoleBinding/ns1/rb1 -> Role/ns1/ro1",
+				"ServiceAccount:ns2/sa1 RoleBinding/ns2/rb2 -> ClusterRole/admin",
+			},
+		},
+		"namespaced": {
+			verb: "get",
+			res:  "secrets",
+			ns:   "ns1",
+			e: []string{
+				"Group:admins ClusterRoleBinding/crb1 -> ClusterRole/admin",
+				"User:fred RoleBinding/ns1/rb1 -> Role/ns1/ro1",
+			},
+		},
+		"aggregated": {
+			verb: "create",
+			res:  "pods/exec",
+			e: []string{
+				"Group:admins ClusterRoleBinding/crb1 -> ClusterRole/admin -> ClusterRole/exec",
+				"ServiceAccount:ns2/sa1 RoleBinding/ns2/rb2 -> ClusterRole/admin -> ClusterRole/exec",
+			},
+		},
+		"groupMatch": {
+			verb: "list",
+			res:  "deployments.apps",
+			e: []string{
+				"Group:admins ClusterRoleBinding/crb1 -> ClusterRole/admin",
+				"ServiceAccount:ns2/sa1 RoleBinding/ns2/rb2 -> ClusterRole/admin",
+			},
+		},
+		"groupMismatch": {
+			verb: "list",
+			res:  "deployments.extensions",
+		},
+	}
+
+	r := NewResolver(makeLister())
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, grantPaths(r.WhoCan(u.verb, u.res, u.ns)))
+		})
+	}
+}
+
+func TestSubjectRules(t *testing.T) {
+	uu := map[string]struct {
+		subject Subject
+		e       []string
+	}{
+		"user": {
+			subject: Subject{Kind: rbacv1.UserKind, Name: "fred"},
+			e: []string{
+				"Group:system:authenticated ClusterRoleBinding/crb2 -> ClusterRole/view",
+				"User:fred RoleBinding/ns1/rb1 -> Role/ns1/ro1",
+			},
+		},
+		"anonymous": {
+			subject: Subject{Kind: rbacv1.UserKind, Name: "system:anonymous"},
+		},
+		"serviceaccount": {
+			subject: Subject{Kind: rbacv1.ServiceAccountKind, Namespace: "ns2", Name: "sa1"},
+			e: []string{
+				"Group:system:authenticated ClusterRoleBinding/crb2 -> ClusterRole/view",
+				"ServiceAccount:ns2/sa1 RoleBinding/ns2/rb2 -> ClusterRole/admin -> ClusterRole/exec",
+				"ServiceAccount:ns2/sa1 RoleBinding/ns2/rb2 -> ClusterRole/admin",
+			},
+		},
+		"group": {
+			subject: Subject{Kind: rbacv1.GroupKind, Name: "admins"},
+			e: []string{
+				"Group:admins ClusterRoleBinding/crb1 -> ClusterRole/admin -> ClusterRole/exec",
+				"Group:admins ClusterRoleBinding/crb1 -> ClusterRole/admin",
+			},
+		},
+	}
+
+	r := NewResolver(makeLister())
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, grantPaths(r.SubjectRules(u.subject)))
+		})
+	}
+}
+
+func TestParseSubject(t *testing.T) {
+	uu := map[string]struct {
+		s   string
+		e   Subject
+		err string
+	}{
+		"user": {
+			s: "user:fred",
+			e: Subject{Kind: rbacv1.UserKind, Name: "fred"},
+		},
+		"group": {
+			s: "Group:system:masters",
+			e: Subject{Kind: rbacv1.GroupKind, Name: "system:masters"},
+		},
+		"sa": {
+			s: "sa:ns1/default",
+			e: Subject{Kind: rbacv1.ServiceAccountKind, Namespace: "ns1", Name: "default"},
+		},
+		"noName": {
+			s:   "user:",
+			err: `invalid subject "user:". Expecting kind:name ie user:fred, group:devs or sa:ns/name`,
+		},
+		"noNamespace": {
+			s:   "sa:default",
+			err: `invalid serviceaccount "default". Expecting sa:ns/name`,
+		},
+		"badKind": {
+			s:   "bozo:fred",
+			err: `invalid subject kind "bozo". Expecting user, group or sa`,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			s, err := ParseSubject(u.s)
+			if u.err != "" {
+				assert.EqualError(t, err, u.err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, u.e, s)
+		})
+	}
+}
+
+func TestDump(t *testing.T) {
+	gg := NewResolver(makeLister()).WhoCan("get", "secrets", "ns1")
+
+	var table bytes.Buffer
+	assert.Nil(t, Dump(&table, TableFormat, gg))
+	assert.Equal(t, `SUBJECT       NAMESPACE  VERBS  RESOURCES                                                         PATH
+Group:admins  *          *      pods,deployments,secrets,pods.apps,deployments.apps,secrets.apps  ClusterRoleBinding/crb1 -> ClusterRole/admin
+User:fred     ns1        get    secrets                                                           RoleBinding/ns1/rb1 -> Role/ns1/ro1
+`, table.String())
+
+	var js bytes.Buffer
+	assert.Nil(t, Dump(&js, JSONFormat, nil))
+	assert.Equal(t, "[]\n", js.String())
+
+	assert.EqualError(t, Dump(&js, "yaml", gg), `invalid output format "yaml". Expecting table or json`)
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+type lister struct{}
+
+func makeLister() lister {
+	return lister{}
+}
+
+func (lister) ListClusterRoles() map[string]*rbacv1.ClusterRole {
+	return map[string]*rbacv1.ClusterRole{
+		"admin": {
+			ObjectMeta: metav1.ObjectMeta{Name: "admin"},
+			AggregationRule: &rbacv1.AggregationRule{
+				ClusterRoleSelectors: []metav1.LabelSelector{
+					{MatchLabels: map[string]string{"aggregate-to-admin": "true"}},
+				},
+			},
+			Rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"pods/exec"}, Verbs: []string{"create"}},
+				{APIGroups: []string{"", "apps"}, Resources: []string{"pods", "deployments", "secrets"}, Verbs: []string{"*"}},
+			},
+		},
+		"exec": {
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "exec",
+				Labels: map[string]string{"aggregate-to-admin": "true"},
+			},
+			Rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"pods/exec"}, Verbs: []string{"create"}},
+			},
+		},
+		"view": {
+			ObjectMeta: metav1.ObjectMeta{Name: "view"},
+			Rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"get"}},
+			},
+		},
+	}
+}
+
+func (lister) ListRoles() map[string]*rbacv1.Role {
+	return map[string]*rbacv1.Role{
+		"ns1/ro1": {
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "ro1"},
+			Rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get"}},
+			},
+		},
+	}
+}
+
+func (lister) ListClusterRoleBindings() map[string]*rbacv1.ClusterRoleBinding {
+	return map[string]*rbacv1.ClusterRoleBinding{
+		"crb1": {
+			ObjectMeta: metav1.ObjectMeta{Name: "crb1"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "admin"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "admins"}},
+		},
+		"crb2": {
+			ObjectMeta: metav1.ObjectMeta{Name: "crb2"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "view"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "system:authenticated"}},
+		},
+		"crb3": {
+			ObjectMeta: metav1.ObjectMeta{Name: "crb3"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "missing"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "fred"}},
+		},
+	}
+}
+
+func (lister) ListRoleBindings() map[string]*rbacv1.RoleBinding {
+	return map[string]*rbacv1.RoleBinding{
+		"ns1/rb1": {
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "rb1"},
+			RoleRef:    rbacv1.RoleRef{Kind: "Role", Name: "ro1"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "fred"}},
+		},
+		"ns2/rb2": {
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns2", Name: "rb2"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "admin"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Namespace: "ns2", Name: "sa1"}},
+		},
+	}
+}
+
+func grantPaths(gg []Grant) []string {
+	var ss []string
+	for _, g := range gg {
+		p := g.Subject.String()
+		for i, s := range g.Path {
+			if i == 0 {
+				p += " " + s
+				continue
+			}
+			p += " -> " + s
+		}
+		ss = append(ss, p)
+	}
+
+	return ss
+}
+
+func TestIn(t *testing.T) {
+	uu := []struct {
+		l []string
+		s string
+		e bool
+	}{
+		{[]string{"a", "b", "c"}, "a", true},
+		{[]string{"a", "b", "c"}, "z", false},
+	}
+
+	for _, u := range uu {
+		assert.Equal(t, u.e, In(u.l, u.s))
+	}
+}
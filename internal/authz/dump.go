@@ -0,0 +1,78 @@
+package authz
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+const (
+	// TableFormat renders grants as a table.
+	TableFormat = "table"
+
+	// JSONFormat renders grants as json.
+	JSONFormat = "json"
+)
+
+// Dump renders grants in the given format.
+func Dump(w io.Writer, format string, gg []Grant) error {
+	switch format {
+	case JSONFormat:
+		if gg == nil {
+			gg = []Grant{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(gg)
+	case TableFormat:
+		return dumpTable(w, gg)
+	default:
+		return fmt.Errorf("invalid output format %q. Expecting table or json", format)
+	}
+}
+
+func dumpTable(w io.Writer, gg []Grant) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SUBJECT\tNAMESPACE\tVERBS\tRESOURCES\tPATH")
+	for _, g := range gg {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+			g.Subject,
+			g.Namespace,
+			strings.Join(g.Rule.Verbs, ","),
+			resources(g.Rule),
+			strings.Join(g.Path, " -> "),
+		)
+	}
+
+	return tw.Flush()
+}
+
+// Resources returns a rule qualified resources or non resource urls.
+func resources(r rbacv1.PolicyRule) string {
+	if len(r.Resources) == 0 {
+		return strings.Join(r.NonResourceURLs, ",")
+	}
+	groups := r.APIGroups
+	if len(groups) == 0 {
+		groups = []string{""}
+	}
+	rr := make([]string, 0, len(r.Resources)*len(groups))
+	for _, g := range groups {
+		for _, res := range r.Resources {
+			if g != "" {
+				res += "." + g
+			}
+			rr = append(rr, res)
+		}
+	}
+	s := strings.Join(rr, ",")
+	if len(r.ResourceNames) > 0 {
+		s += "[" + strings.Join(r.ResourceNames, ",") + "]"
+	}
+
+	return s
+}
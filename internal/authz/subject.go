@@ -0,0 +1,84 @@
+package authz
+
+import (
+	"fmt"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+const (
+	authenticatedGroup   = "system:authenticated"
+	unauthenticatedGroup = "system:unauthenticated"
+	anonymousUser        = "system:anonymous"
+	serviceAccountsGroup = "system:serviceaccounts"
+)
+
+// Subject represents an rbac subject.
+type Subject struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// ParseSubject parses a kind:name subject spec ie user:fred, group:devs or sa:ns/name.
+func ParseSubject(s string) (Subject, error) {
+	tokens := strings.SplitN(s, ":", 2)
+	if len(tokens) < 2 || tokens[1] == "" {
+		return Subject{}, fmt.Errorf("invalid subject %q. Expecting kind:name ie user:fred, group:devs or sa:ns/name", s)
+	}
+	name := tokens[1]
+	switch strings.ToLower(tokens[0]) {
+	case "user", "u":
+		return Subject{Kind: rbacv1.UserKind, Name: name}, nil
+	case "group", "g":
+		return Subject{Kind: rbacv1.GroupKind, Name: name}, nil
+	case "serviceaccount", "sa":
+		nn := strings.SplitN(name, "/", 2)
+		if len(nn) < 2 || nn[0] == "" || nn[1] == "" {
+			return Subject{}, fmt.Errorf("invalid serviceaccount %q. Expecting sa:ns/name", name)
+		}
+		return Subject{Kind: rbacv1.ServiceAccountKind, Namespace: nn[0], Name: nn[1]}, nil
+	default:
+		return Subject{}, fmt.Errorf("invalid subject kind %q. Expecting user, group or sa", tokens[0])
+	}
+}
+
+// String returns the subject representation.
+func (s Subject) String() string {
+	if s.Kind == rbacv1.ServiceAccountKind {
+		return s.Kind + ":" + s.Namespace + "/" + s.Name
+	}
+
+	return s.Kind + ":" + s.Name
+}
+
+// Matches returns true if a binding subject refers to this subject or one of its implicit groups.
+func (s Subject) Matches(bs rbacv1.Subject) bool {
+	if bs.Kind == rbacv1.GroupKind && s.Kind != rbacv1.GroupKind {
+		return In(s.groups(), bs.Name)
+	}
+
+	return toSubject(bs) == s
+}
+
+// Groups returns the groups a user or serviceaccount implicitly belongs to.
+func (s Subject) groups() []string {
+	switch {
+	case s.Kind == rbacv1.ServiceAccountKind:
+		return []string{serviceAccountsGroup, serviceAccountsGroup + ":" + s.Namespace, authenticatedGroup}
+	case s.Name == anonymousUser:
+		return []string{unauthenticatedGroup}
+	default:
+		return []string{authenticatedGroup}
+	}
+}
+
+func toSubject(s rbacv1.Subject) Subject {
+	sub := Subject{Kind: s.Kind, Name: s.Name}
+	if s.Kind == rbacv1.ServiceAccountKind {
+		sub.Namespace = s.Namespace
+	}
+
+	return sub
+}
@@ -0,0 +1,23 @@
+package cache
+
+import (
+	batchv1 "k8s.io/api/batch/v1"
+)
+
+// CronJobKey tracks CronJob resource references
+const CronJobKey = "cj"
+
+// CronJob represents CronJob cache.
+type CronJob struct {
+	cjs map[string]*batchv1.CronJob
+}
+
+// NewCronJob returns a new CronJob cache.
+func NewCronJob(cjs map[string]*batchv1.CronJob) *CronJob {
+	return &CronJob{cjs: cjs}
+}
+
+// ListCronJobs returns all available CronJobs on the cluster.
+func (c *CronJob) ListCronJobs() map[string]*batchv1.CronJob {
+	return c.cjs
+}
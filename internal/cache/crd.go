@@ -0,0 +1,23 @@
+package cache
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// CustomResourceDefinitionKey tracks CustomResourceDefinition resource references
+const CustomResourceDefinitionKey = "crd"
+
+// CustomResourceDefinition represents CustomResourceDefinition cache.
+type CustomResourceDefinition struct {
+	crds map[string]*unstructured.Unstructured
+}
+
+// NewCustomResourceDefinition returns a new CustomResourceDefinition cache.
+func NewCustomResourceDefinition(crds map[string]*unstructured.Unstructured) *CustomResourceDefinition {
+	return &CustomResourceDefinition{crds: crds}
+}
+
+// ListCustomResourceDefinitions returns all available CustomResourceDefinitions on the cluster.
+func (c *CustomResourceDefinition) ListCustomResourceDefinitions() map[string]*unstructured.Unstructured {
+	return c.crds
+}
@@ -1,20 +1,20 @@
 package cache
 
 import (
-	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 )
 
 // HorizontalPodAutoscaler represents a collection of HorizontalPodAutoScalers available on a cluster.
 type HorizontalPodAutoscaler struct {
-	hpas map[string]*autoscalingv1.HorizontalPodAutoscaler
+	hpas map[string]*autoscalingv2.HorizontalPodAutoscaler
 }
 
 // NewHorizontalPodAutoscaler returns a new HorizontalPodAutoScaler.
-func NewHorizontalPodAutoscaler(svcs map[string]*autoscalingv1.HorizontalPodAutoscaler) *HorizontalPodAutoscaler {
+func NewHorizontalPodAutoscaler(svcs map[string]*autoscalingv2.HorizontalPodAutoscaler) *HorizontalPodAutoscaler {
 	return &HorizontalPodAutoscaler{svcs}
 }
 
 // ListHorizontalPodAutoscalers returns all available HorizontalPodAutoScalers on the cluster.
-func (h *HorizontalPodAutoscaler) ListHorizontalPodAutoscalers() map[string]*autoscalingv1.HorizontalPodAutoscaler {
+func (h *HorizontalPodAutoscaler) ListHorizontalPodAutoscalers() map[string]*autoscalingv2.HorizontalPodAutoscaler {
 	return h.hpas
 }
@@ -0,0 +1,23 @@
+package cache
+
+import (
+	netv1 "k8s.io/api/networking/v1"
+)
+
+// IngressClassKey tracks IngressClass resource references
+const IngressClassKey = "ingclass"
+
+// IngressClass represents IngressClass cache.
+type IngressClass struct {
+	ics map[string]*netv1.IngressClass
+}
+
+// NewIngressClass returns a new IngressClass cache.
+func NewIngressClass(ics map[string]*netv1.IngressClass) *IngressClass {
+	return &IngressClass{ics: ics}
+}
+
+// ListIngressClasses returns all available IngressClasses on the cluster.
+func (i *IngressClass) ListIngressClasses() map[string]*netv1.IngressClass {
+	return i.ics
+}
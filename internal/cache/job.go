@@ -0,0 +1,23 @@
+package cache
+
+import (
+	batchv1 "k8s.io/api/batch/v1"
+)
+
+// JobKey tracks Job resource references
+const JobKey = "job"
+
+// Job represents Job cache.
+type Job struct {
+	jobs map[string]*batchv1.Job
+}
+
+// NewJob returns a new Job cache.
+func NewJob(jobs map[string]*batchv1.Job) *Job {
+	return &Job{jobs: jobs}
+}
+
+// ListJobs returns all available Jobs on the cluster.
+func (j *Job) ListJobs() map[string]*batchv1.Job {
+	return j.jobs
+}
@@ -0,0 +1,23 @@
+package cache
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// ResourceQuotaKey tracks ResourceQuota resource references
+const ResourceQuotaKey = "quota"
+
+// ResourceQuota represents ResourceQuota cache.
+type ResourceQuota struct {
+	quotas map[string]*v1.ResourceQuota
+}
+
+// NewResourceQuota returns a new ResourceQuota cache.
+func NewResourceQuota(quotas map[string]*v1.ResourceQuota) *ResourceQuota {
+	return &ResourceQuota{quotas: quotas}
+}
+
+// ListResourceQuotas returns all available ResourceQuotas on the cluster.
+func (r *ResourceQuota) ListResourceQuotas() map[string]*v1.ResourceQuota {
+	return r.quotas
+}
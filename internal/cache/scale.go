@@ -0,0 +1,27 @@
+package cache
+
+import (
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+)
+
+// Scale represents a collection of scale subresources keyed by their target.
+type Scale struct {
+	scales map[string]*autoscalingv1.Scale
+}
+
+// NewScale returns a new Scale.
+func NewScale(ss map[string]*autoscalingv1.Scale) *Scale {
+	return &Scale{scales: ss}
+}
+
+// ListScales returns all resolved scale subresources. A nil map indicates
+// scale subresources could not be resolved ie offline scans while a nil
+// scale indicates its target could not be resolved.
+func (s *Scale) ListScales() map[string]*autoscalingv1.Scale {
+	return s.scales
+}
+
+// ScaleFQN returns a scale target identifier.
+func ScaleFQN(ns, kind, n string) string {
+	return FQN(ns, kind+"/"+n)
+}
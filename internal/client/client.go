@@ -16,6 +16,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/cache"
 	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/discovery/cached/disk"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
@@ -264,7 +265,7 @@ func (a *APIClient) RestConfig() (*restclient.Config, error) {
 }
 
 // CachedDiscovery returns a cached discovery client.
-func (a *APIClient) CachedDiscovery() (*disk.CachedDiscoveryClient, error) {
+func (a *APIClient) CachedDiscovery() (discovery.CachedDiscoveryInterface, error) {
 	a.mx.Lock()
 	defer a.mx.Unlock()
 
@@ -303,7 +304,7 @@ func (a *APIClient) DynDial() (dynamic.Interface, error) {
 }
 
 // MXDial returns a handle to the metrics server.
-func (a *APIClient) MXDial() (*versioned.Clientset, error) {
+func (a *APIClient) MXDial() (versioned.Interface, error) {
 	a.mx.Lock()
 	defer a.mx.Unlock()
 
@@ -316,9 +317,10 @@ func (a *APIClient) MXDial() (*versioned.Clientset, error) {
 	}
 	if a.mxsClient, err = versioned.NewForConfig(rc); err != nil {
 		log.Error().Err(err)
+		return nil, err
 	}
 
-	return a.mxsClient, err
+	return a.mxsClient, nil
 }
 
 func (a *APIClient) checkCacheBool(key string) (state bool, ok bool) {
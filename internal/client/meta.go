@@ -1,6 +1,8 @@
 package client
 
 import (
+	"sync"
+
 	"github.com/derailed/popeye/types"
 )
 
@@ -17,8 +19,12 @@ func newMeta() Meta {
 	return make(map[string][]Schema)
 }
 
-// Resources tracks dictionary of resources.
-var Resources = newMeta()
+var (
+	// Resources tracks dictionary of resources.
+	Resources = newMeta()
+
+	resourcesMX sync.Mutex
+)
 
 // Load loads resource meta from server.
 func Load(f types.Factory) error {
@@ -31,6 +37,8 @@ func Load(f types.Factory) error {
 		return err
 	}
 
+	resourcesMX.Lock()
+	defer resourcesMX.Unlock()
 	for _, r := range rr {
 		for _, res := range r.APIResources {
 			gvr := FromGVAndR(r.GroupVersion, res.Name)
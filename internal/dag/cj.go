@@ -0,0 +1,62 @@
+package dag
+
+import (
+	"context"
+	"errors"
+
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/dao"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ListCronJobs list all included CronJobs.
+func ListCronJobs(ctx context.Context) (map[string]*batchv1.CronJob, error) {
+	return listAllCronJobs(ctx)
+}
+
+// ListAllCronJobs fetch all CronJobs on the cluster.
+func listAllCronJobs(ctx context.Context) (map[string]*batchv1.CronJob, error) {
+	ll, err := fetchCronJobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cjs := make(map[string]*batchv1.CronJob, len(ll.Items))
+	for i := range ll.Items {
+		cjs[metaFQN(ll.Items[i].ObjectMeta)] = &ll.Items[i]
+	}
+
+	return cjs, nil
+}
+
+// FetchCronJobs retrieves all CronJobs on the cluster.
+func fetchCronJobs(ctx context.Context) (*batchv1.CronJobList, error) {
+	f, cfg := mustExtractFactory(ctx), mustExtractConfig(ctx)
+	if cfg.Flags.StandAlone {
+		dial, err := f.Client().Dial()
+		if err != nil {
+			return nil, err
+		}
+		return dial.BatchV1().CronJobs(f.Client().ActiveNamespace()).List(ctx, metav1.ListOptions{})
+	}
+
+	var res dao.Resource
+	res.Init(f, client.NewGVR("batch/v1/cronjobs"))
+	oo, err := res.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var ll batchv1.CronJobList
+	for _, o := range oo {
+		var cj batchv1.CronJob
+		err = runtime.DefaultUnstructuredConverter.FromUnstructured(o.(*unstructured.Unstructured).Object, &cj)
+		if err != nil {
+			return nil, errors.New("expecting cronjob resource")
+		}
+		ll.Items = append(ll.Items, cj)
+	}
+
+	return &ll, nil
+}
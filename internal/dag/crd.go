@@ -0,0 +1,63 @@
+package dag
+
+import (
+	"context"
+	"errors"
+
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/dao"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// CustomResourceDefinitionGVR tracks crd specification.
+var CustomResourceDefinitionGVR = client.NewGVR("apiextensions.k8s.io/v1/customresourcedefinitions")
+
+// ListCustomResourceDefinitions list all included CustomResourceDefinitions.
+func ListCustomResourceDefinitions(ctx context.Context) (map[string]*unstructured.Unstructured, error) {
+	return listAllCustomResourceDefinitions(ctx)
+}
+
+// ListAllCustomResourceDefinitions fetch all CustomResourceDefinitions on the cluster.
+func listAllCustomResourceDefinitions(ctx context.Context) (map[string]*unstructured.Unstructured, error) {
+	ll, err := fetchCustomResourceDefinitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	crds := make(map[string]*unstructured.Unstructured, len(ll.Items))
+	for i := range ll.Items {
+		crds[client.FQN(ll.Items[i].GetNamespace(), ll.Items[i].GetName())] = &ll.Items[i]
+	}
+
+	return crds, nil
+}
+
+// FetchCustomResourceDefinitions retrieves all CustomResourceDefinitions on the cluster.
+// No typed client is available for crds, hence these are served unstructured.
+func fetchCustomResourceDefinitions(ctx context.Context) (*unstructured.UnstructuredList, error) {
+	f, cfg := mustExtractFactory(ctx), mustExtractConfig(ctx)
+	if cfg.Flags.StandAlone {
+		dial, err := f.Client().DynDial()
+		if err != nil {
+			return nil, err
+		}
+		return dial.Resource(CustomResourceDefinitionGVR.GVR()).List(ctx, metav1.ListOptions{})
+	}
+
+	var res dao.Resource
+	res.Init(f, CustomResourceDefinitionGVR)
+	oo, err := res.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var ll unstructured.UnstructuredList
+	for _, o := range oo {
+		crd, ok := o.(*unstructured.Unstructured)
+		if !ok {
+			return nil, errors.New("expecting crd resource")
+		}
+		ll.Items = append(ll.Items, *crd)
+	}
+
+	return &ll, nil
+}
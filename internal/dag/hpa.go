@@ -2,28 +2,40 @@ package dag
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 
 	"github.com/derailed/popeye/internal/client"
 	"github.com/derailed/popeye/internal/dao"
+	"github.com/derailed/popeye/types"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+const (
+	// HpaConditionsAnnotation tracks autoscaling/v1 HPA conditions.
+	hpaConditionsAnnotation = "autoscaling.alpha.kubernetes.io/conditions"
+
+	// HpaV2Minor tracks the first cluster minor serving autoscaling/v2.
+	hpaV2Minor = 23
+)
+
 // ListHorizontalPodAutoscalers list all included HorizontalPodAutoscalers.
-func ListHorizontalPodAutoscalers(ctx context.Context) (map[string]*autoscalingv1.HorizontalPodAutoscaler, error) {
+func ListHorizontalPodAutoscalers(ctx context.Context) (map[string]*autoscalingv2.HorizontalPodAutoscaler, error) {
 	return listAllHorizontalPodAutoscalers(ctx)
 }
 
 // ListAllHorizontalPodAutoscalers fetch all HorizontalPodAutoscalers on the cluster.
-func listAllHorizontalPodAutoscalers(ctx context.Context) (map[string]*autoscalingv1.HorizontalPodAutoscaler, error) {
+func listAllHorizontalPodAutoscalers(ctx context.Context) (map[string]*autoscalingv2.HorizontalPodAutoscaler, error) {
 	ll, err := fetchHorizontalPodAutoscalers(ctx)
 	if err != nil {
 		return nil, err
 	}
-	hpas := make(map[string]*autoscalingv1.HorizontalPodAutoscaler, len(ll.Items))
+	hpas := make(map[string]*autoscalingv2.HorizontalPodAutoscaler, len(ll.Items))
 	for i := range ll.Items {
 		hpas[metaFQN(ll.Items[i].ObjectMeta)] = &ll.Items[i]
 	}
@@ -32,26 +44,42 @@ func listAllHorizontalPodAutoscalers(ctx context.Context) (map[string]*autoscali
 }
 
 // FetchHorizontalPodAutoscalers retrieves all HorizontalPodAutoscalers on the cluster.
-func fetchHorizontalPodAutoscalers(ctx context.Context) (*autoscalingv1.HorizontalPodAutoscalerList, error) {
+// Clusters not serving autoscaling/v2 are read as autoscaling/v1 and converted.
+func fetchHorizontalPodAutoscalers(ctx context.Context) (*autoscalingv2.HorizontalPodAutoscalerList, error) {
 	f, cfg := mustExtractFactory(ctx), mustExtractConfig(ctx)
+	v2 := servesHPAv2(f)
 	if cfg.Flags.StandAlone {
 		dial, err := f.Client().Dial()
 		if err != nil {
 			return nil, err
 		}
-		return dial.AutoscalingV1().HorizontalPodAutoscalers(f.Client().ActiveNamespace()).List(ctx, metav1.ListOptions{})
+		if v2 {
+			return dial.AutoscalingV2().HorizontalPodAutoscalers(f.Client().ActiveNamespace()).List(ctx, metav1.ListOptions{})
+		}
+		ll, err := dial.AutoscalingV1().HorizontalPodAutoscalers(f.Client().ActiveNamespace()).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		var res autoscalingv2.HorizontalPodAutoscalerList
+		for i := range ll.Items {
+			res.Items = append(res.Items, hpaV1ToV2(&ll.Items[i]))
+		}
+		return &res, nil
 	}
 
+	gvr := "autoscaling/v1/horizontalpodautoscalers"
+	if v2 {
+		gvr = "autoscaling/v2/horizontalpodautoscalers"
+	}
 	var res dao.Resource
-	res.Init(f, client.NewGVR("autoscaling/v1/horizontalpodautoscalers"))
+	res.Init(f, client.NewGVR(gvr))
 	oo, err := res.List(ctx)
 	if err != nil {
 		return nil, err
 	}
-	var ll autoscalingv1.HorizontalPodAutoscalerList
+	var ll autoscalingv2.HorizontalPodAutoscalerList
 	for _, o := range oo {
-		var hpa autoscalingv1.HorizontalPodAutoscaler
-		err = runtime.DefaultUnstructuredConverter.FromUnstructured(o.(*unstructured.Unstructured).Object, &hpa)
+		hpa, err := toHPA(o.(*unstructured.Unstructured))
 		if err != nil {
 			return nil, errors.New("expecting hpa resource")
 		}
@@ -60,3 +88,81 @@ func fetchHorizontalPodAutoscalers(ctx context.Context) (*autoscalingv1.Horizont
 
 	return &ll, nil
 }
+
+// HpaV1ToV2 converts an autoscaling/v1 HPA to its autoscaling/v2 representation.
+// The declared api version is retained.
+func hpaV1ToV2(hpa *autoscalingv1.HorizontalPodAutoscaler) autoscalingv2.HorizontalPodAutoscaler {
+	res := autoscalingv2.HorizontalPodAutoscaler{
+		TypeMeta:   hpa.TypeMeta,
+		ObjectMeta: hpa.ObjectMeta,
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: hpa.Spec.ScaleTargetRef.APIVersion,
+				Kind:       hpa.Spec.ScaleTargetRef.Kind,
+				Name:       hpa.Spec.ScaleTargetRef.Name,
+			},
+			MinReplicas: hpa.Spec.MinReplicas,
+			MaxReplicas: hpa.Spec.MaxReplicas,
+		},
+		Status: autoscalingv2.HorizontalPodAutoscalerStatus{
+			ObservedGeneration: hpa.Status.ObservedGeneration,
+			LastScaleTime:      hpa.Status.LastScaleTime,
+			CurrentReplicas:    hpa.Status.CurrentReplicas,
+			DesiredReplicas:    hpa.Status.DesiredReplicas,
+		},
+	}
+	if hpa.Spec.TargetCPUUtilizationPercentage != nil {
+		res.Spec.Metrics = []autoscalingv2.MetricSpec{
+			{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Name: v1.ResourceCPU,
+					Target: autoscalingv2.MetricTarget{
+						Type:               autoscalingv2.UtilizationMetricType,
+						AverageUtilization: hpa.Spec.TargetCPUUtilizationPercentage,
+					},
+				},
+			},
+		}
+	}
+	if raw, ok := hpa.Annotations[hpaConditionsAnnotation]; ok {
+		var cc []autoscalingv2.HorizontalPodAutoscalerCondition
+		if err := json.Unmarshal([]byte(raw), &cc); err == nil {
+			res.Status.Conditions = cc
+		}
+	}
+
+	return res
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func servesHPAv2(f types.Factory) bool {
+	info, err := f.Client().ServerVersion()
+	if err != nil {
+		return false
+	}
+	rev, err := client.NewRevision(info)
+	if err != nil {
+		return false
+	}
+
+	return rev.Minor >= hpaV2Minor
+}
+
+// ToHPA converts a v1, v2beta2 or v2 HPA resource to autoscaling/v2.
+func toHPA(o *unstructured.Unstructured) (autoscalingv2.HorizontalPodAutoscaler, error) {
+	if o.GetAPIVersion() == autoscalingv1.SchemeGroupVersion.String() {
+		var hpa autoscalingv1.HorizontalPodAutoscaler
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(o.Object, &hpa); err != nil {
+			return autoscalingv2.HorizontalPodAutoscaler{}, err
+		}
+		return hpaV1ToV2(&hpa), nil
+	}
+
+	var hpa autoscalingv2.HorizontalPodAutoscaler
+	err := runtime.DefaultUnstructuredConverter.FromUnstructured(o.Object, &hpa)
+
+	return hpa, err
+}
@@ -0,0 +1,77 @@
+package dag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestToHPA(t *testing.T) {
+	uu := map[string]struct {
+		o       map[string]interface{}
+		metrics int
+		conds   []autoscalingv2.HorizontalPodAutoscalerConditionType
+	}{
+		"v1": {
+			o: map[string]interface{}{
+				"apiVersion": "autoscaling/v1",
+				"kind":       "HorizontalPodAutoscaler",
+				"metadata": map[string]interface{}{
+					"name":      "h1",
+					"namespace": "default",
+					"annotations": map[string]interface{}{
+						hpaConditionsAnnotation: `[{"type": "ScalingLimited", "status": "True", "reason": "TooManyReplicas"}]`,
+					},
+				},
+				"spec": map[string]interface{}{
+					"maxReplicas":                    int64(3),
+					"scaleTargetRef":                 map[string]interface{}{"kind": "Deployment", "name": "d1"},
+					"targetCPUUtilizationPercentage": int64(80),
+				},
+			},
+			metrics: 1,
+			conds:   []autoscalingv2.HorizontalPodAutoscalerConditionType{autoscalingv2.ScalingLimited},
+		},
+		"v2": {
+			o: map[string]interface{}{
+				"apiVersion": "autoscaling/v2",
+				"kind":       "HorizontalPodAutoscaler",
+				"metadata":   map[string]interface{}{"name": "h1", "namespace": "default"},
+				"spec": map[string]interface{}{
+					"maxReplicas":    int64(3),
+					"scaleTargetRef": map[string]interface{}{"kind": "Deployment", "name": "d1"},
+					"metrics": []interface{}{
+						map[string]interface{}{
+							"type":     "Resource",
+							"resource": map[string]interface{}{"name": "memory", "target": map[string]interface{}{"type": "Utilization"}},
+						},
+					},
+				},
+			},
+			metrics: 1,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			hpa, err := toHPA(&unstructured.Unstructured{Object: u.o})
+
+			assert.Nil(t, err)
+			assert.Equal(t, u.o["apiVersion"], hpa.APIVersion)
+			assert.Equal(t, int32(3), hpa.Spec.MaxReplicas)
+			assert.Equal(t, "d1", hpa.Spec.ScaleTargetRef.Name)
+			assert.Equal(t, u.metrics, len(hpa.Spec.Metrics))
+			assert.Equal(t, autoscalingv2.UtilizationMetricType, hpa.Spec.Metrics[0].Resource.Target.Type)
+			var conds []autoscalingv2.HorizontalPodAutoscalerConditionType
+			for _, c := range hpa.Status.Conditions {
+				assert.Equal(t, v1.ConditionTrue, c.Status)
+				conds = append(conds, c.Type)
+			}
+			assert.Equal(t, u.conds, conds)
+		})
+	}
+}
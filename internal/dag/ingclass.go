@@ -0,0 +1,87 @@
+package dag
+
+import (
+	"context"
+	"errors"
+
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/dao"
+	"github.com/derailed/popeye/types"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// IngressClassMinor tracks the first cluster minor serving networking.k8s.io/v1 IngressClasses.
+const ingressClassMinor = 19
+
+// ListIngressClasses list all included IngressClasses.
+// Clusters not serving IngressClasses yield no classes.
+func ListIngressClasses(ctx context.Context) (map[string]*netv1.IngressClass, error) {
+	if !servesIngressClasses(mustExtractFactory(ctx)) {
+		return nil, nil
+	}
+
+	return listAllIngressClasses(ctx)
+}
+
+// ListAllIngressClasses fetch all IngressClasses on the cluster.
+func listAllIngressClasses(ctx context.Context) (map[string]*netv1.IngressClass, error) {
+	ll, err := fetchIngressClasses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ics := make(map[string]*netv1.IngressClass, len(ll.Items))
+	for i := range ll.Items {
+		ics[metaFQN(ll.Items[i].ObjectMeta)] = &ll.Items[i]
+	}
+
+	return ics, nil
+}
+
+// FetchIngressClasses retrieves all IngressClasses on the cluster.
+func fetchIngressClasses(ctx context.Context) (*netv1.IngressClassList, error) {
+	f, cfg := mustExtractFactory(ctx), mustExtractConfig(ctx)
+	if cfg.Flags.StandAlone {
+		dial, err := f.Client().Dial()
+		if err != nil {
+			return nil, err
+		}
+		return dial.NetworkingV1().IngressClasses().List(ctx, metav1.ListOptions{})
+	}
+
+	var res dao.Resource
+	res.Init(f, client.NewGVR("networking.k8s.io/v1/ingressclasses"))
+	oo, err := res.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var ll netv1.IngressClassList
+	for _, o := range oo {
+		var ic netv1.IngressClass
+		err = runtime.DefaultUnstructuredConverter.FromUnstructured(o.(*unstructured.Unstructured).Object, &ic)
+		if err != nil {
+			return nil, errors.New("expecting ingressclass resource")
+		}
+		ll.Items = append(ll.Items, ic)
+	}
+
+	return &ll, nil
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func servesIngressClasses(f types.Factory) bool {
+	info, err := f.Client().ServerVersion()
+	if err != nil {
+		return false
+	}
+	rev, err := client.NewRevision(info)
+	if err != nil {
+		return false
+	}
+
+	return rev.Minor >= ingressClassMinor
+}
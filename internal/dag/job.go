@@ -0,0 +1,62 @@
+package dag
+
+import (
+	"context"
+	"errors"
+
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/dao"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ListJobs list all included Jobs.
+func ListJobs(ctx context.Context) (map[string]*batchv1.Job, error) {
+	return listAllJobs(ctx)
+}
+
+// ListAllJobs fetch all Jobs on the cluster.
+func listAllJobs(ctx context.Context) (map[string]*batchv1.Job, error) {
+	ll, err := fetchJobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	jobs := make(map[string]*batchv1.Job, len(ll.Items))
+	for i := range ll.Items {
+		jobs[metaFQN(ll.Items[i].ObjectMeta)] = &ll.Items[i]
+	}
+
+	return jobs, nil
+}
+
+// FetchJobs retrieves all Jobs on the cluster.
+func fetchJobs(ctx context.Context) (*batchv1.JobList, error) {
+	f, cfg := mustExtractFactory(ctx), mustExtractConfig(ctx)
+	if cfg.Flags.StandAlone {
+		dial, err := f.Client().Dial()
+		if err != nil {
+			return nil, err
+		}
+		return dial.BatchV1().Jobs(f.Client().ActiveNamespace()).List(ctx, metav1.ListOptions{})
+	}
+
+	var res dao.Resource
+	res.Init(f, client.NewGVR("batch/v1/jobs"))
+	oo, err := res.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var ll batchv1.JobList
+	for _, o := range oo {
+		var job batchv1.Job
+		err = runtime.DefaultUnstructuredConverter.FromUnstructured(o.(*unstructured.Unstructured).Object, &job)
+		if err != nil {
+			return nil, errors.New("expecting job resource")
+		}
+		ll.Items = append(ll.Items, job)
+	}
+
+	return &ll, nil
+}
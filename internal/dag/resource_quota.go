@@ -0,0 +1,62 @@
+package dag
+
+import (
+	"context"
+	"errors"
+
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/dao"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ListResourceQuotas list all included ResourceQuotas.
+func ListResourceQuotas(ctx context.Context) (map[string]*v1.ResourceQuota, error) {
+	return listAllResourceQuotas(ctx)
+}
+
+// ListAllResourceQuotas fetch all ResourceQuotas on the cluster.
+func listAllResourceQuotas(ctx context.Context) (map[string]*v1.ResourceQuota, error) {
+	ll, err := fetchResourceQuotas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	quotas := make(map[string]*v1.ResourceQuota, len(ll.Items))
+	for i := range ll.Items {
+		quotas[metaFQN(ll.Items[i].ObjectMeta)] = &ll.Items[i]
+	}
+
+	return quotas, nil
+}
+
+// fetchResourceQuotas retrieves all ResourceQuotas on the cluster.
+func fetchResourceQuotas(ctx context.Context) (*v1.ResourceQuotaList, error) {
+	f, cfg := mustExtractFactory(ctx), mustExtractConfig(ctx)
+	if cfg.Flags.StandAlone {
+		dial, err := f.Client().Dial()
+		if err != nil {
+			return nil, err
+		}
+		return dial.CoreV1().ResourceQuotas(f.Client().ActiveNamespace()).List(ctx, metav1.ListOptions{})
+	}
+
+	var res dao.Resource
+	res.Init(f, client.NewGVR("v1/resourcequotas"))
+	oo, err := res.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var ll v1.ResourceQuotaList
+	for _, o := range oo {
+		var quota v1.ResourceQuota
+		err = runtime.DefaultUnstructuredConverter.FromUnstructured(o.(*unstructured.Unstructured).Object, &quota)
+		if err != nil {
+			return nil, errors.New("expecting resourcequota resource")
+		}
+		ll.Items = append(ll.Items, quota)
+	}
+
+	return &ll, nil
+}
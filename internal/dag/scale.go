@@ -0,0 +1,95 @@
+package dag
+
+import (
+	"context"
+	"errors"
+
+	"github.com/derailed/popeye/internal/cache"
+	"github.com/derailed/popeye/internal/offline"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/scale"
+)
+
+// ListScales resolves the scale subresource of HPA targets. Deployments and
+// StatefulSets are skipped as their replicas are tracked by their own caches.
+// Targets that do not exist are omitted while targets that could not be
+// resolved map to a nil scale. Offline connections yield no scales.
+func ListScales(ctx context.Context, hpas map[string]*autoscalingv2.HorizontalPodAutoscaler) (map[string]*autoscalingv1.Scale, error) {
+	if !hasScaledTargets(hpas) {
+		return nil, nil
+	}
+	f := mustExtractFactory(ctx)
+	cfg, err := f.Client().RestConfig()
+	if errors.Is(err, offline.ErrNoCluster) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	disco, err := f.Client().CachedDiscovery()
+	if err != nil {
+		return nil, err
+	}
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(disco)
+	sg, err := scale.NewForConfig(cfg, mapper, dynamic.LegacyAPIPathResolverFunc, scale.NewDiscoveryScaleKindResolver(disco))
+	if err != nil {
+		return nil, err
+	}
+
+	var errs error
+	ss := make(map[string]*autoscalingv1.Scale)
+	for _, hpa := range hpas {
+		ref := hpa.Spec.ScaleTargetRef
+		if !isScaledTarget(ref) {
+			continue
+		}
+		fqn := cache.ScaleFQN(hpa.Namespace, ref.Kind, ref.Name)
+		s, err := getScale(ctx, mapper, sg, hpa.Namespace, ref)
+		if kerrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil && errs == nil {
+			errs = err
+		}
+		ss[fqn] = s
+	}
+
+	return ss, errs
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func hasScaledTargets(hpas map[string]*autoscalingv2.HorizontalPodAutoscaler) bool {
+	for _, hpa := range hpas {
+		if isScaledTarget(hpa.Spec.ScaleTargetRef) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isScaledTarget(ref autoscalingv2.CrossVersionObjectReference) bool {
+	return ref.Kind != "Deployment" && ref.Kind != "StatefulSet"
+}
+
+func getScale(ctx context.Context, mapper meta.RESTMapper, sg scale.ScalesGetter, ns string, ref autoscalingv2.CrossVersionObjectReference) (*autoscalingv1.Scale, error) {
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return nil, err
+	}
+	m, err := mapper.RESTMapping(schema.GroupKind{Group: gv.Group, Kind: ref.Kind}, gv.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	return sg.Scales(ns).Get(ctx, m.Resource.GroupResource(), ref.Name, metav1.GetOptions{})
+}
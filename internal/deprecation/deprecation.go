@@ -0,0 +1,148 @@
+package deprecation
+
+import (
+	_ "embed"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+)
+
+// API represents a deprecated api version of a given kind.
+type API struct {
+	Group       string `yaml:"group"`
+	Version     string `yaml:"version"`
+	Kind        string `yaml:"kind"`
+	Deprecated  string `yaml:"deprecated"`
+	Removed     string `yaml:"removed"`
+	Replacement string `yaml:"replacement"`
+}
+
+// GroupVersion returns the api group/version.
+func (a API) GroupVersion() string {
+	if a.Group == "" {
+		return a.Version
+	}
+
+	return a.Group + "/" + a.Version
+}
+
+// DeprecatedIn returns true if the api is deprecated in the given version.
+func (a API) DeprecatedIn(v Version) bool {
+	d, err := ParseVersion(a.Deprecated)
+	if err != nil {
+		return true
+	}
+
+	return !v.Less(d)
+}
+
+// RemovedIn returns true if the api is no longer served in the given version.
+func (a API) RemovedIn(v Version) bool {
+	r, err := ParseVersion(a.Removed)
+	if err != nil {
+		return false
+	}
+
+	return !v.Less(r)
+}
+
+// Table represents a collection of deprecated apis.
+type Table struct {
+	APIs []API `yaml:"apis"`
+
+	index map[string]API
+}
+
+// Load retrieves the deprecated apis table from yaml file.
+func Load() (*Table, error) {
+	var t Table
+	if err := yaml.Unmarshal([]byte(apis), &t); err != nil {
+		return nil, err
+	}
+	t.index = make(map[string]API, len(t.APIs))
+	for _, a := range t.APIs {
+		t.index[key(a.GroupVersion(), a.Kind)] = a
+	}
+	sort.SliceStable(t.APIs, func(i, j int) bool {
+		return t.APIs[i].GroupVersion()+t.APIs[i].Kind < t.APIs[j].GroupVersion()+t.APIs[j].Kind
+	})
+
+	return &t, nil
+}
+
+var (
+	defaultTable *Table
+	defaultOnce  sync.Once
+)
+
+// Default returns the embedded deprecated apis table.
+func Default() *Table {
+	defaultOnce.Do(func() {
+		t, err := Load()
+		if err != nil {
+			panic(fmt.Sprintf("Invalid deprecated apis table -- %v", err))
+		}
+		defaultTable = t
+	})
+
+	return defaultTable
+}
+
+// Lookup returns the deprecated api matching the given api version and kind if any.
+func (t *Table) Lookup(apiVersion, kind string) (API, bool) {
+	a, ok := t.index[key(apiVersion, kind)]
+
+	return a, ok
+}
+
+// Version represents a Kubernetes minor release.
+type Version struct {
+	Major, Minor int
+}
+
+// ParseVersion parses a version of the form 1.22, v1.22 or v1.22.3.
+func ParseVersion(s string) (Version, error) {
+	tokens := strings.Split(strings.TrimPrefix(strings.TrimSpace(s), "v"), ".")
+	if len(tokens) < 2 {
+		return Version{}, fmt.Errorf("invalid version %q. Expecting major.minor", s)
+	}
+	major, err := strconv.Atoi(tokens[0])
+	if err != nil {
+		return Version{}, fmt.Errorf("invalid major version %q", s)
+	}
+	// Managed clusters may report minor versions such as 22+.
+	minor, err := strconv.Atoi(strings.TrimSuffix(tokens[1], "+"))
+	if err != nil {
+		return Version{}, fmt.Errorf("invalid minor version %q", s)
+	}
+
+	return Version{Major: major, Minor: minor}, nil
+}
+
+// Less returns true if the version precedes the given one.
+func (v Version) Less(o Version) bool {
+	if v.Major != o.Major {
+		return v.Major < o.Major
+	}
+
+	return v.Minor < o.Minor
+}
+
+// String returns a version representation.
+func (v Version) String() string {
+	return fmt.Sprintf("v%d.%d", v.Major, v.Minor)
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func key(gv, kind string) string {
+	return gv + ":" + kind
+}
+
+//go:embed assets/apis.yml
+var apis string
@@ -0,0 +1,126 @@
+package deprecation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	tt, err := Load()
+
+	assert.Nil(t, err)
+	assert.Equal(t, 50, len(tt.APIs))
+	for _, a := range tt.APIs {
+		assert.NotEmpty(t, a.Kind)
+		assert.NotEmpty(t, a.Replacement)
+		_, err := ParseVersion(a.Deprecated)
+		assert.Nil(t, err, a.GroupVersion()+"/"+a.Kind)
+		if a.Removed != "" {
+			_, err := ParseVersion(a.Removed)
+			assert.Nil(t, err, a.GroupVersion()+"/"+a.Kind)
+		}
+	}
+}
+
+func TestTableLookup(t *testing.T) {
+	uu := map[string]struct {
+		rev, kind string
+		ok        bool
+		e         string
+	}{
+		"removed": {
+			rev:  "extensions/v1beta1",
+			kind: "Ingress",
+			ok:   true,
+			e:    "networking.k8s.io/v1/ingresses",
+		},
+		"current": {
+			rev:  "networking.k8s.io/v1",
+			kind: "Ingress",
+		},
+		"wrongKind": {
+			rev:  "extensions/v1beta1",
+			kind: "CronJob",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			a, ok := Default().Lookup(u.rev, u.kind)
+			assert.Equal(t, u.ok, ok)
+			assert.Equal(t, u.e, a.Replacement)
+		})
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	uu := map[string]struct {
+		s   string
+		e   Version
+		err bool
+	}{
+		"plain":   {s: "1.25", e: Version{Major: 1, Minor: 25}},
+		"prefix":  {s: "v1.22", e: Version{Major: 1, Minor: 22}},
+		"patch":   {s: "v1.22.3", e: Version{Major: 1, Minor: 22}},
+		"managed": {s: "1.22+", e: Version{Major: 1, Minor: 22}},
+		"major":   {s: "1", err: true},
+		"toast":   {s: "fred.blee", err: true},
+		"empty":   {s: "", err: true},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			v, err := ParseVersion(u.s)
+			if u.err {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, u.e, v)
+		})
+	}
+}
+
+func TestAPIRemovedIn(t *testing.T) {
+	uu := map[string]struct {
+		api                 API
+		v                   Version
+		deprecated, removed bool
+	}{
+		"before": {
+			api: API{Deprecated: "1.21", Removed: "1.25"},
+			v:   Version{Major: 1, Minor: 20},
+		},
+		"deprecated": {
+			api:        API{Deprecated: "1.21", Removed: "1.25"},
+			v:          Version{Major: 1, Minor: 24},
+			deprecated: true,
+		},
+		"removed": {
+			api:        API{Deprecated: "1.21", Removed: "1.25"},
+			v:          Version{Major: 1, Minor: 25},
+			deprecated: true,
+			removed:    true,
+		},
+		"unscheduled": {
+			api:        API{Deprecated: "1.21"},
+			v:          Version{Major: 1, Minor: 30},
+			deprecated: true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.deprecated, u.api.DeprecatedIn(u.v))
+			assert.Equal(t, u.removed, u.api.RemovedIn(u.v))
+		})
+	}
+}
+
+func TestVersionString(t *testing.T) {
+	assert.Equal(t, "v1.25", Version{Major: 1, Minor: 25}.String())
+}
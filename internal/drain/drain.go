@@ -0,0 +1,205 @@
+package drain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	polv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Drain outcome statuses.
+const (
+	// StatusOK indicates the workload survives the drain.
+	StatusOK = "ok"
+
+	// StatusBlocked indicates a PDB blocks the workload eviction.
+	StatusBlocked = "blocked"
+
+	// StatusOutage indicates the workload loses all its ready replicas.
+	StatusOutage = "outage"
+
+	// StatusUnmanaged indicates a bare pod that will not be rescheduled.
+	StatusUnmanaged = "unmanaged"
+)
+
+const mirrorPodAnnotation = "kubernetes.io/config.mirror"
+
+// Lister lists the resources involved in a node drain.
+type Lister interface {
+	ListNodes() map[string]*v1.Node
+	ListPods() map[string]*v1.Pod
+	ListReplicaSets() map[string]*appsv1.ReplicaSet
+	ListPodDisruptionBudgets() map[string]*polv1beta1.PodDisruptionBudget
+}
+
+// Outcome represents the drain impact on a workload.
+type Outcome struct {
+	Namespace  string   `json:"namespace"`
+	Workload   string   `json:"workload"`
+	Evicted    int      `json:"evicted"`
+	Ready      int      `json:"ready"`
+	ReadyAfter int      `json:"readyAfter"`
+	BlockedBy  []string `json:"blockedBy,omitempty"`
+	Status     string   `json:"status"`
+}
+
+// Simulator simulates node drains.
+type Simulator struct {
+	Lister
+}
+
+// NewSimulator returns a new instance.
+func NewSimulator(l Lister) *Simulator {
+	return &Simulator{Lister: l}
+}
+
+// Drain simulates evicting all pods from a node and reports the impact on each workload.
+func (s *Simulator) Drain(node string) ([]Outcome, error) {
+	if _, ok := s.ListNodes()[node]; !ok {
+		return nil, fmt.Errorf("node %q not found", node)
+	}
+
+	oo := make(map[string]*Outcome)
+	evicted := make(map[string][]*v1.Pod)
+	for _, po := range s.ListPods() {
+		if !evictable(po) {
+			continue
+		}
+		kind, name := s.workload(po)
+		k := po.Namespace + "/" + kind + "/" + name
+		o, ok := oo[k]
+		if !ok {
+			o = &Outcome{Namespace: po.Namespace, Workload: kind + "/" + name}
+			oo[k] = o
+		}
+		ready := isReady(po)
+		if ready {
+			o.Ready++
+		}
+		if po.Spec.NodeName != node {
+			if ready {
+				o.ReadyAfter++
+			}
+			continue
+		}
+		o.Evicted++
+		evicted[k] = append(evicted[k], po)
+	}
+
+	s.checkBudgets(oo, evicted)
+
+	res := make([]Outcome, 0, len(evicted))
+	for k := range evicted {
+		o := oo[k]
+		o.Status = status(*o)
+		res = append(res, *o)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Namespace != res[j].Namespace {
+			return res[i].Namespace < res[j].Namespace
+		}
+		return res[i].Workload < res[j].Workload
+	})
+
+	return res, nil
+}
+
+// CheckBudgets flags workloads whose evicted pods exceed their PDBs allowed disruptions.
+func (s *Simulator) checkBudgets(oo map[string]*Outcome, evicted map[string][]*v1.Pod) {
+	for _, pdb := range s.ListPodDisruptionBudgets() {
+		if pdb.Spec.Selector == nil {
+			continue
+		}
+		sel, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			continue
+		}
+		var count int32
+		var hits []string
+		for k, pp := range evicted {
+			var matched bool
+			for _, po := range pp {
+				if po.Namespace != pdb.Namespace || !sel.Matches(labels.Set(po.Labels)) {
+					continue
+				}
+				count, matched = count+1, true
+			}
+			if matched {
+				hits = append(hits, k)
+			}
+		}
+		if count <= pdb.Status.DisruptionsAllowed {
+			continue
+		}
+		for _, k := range hits {
+			oo[k].BlockedBy = append(oo[k].BlockedBy, pdb.Name)
+			sort.Strings(oo[k].BlockedBy)
+		}
+	}
+}
+
+// Workload returns the kind and name of the pod top level controller.
+func (s *Simulator) workload(po *v1.Pod) (string, string) {
+	ref := metav1.GetControllerOf(po)
+	if ref == nil {
+		return "Pod", po.Name
+	}
+	if ref.Kind != "ReplicaSet" {
+		return ref.Kind, ref.Name
+	}
+	rs, ok := s.ListReplicaSets()[po.Namespace+"/"+ref.Name]
+	if !ok {
+		return ref.Kind, ref.Name
+	}
+	if dref := metav1.GetControllerOf(rs); dref != nil {
+		return dref.Kind, dref.Name
+	}
+
+	return ref.Kind, ref.Name
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+// Evictable checks if a pod would be evicted by a drain.
+func evictable(po *v1.Pod) bool {
+	if po.Status.Phase == v1.PodSucceeded || po.Status.Phase == v1.PodFailed {
+		return false
+	}
+	if _, ok := po.Annotations[mirrorPodAnnotation]; ok {
+		return false
+	}
+	if ref := metav1.GetControllerOf(po); ref != nil && ref.Kind == "DaemonSet" {
+		return false
+	}
+
+	return true
+}
+
+func status(o Outcome) string {
+	switch {
+	case len(o.BlockedBy) > 0:
+		return StatusBlocked
+	case strings.HasPrefix(o.Workload, "Pod/"):
+		return StatusUnmanaged
+	case o.Ready > 0 && o.ReadyAfter == 0:
+		return StatusOutage
+	default:
+		return StatusOK
+	}
+}
+
+func isReady(po *v1.Pod) bool {
+	for _, c := range po.Status.Conditions {
+		if c.Type == v1.PodReady {
+			return c.Status == v1.ConditionTrue
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,168 @@
+package drain
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	polv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDrain(t *testing.T) {
+	uu := map[string]struct {
+		node string
+		e    []Outcome
+		err  string
+	}{
+		"n1": {
+			node: "n1",
+			e: []Outcome{
+				{Namespace: "ns1", Workload: "Deployment/d1", Evicted: 1, Ready: 2, ReadyAfter: 1, BlockedBy: []string{"pdb1"}, Status: StatusBlocked},
+				{Namespace: "ns1", Workload: "Deployment/d2", Evicted: 1, Ready: 1, Status: StatusOutage},
+				{Namespace: "ns1", Workload: "Pod/bare", Evicted: 1, Ready: 1, Status: StatusUnmanaged},
+				{Namespace: "ns1", Workload: "StatefulSet/s1", Evicted: 1, Ready: 2, ReadyAfter: 1, Status: StatusOK},
+			},
+		},
+		"n2": {
+			node: "n2",
+			e: []Outcome{
+				{Namespace: "ns1", Workload: "Deployment/d1", Evicted: 1, Ready: 2, ReadyAfter: 1, BlockedBy: []string{"pdb1"}, Status: StatusBlocked},
+				{Namespace: "ns1", Workload: "StatefulSet/s1", Evicted: 1, Ready: 2, ReadyAfter: 1, Status: StatusOK},
+			},
+		},
+		"empty": {
+			node: "n3",
+			e:    []Outcome{},
+		},
+		"toast": {
+			node: "fred",
+			err:  `node "fred" not found`,
+		},
+	}
+
+	s := NewSimulator(makeLister())
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			oo, err := s.Drain(u.node)
+			if err != nil {
+				assert.Equal(t, u.err, err.Error())
+				return
+			}
+			assert.Equal(t, u.e, oo)
+		})
+	}
+}
+
+func TestDump(t *testing.T) {
+	oo, err := NewSimulator(makeLister()).Drain("n2")
+	assert.Nil(t, err)
+
+	var table bytes.Buffer
+	assert.Nil(t, Dump(&table, TableFormat, oo))
+	assert.Equal(t, `NAMESPACE  WORKLOAD        EVICTED  READY  READY-AFTER  STATUS   BLOCKED-BY
+ns1        Deployment/d1   1        2      1            blocked  pdb1
+ns1        StatefulSet/s1  1        2      1            ok       
+`, table.String())
+
+	var js bytes.Buffer
+	assert.Nil(t, Dump(&js, JSONFormat, nil))
+	assert.Equal(t, "[]\n", js.String())
+
+	assert.EqualError(t, Dump(&js, "yaml", oo), `invalid output format "yaml". Expecting table or json`)
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+type lister struct{}
+
+func makeLister() lister {
+	return lister{}
+}
+
+func (lister) ListNodes() map[string]*v1.Node {
+	return map[string]*v1.Node{
+		"n1": {ObjectMeta: metav1.ObjectMeta{Name: "n1"}},
+		"n2": {ObjectMeta: metav1.ObjectMeta{Name: "n2"}},
+		"n3": {ObjectMeta: metav1.ObjectMeta{Name: "n3"}},
+	}
+}
+
+func (lister) ListPods() map[string]*v1.Pod {
+	mirror := makePod("mirror", "n1", "", "", true)
+	mirror.Annotations = map[string]string{mirrorPodAnnotation: "fred"}
+	done := makePod("done", "n1", "ReplicaSet", "rs2", false)
+	done.Status.Phase = v1.PodSucceeded
+
+	return map[string]*v1.Pod{
+		"ns1/d1-1":   makePod("d1-1", "n1", "ReplicaSet", "rs1", true),
+		"ns1/d1-2":   makePod("d1-2", "n2", "ReplicaSet", "rs1", true),
+		"ns1/d2-1":   makePod("d2-1", "n1", "ReplicaSet", "rs2", true),
+		"ns1/s1-0":   makePod("s1-0", "n1", "StatefulSet", "s1", true),
+		"ns1/s1-1":   makePod("s1-1", "n2", "StatefulSet", "s1", true),
+		"ns1/ds-1":   makePod("ds-1", "n1", "DaemonSet", "ds", true),
+		"ns1/bare":   makePod("bare", "n1", "", "", true),
+		"ns1/mirror": mirror,
+		"ns1/done":   done,
+	}
+}
+
+func (lister) ListReplicaSets() map[string]*appsv1.ReplicaSet {
+	return map[string]*appsv1.ReplicaSet{
+		"ns1/rs1": {ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "rs1", OwnerReferences: makeOwner("Deployment", "d1")}},
+		"ns1/rs2": {ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "rs2", OwnerReferences: makeOwner("Deployment", "d2")}},
+	}
+}
+
+func (lister) ListPodDisruptionBudgets() map[string]*polv1beta1.PodDisruptionBudget {
+	return map[string]*polv1beta1.PodDisruptionBudget{
+		"ns1/pdb1": makePDB("pdb1", "rs1", 0),
+		"ns1/pdb2": makePDB("pdb2", "s1", 1),
+		"ns2/pdb3": {
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns2", Name: "pdb3"},
+			Spec:       polv1beta1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{}},
+		},
+	}
+}
+
+func makePod(n, node, kind, owner string, ready bool) *v1.Pod {
+	po := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      n,
+			Labels:    map[string]string{"app": owner},
+		},
+		Spec: v1.PodSpec{NodeName: node},
+		Status: v1.PodStatus{
+			Phase:      v1.PodRunning,
+			Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionFalse}},
+		},
+	}
+	if kind != "" {
+		po.OwnerReferences = makeOwner(kind, owner)
+	}
+	if ready {
+		po.Status.Conditions[0].Status = v1.ConditionTrue
+	}
+
+	return &po
+}
+
+func makeOwner(kind, n string) []metav1.OwnerReference {
+	ctrl := true
+	return []metav1.OwnerReference{{Kind: kind, Name: n, Controller: &ctrl}}
+}
+
+func makePDB(n, app string, allowed int32) *polv1beta1.PodDisruptionBudget {
+	return &polv1beta1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: n},
+		Spec: polv1beta1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": app}},
+		},
+		Status: polv1beta1.PodDisruptionBudgetStatus{DisruptionsAllowed: allowed},
+	}
+}
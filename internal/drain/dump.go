@@ -0,0 +1,52 @@
+package drain
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	// TableFormat renders drain outcomes as a table.
+	TableFormat = "table"
+
+	// JSONFormat renders drain outcomes as json.
+	JSONFormat = "json"
+)
+
+// Dump renders drain outcomes in the given format.
+func Dump(w io.Writer, format string, oo []Outcome) error {
+	switch format {
+	case JSONFormat:
+		if oo == nil {
+			oo = []Outcome{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(oo)
+	case TableFormat:
+		return dumpTable(w, oo)
+	default:
+		return fmt.Errorf("invalid output format %q. Expecting table or json", format)
+	}
+}
+
+func dumpTable(w io.Writer, oo []Outcome) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAMESPACE\tWORKLOAD\tEVICTED\tREADY\tREADY-AFTER\tSTATUS\tBLOCKED-BY")
+	for _, o := range oo {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%d\t%s\t%s\n",
+			o.Namespace,
+			o.Workload,
+			o.Evicted,
+			o.Ready,
+			o.ReadyAfter,
+			o.Status,
+			strings.Join(o.BlockedBy, ","),
+		)
+	}
+
+	return tw.Flush()
+}
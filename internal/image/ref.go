@@ -0,0 +1,62 @@
+package image
+
+import "strings"
+
+const (
+	// DefaultRegistry tracks the registry used when an image does not specify one.
+	DefaultRegistry = "docker.io"
+
+	// LatestTag tracks the docker image latest tag.
+	LatestTag = "latest"
+)
+
+// Ref represents a parsed container image reference.
+type Ref struct {
+	Registry, Repository, Tag, Digest string
+}
+
+// Parse parses an image reference, normalizing docker hub references
+// ie nginx resolves to docker.io/library/nginx.
+func Parse(image string) Ref {
+	var ref Ref
+	if i := strings.Index(image, "@"); i >= 0 {
+		image, ref.Digest = image[:i], image[i+1:]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image, ref.Tag = image[:i], image[i+1:]
+	}
+
+	tokens := strings.SplitN(image, "/", 2)
+	if len(tokens) == 2 && isRegistryHost(tokens[0]) {
+		ref.Registry, ref.Repository = tokens[0], tokens[1]
+	} else {
+		ref.Registry, ref.Repository = DefaultRegistry, image
+	}
+	switch ref.Registry {
+	case "index.docker.io", "registry-1.docker.io":
+		ref.Registry = DefaultRegistry
+	}
+	if ref.Registry == DefaultRegistry && !strings.Contains(ref.Repository, "/") {
+		ref.Repository = "library/" + ref.Repository
+	}
+
+	return ref
+}
+
+// Name returns the fully qualified image repository.
+func (r Ref) Name() string {
+	return r.Registry + "/" + r.Repository
+}
+
+// Mutable checks if the image may change under the same reference.
+func (r Ref) Mutable() bool {
+	return r.Digest == "" && (r.Tag == "" || r.Tag == LatestTag)
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+// IsRegistryHost checks if an image path component is a registry host.
+func isRegistryHost(s string) bool {
+	return s == "localhost" || strings.ContainsAny(s, ".:")
+}
@@ -0,0 +1,50 @@
+package image
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	uu := map[string]struct {
+		image string
+		e     Ref
+	}{
+		"short": {
+			image: "nginx",
+			e:     Ref{Registry: "docker.io", Repository: "library/nginx"},
+		},
+		"library": {
+			image: "library/nginx:1.23",
+			e:     Ref{Registry: "docker.io", Repository: "library/nginx", Tag: "1.23"},
+		},
+		"hub": {
+			image: "index.docker.io/bitnami/redis:7.0",
+			e:     Ref{Registry: "docker.io", Repository: "bitnami/redis", Tag: "7.0"},
+		},
+		"localhost": {
+			image: "localhost/fred",
+			e:     Ref{Registry: "localhost", Repository: "fred"},
+		},
+		"port": {
+			image: "localhost:5000/fred/blee:0.1.0",
+			e:     Ref{Registry: "localhost:5000", Repository: "fred/blee", Tag: "0.1.0"},
+		},
+		"digest": {
+			image: "quay.io/fred/blee@sha256:4f0c5b2a",
+			e:     Ref{Registry: "quay.io", Repository: "fred/blee", Digest: "sha256:4f0c5b2a"},
+		},
+		"tagDigest": {
+			image: "gcr.io/fred/blee:1.0@sha256:4f0c5b2a",
+			e:     Ref{Registry: "gcr.io", Repository: "fred/blee", Tag: "1.0", Digest: "sha256:4f0c5b2a"},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, Parse(u.image))
+		})
+	}
+}
@@ -54,6 +54,9 @@ func (c *Collector) AddSubCode(ctx context.Context, code config.ID, args ...inte
 	if !ok {
 		log.Error().Err(fmt.Errorf("No code with ID %d", code)).Msg("AddSubCode failed")
 	}
+	if c.skipLive(code) {
+		return
+	}
 	if !c.ShouldExclude(run.SectionGVR.String(), run.FQN, code) {
 		c.addIssue(run.FQN, New(run.GroupGVR, run.Group, co.Severity, co.Format(code, args...)))
 	}
@@ -67,6 +70,9 @@ func (c *Collector) AddCode(ctx context.Context, code config.ID, args ...interfa
 		// BOZO!! refact once codes are in!!
 		panic(fmt.Errorf("No code with ID %d", code))
 	}
+	if c.skipLive(code) {
+		return
+	}
 	if !c.ShouldExclude(run.SectionGVR.String(), run.FQN, code) {
 		c.addIssue(run.FQN, New(run.SectionGVR, Root, co.Severity, co.Format(code, args...)))
 	}
@@ -80,6 +86,11 @@ func (c *Collector) AddErr(ctx context.Context, errs ...error) {
 	}
 }
 
+// SkipLive checks if a code can not be evaluated without a live cluster.
+func (c *Collector) skipLive(code config.ID) bool {
+	return c.Config.Offline() && IsLive(code)
+}
+
 // AddIssue adds 1 or more concerns to the collector.
 func (c *Collector) addIssue(fqn string, concerns ...Issue) {
 	if len(concerns) == 0 {
@@ -240,6 +240,45 @@ func TestAddSubCode(t *testing.T) {
 	}
 }
 
+func TestAddCodeOffline(t *testing.T) {
+	uu := map[string]struct {
+		code    config.ID
+		offline bool
+		count   int
+	}{
+		"live": {
+			code:  204,
+			count: 1,
+		},
+		"liveOffline": {
+			code:    204,
+			offline: true,
+		},
+		"staticOffline": {
+			code:    100,
+			offline: true,
+			count:   1,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			cfg := makeConfig(t)
+			if u.offline {
+				m := "manifests.yaml"
+				cfg.Flags.Manifests = &m
+			}
+			c := NewCollector(loadCodes(t), cfg)
+			ctx := makeContext("test", Root, "blee")
+			c.AddCode(ctx, u.code, 1, 1)
+			c.AddSubCode(ctx, u.code, 1, 1)
+
+			assert.Equal(t, 2*u.count, len(c.outcomes[Root]))
+		})
+	}
+}
+
 // Helpers...
 
 func loadCodes(t *testing.T) *Codes {
@@ -2,6 +2,8 @@ package issues
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 
 	"github.com/derailed/popeye/internal/client"
 	"github.com/derailed/popeye/pkg/config"
@@ -35,6 +37,22 @@ func (i Issue) Blank() bool {
 	return i == Blank
 }
 
+var codeRX = regexp.MustCompile(`\A\[POP-(\d+)\]`)
+
+// Code returns the issue sanitizer code if any.
+func (i Issue) Code() (config.ID, bool) {
+	mm := codeRX.FindStringSubmatch(i.Message)
+	if len(mm) < 2 {
+		return 0, false
+	}
+	id, err := strconv.Atoi(mm[1])
+	if err != nil {
+		return 0, false
+	}
+
+	return config.ID(id), true
+}
+
 // IsSubIssue checks if error is a sub error.
 func (i Issue) IsSubIssue() bool {
 	return i.Group != Root
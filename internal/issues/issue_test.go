@@ -43,3 +43,25 @@ func TestBlank(t *testing.T) {
 		})
 	}
 }
+
+func TestIssueCode(t *testing.T) {
+	uu := map[string]struct {
+		i  Issue
+		id config.ID
+		ok bool
+	}{
+		"code":    {New(client.NewGVR("fred"), Root, config.WarnLevel, "[POP-106] blah"), 106, true},
+		"none":    {New(client.NewGVR("fred"), Root, config.WarnLevel, "blah"), 0, false},
+		"inside":  {New(client.NewGVR("fred"), Root, config.WarnLevel, "blah [POP-106]"), 0, false},
+		"garbled": {New(client.NewGVR("fred"), Root, config.WarnLevel, "[POP-] blah"), 0, false},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			id, ok := u.i.Code()
+			assert.Equal(t, u.ok, ok)
+			assert.Equal(t, u.id, id)
+		})
+	}
+}
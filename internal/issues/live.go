@@ -0,0 +1,28 @@
+package issues
+
+import "github.com/derailed/popeye/pkg/config"
+
+// liveCodes tracks codes that depend on live cluster state ie metrics, statuses or running pods.
+var liveCodes = map[config.ID]struct{}{
+	109: {}, 110: {}, 111: {}, 112: {},
+	200: {}, 201: {}, 202: {}, 203: {}, 204: {}, 205: {}, 207: {}, 210: {},
+	400: {}, 401: {}, 402: {},
+	501: {}, 503: {}, 504: {}, 505: {}, 506: {},
+	602: {}, 603: {}, 604: {}, 605: {},
+	700: {}, 701: {}, 702: {}, 703: {}, 704: {}, 705: {}, 706: {}, 707: {}, 708: {}, 709: {}, 710: {}, 711: {}, 712: {},
+	800: {},
+	900: {}, 901: {},
+	1000: {}, 1001: {}, 1002: {}, 1003: {}, 1004: {}, 1005: {},
+	1100: {}, 1101: {}, 1105: {}, 1106: {}, 1109: {}, 1110: {},
+	1120: {}, 1121: {},
+	1200: {},
+	1401: {}, 1402: {}, 1405: {}, 1408: {},
+	1500: {}, 1501: {},
+	1604: {}, 1605: {},
+}
+
+// IsLive returns true if the code requires live cluster state to be evaluated.
+func IsLive(code config.ID) bool {
+	_, ok := liveCodes[code]
+	return ok
+}
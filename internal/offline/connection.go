@@ -0,0 +1,164 @@
+package offline
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/types"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/metrics/pkg/client/clientset/versioned"
+)
+
+var (
+	_ types.Connection = (*Connection)(nil)
+	_ types.Config     = (*Config)(nil)
+
+	// ErrNoCluster indicates a cluster operation was attempted offline.
+	ErrNoCluster = errors.New("no cluster connection available offline")
+)
+
+// DefaultVersion represents the api server version assumed offline.
+var DefaultVersion = version.Info{
+	Major:      "1",
+	Minor:      "23",
+	GitVersion: "v1.23.0",
+	Platform:   "offline",
+}
+
+// Connection represents a cluster less connection.
+type Connection struct {
+	config  *Config
+	disco   *Discovery
+	version *version.Info
+	mx      versioned.Interface
+}
+
+// NewConnection returns a new instance.
+func NewConnection(cfg *Config, d *Discovery, v *version.Info) *Connection {
+	return &Connection{config: cfg, disco: d, version: v}
+}
+
+// SetMetrics registers a metrics client.
+func (c *Connection) SetMetrics(mx versioned.Interface) {
+	c.mx = mx
+}
+
+// CanI returns true. No rbac enforced offline.
+func (*Connection) CanI(ns, gvr string, verbs []string) (bool, error) {
+	return true, nil
+}
+
+// Config returns current config.
+func (c *Connection) Config() types.Config {
+	return c.config
+}
+
+// Dial returns an api server client. None available offline.
+func (*Connection) Dial() (kubernetes.Interface, error) {
+	return nil, ErrNoCluster
+}
+
+// CachedDiscovery returns a discovery client.
+func (c *Connection) CachedDiscovery() (discovery.CachedDiscoveryInterface, error) {
+	return c.disco, nil
+}
+
+// RestConfig returns a rest config. None available offline.
+func (*Connection) RestConfig() (*restclient.Config, error) {
+	return nil, ErrNoCluster
+}
+
+// MXDial returns a metrics client if one was registered.
+func (c *Connection) MXDial() (versioned.Interface, error) {
+	if c.mx == nil {
+		return nil, ErrNoCluster
+	}
+	return c.mx, nil
+}
+
+// DynDial returns a dynamic client. None available offline.
+func (*Connection) DynDial() (dynamic.Interface, error) {
+	return nil, ErrNoCluster
+}
+
+// HasMetrics checks if a metrics client was registered.
+func (c *Connection) HasMetrics() bool {
+	return c.mx != nil
+}
+
+// ServerVersion returns the assumed server version.
+func (c *Connection) ServerVersion() (*version.Info, error) {
+	return c.version, nil
+}
+
+// ActiveCluster returns the current cluster name.
+func (c *Connection) ActiveCluster() string {
+	n, _ := c.config.CurrentClusterName()
+	return n
+}
+
+// ActiveNamespace returns the current namespace.
+func (c *Connection) ActiveNamespace() string {
+	ns, err := c.config.CurrentNamespaceName()
+	if err != nil {
+		return client.AllNamespaces
+	}
+	return ns
+}
+
+// Config represents a cluster less configuration.
+type Config struct {
+	flags   *genericclioptions.ConfigFlags
+	cluster string
+}
+
+// NewConfig returns a new instance.
+func NewConfig(f *genericclioptions.ConfigFlags, cluster string) *Config {
+	return &Config{flags: f, cluster: cluster}
+}
+
+// CurrentNamespaceName returns the active namespace.
+func (c *Config) CurrentNamespaceName() (string, error) {
+	if c.flags != nil && c.flags.Namespace != nil {
+		return *c.flags.Namespace, nil
+	}
+
+	return "", errors.New("no active namespace specified")
+}
+
+// CurrentClusterName returns the cluster name.
+func (c *Config) CurrentClusterName() (string, error) {
+	return c.cluster, nil
+}
+
+// Flags returns the configuration flags.
+func (c *Config) Flags() *genericclioptions.ConfigFlags {
+	return c.flags
+}
+
+// RESTConfig returns a rest config. None available offline.
+func (*Config) RESTConfig() (*restclient.Config, error) {
+	return nil, ErrNoCluster
+}
+
+// CallTimeout returns the api call timeout.
+func (*Config) CallTimeout() time.Duration {
+	return client.CallTimeout
+}
+
+// ClusterName returns a cluster name for a manifests source.
+func ClusterName(source string) string {
+	if source == StdIn {
+		return "stdin"
+	}
+
+	return strings.TrimSuffix(filepath.Base(source), filepath.Ext(source))
+}
@@ -0,0 +1,145 @@
+package offline
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	openapi_v2 "github.com/googleapis/gnostic/openapiv2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery"
+	restclient "k8s.io/client-go/rest"
+)
+
+var _ discovery.CachedDiscoveryInterface = (*Discovery)(nil)
+
+// Discovery serves a static set of api resources.
+type Discovery struct {
+	resources []*metav1.APIResourceList
+	version   *version.Info
+}
+
+// NewDiscovery returns a new instance.
+func NewDiscovery(rr []*metav1.APIResourceList, v *version.Info) *Discovery {
+	return &Discovery{resources: rr, version: v}
+}
+
+// RESTClient returns a rest client. None available offline.
+func (*Discovery) RESTClient() restclient.Interface {
+	return nil
+}
+
+// ServerGroups returns the supported groups.
+func (d *Discovery) ServerGroups() (*metav1.APIGroupList, error) {
+	gg := make(map[string]int)
+	ll := metav1.APIGroupList{}
+	for _, r := range d.resources {
+		gv, err := schema.ParseGroupVersion(r.GroupVersion)
+		if err != nil {
+			return nil, err
+		}
+		v := metav1.GroupVersionForDiscovery{GroupVersion: r.GroupVersion, Version: gv.Version}
+		if i, ok := gg[gv.Group]; ok {
+			ll.Groups[i].Versions = append(ll.Groups[i].Versions, v)
+			continue
+		}
+		ll.Groups = append(ll.Groups, metav1.APIGroup{
+			Name:             gv.Group,
+			Versions:         []metav1.GroupVersionForDiscovery{v},
+			PreferredVersion: v,
+		})
+		gg[gv.Group] = len(ll.Groups) - 1
+	}
+
+	return &ll, nil
+}
+
+// ServerResourcesForGroupVersion returns the resources for a group and version.
+func (d *Discovery) ServerResourcesForGroupVersion(gv string) (*metav1.APIResourceList, error) {
+	for _, r := range d.resources {
+		if r.GroupVersion == gv {
+			return r, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no resources found for group version %q", gv)
+}
+
+// ServerResources returns the resources for all groups and versions.
+func (d *Discovery) ServerResources() ([]*metav1.APIResourceList, error) {
+	return d.resources, nil
+}
+
+// ServerGroupsAndResources returns the groups and resources for all groups and versions.
+func (d *Discovery) ServerGroupsAndResources() ([]*metav1.APIGroup, []*metav1.APIResourceList, error) {
+	ll, err := d.ServerGroups()
+	if err != nil {
+		return nil, nil, err
+	}
+	gg := make([]*metav1.APIGroup, 0, len(ll.Groups))
+	for i := range ll.Groups {
+		gg = append(gg, &ll.Groups[i])
+	}
+
+	return gg, d.resources, nil
+}
+
+// ServerPreferredResources returns the resources with their preferred versions.
+func (d *Discovery) ServerPreferredResources() ([]*metav1.APIResourceList, error) {
+	return d.resources, nil
+}
+
+// ServerPreferredNamespacedResources returns the namespaced resources with their preferred versions.
+func (d *Discovery) ServerPreferredNamespacedResources() ([]*metav1.APIResourceList, error) {
+	ll := make([]*metav1.APIResourceList, 0, len(d.resources))
+	for _, r := range d.resources {
+		l := metav1.APIResourceList{GroupVersion: r.GroupVersion}
+		for _, res := range r.APIResources {
+			if res.Namespaced {
+				l.APIResources = append(l.APIResources, res)
+			}
+		}
+		if len(l.APIResources) > 0 {
+			ll = append(ll, &l)
+		}
+	}
+
+	return ll, nil
+}
+
+// ServerVersion returns the server version.
+func (d *Discovery) ServerVersion() (*version.Info, error) {
+	return d.version, nil
+}
+
+// OpenAPISchema returns the server api schema. None available offline.
+func (*Discovery) OpenAPISchema() (*openapi_v2.Document, error) {
+	return nil, errors.New("no openapi schema available offline")
+}
+
+// Fresh checks if the cache is current. Always fresh offline.
+func (*Discovery) Fresh() bool {
+	return true
+}
+
+// Invalidate resets the cache. Noop offline.
+func (*Discovery) Invalidate() {}
+
+// ResourceFor returns the resource matching a given kind.
+func (d *Discovery) ResourceFor(gvk schema.GroupVersionKind) (metav1.APIResource, bool) {
+	for _, r := range d.resources {
+		gv, err := schema.ParseGroupVersion(r.GroupVersion)
+		if err != nil || gv.Group != gvk.Group {
+			continue
+		}
+		for _, res := range r.APIResources {
+			if res.Kind == gvk.Kind && !strings.Contains(res.Name, "/") {
+				return res, true
+			}
+		}
+	}
+
+	return metav1.APIResource{}, false
+}
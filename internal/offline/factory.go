@@ -0,0 +1,180 @@
+package offline
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/types"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/informers"
+)
+
+var _ types.Factory = (*Factory)(nil)
+
+// DefaultNamespace is assigned to namespaced manifests with no namespace.
+const DefaultNamespace = "default"
+
+// Factory serves resources from an in memory store.
+type Factory struct {
+	client types.Connection
+	disco  *Discovery
+	store  map[string]map[string]*unstructured.Unstructured
+	mx     sync.RWMutex
+}
+
+// NewFactory returns a new instance.
+func NewFactory(c types.Connection, d *Discovery) *Factory {
+	return &Factory{
+		client: c,
+		disco:  d,
+		store:  make(map[string]map[string]*unstructured.Unstructured),
+	}
+}
+
+// NewManifestFactory returns a factory serving manifests located at the given path.
+func NewManifestFactory(flags *genericclioptions.ConfigFlags, path string) (*Factory, error) {
+	oo, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	v := DefaultVersion
+	d := NewDiscovery(DefaultResources(), &v)
+	f := NewFactory(NewConnection(NewConfig(flags, ClusterName(path)), d, &v), d)
+
+	return f, f.Add(oo...)
+}
+
+// Add stores a collection of resources.
+func (f *Factory) Add(oo ...*unstructured.Unstructured) error {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	for _, o := range oo {
+		gvk := o.GroupVersionKind()
+		res, ok := f.disco.ResourceFor(gvk)
+		if !ok {
+			gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+			res.Name, res.Namespaced = gvr.Resource, true
+		}
+		if o.GetName() == "" {
+			return fmt.Errorf("%s manifest is missing a name", gvk.Kind)
+		}
+		if res.Namespaced && o.GetNamespace() == "" {
+			o.SetNamespace(f.defaultNamespace())
+		}
+		if !res.Namespaced {
+			o.SetNamespace("")
+		}
+		f.add(storeKey(gvk.Group, res.Name), o)
+	}
+
+	return nil
+}
+
+// AddFor stores a collection of resources for a given gvr as is.
+func (f *Factory) AddFor(gvr string, oo ...*unstructured.Unstructured) {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	key := gvrKey(gvr)
+	for _, o := range oo {
+		f.add(key, o)
+	}
+}
+
+func (f *Factory) add(key string, o *unstructured.Unstructured) {
+	if _, ok := f.store[key]; !ok {
+		f.store[key] = make(map[string]*unstructured.Unstructured)
+	}
+	f.store[key][client.FQN(o.GetNamespace(), o.GetName())] = o
+}
+
+// Client returns the factory connection.
+func (f *Factory) Client() types.Connection {
+	return f.client
+}
+
+// List returns a resource collection.
+func (f *Factory) List(gvr, ns string, _ bool, sel labels.Selector) ([]runtime.Object, error) {
+	f.mx.RLock()
+	defer f.mx.RUnlock()
+
+	if sel == nil {
+		sel = labels.Everything()
+	}
+	ns = client.CleanseNamespace(ns)
+	mm := f.store[gvrKey(gvr)]
+	kk := make([]string, 0, len(mm))
+	for k := range mm {
+		kk = append(kk, k)
+	}
+	sort.Strings(kk)
+
+	oo := make([]runtime.Object, 0, len(kk))
+	for _, k := range kk {
+		o := mm[k]
+		if !client.IsClusterWide(ns) && o.GetNamespace() != "" && o.GetNamespace() != ns {
+			continue
+		}
+		if !sel.Matches(labels.Set(o.GetLabels())) {
+			continue
+		}
+		oo = append(oo, o)
+	}
+
+	return oo, nil
+}
+
+// Get retrieves a given resource.
+func (f *Factory) Get(gvr, path string, _ bool, _ labels.Selector) (runtime.Object, error) {
+	f.mx.RLock()
+	defer f.mx.RUnlock()
+
+	ns, n := client.Namespaced(path)
+	if o, ok := f.store[gvrKey(gvr)][client.FQN(ns, n)]; ok {
+		return o, nil
+	}
+
+	return nil, fmt.Errorf("resource %s %q not found", gvr, path)
+}
+
+// ForResource returns an informer for a given resource. None available offline.
+func (*Factory) ForResource(ns, gvr string) (informers.GenericInformer, error) {
+	return nil, errors.New("informers are not available offline")
+}
+
+// CanForResource returns an informer if access is granted. None available offline.
+func (f *Factory) CanForResource(ns, gvr string, _ []string) (informers.GenericInformer, error) {
+	return f.ForResource(ns, gvr)
+}
+
+// WaitForCacheSync waits for the store to sync. Noop offline.
+func (*Factory) WaitForCacheSync() {}
+
+func (f *Factory) defaultNamespace() string {
+	if ns := f.client.ActiveNamespace(); !client.IsClusterWide(ns) {
+		return ns
+	}
+
+	return DefaultNamespace
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+// StoreKey ignores versions so resources resolve across api revisions.
+func storeKey(g, r string) string {
+	return g + "/" + r
+}
+
+func gvrKey(s string) string {
+	gvr := client.NewGVR(s)
+	return storeKey(gvr.G(), gvr.R())
+}
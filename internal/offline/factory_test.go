@@ -0,0 +1,149 @@
+package offline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestFactoryList(t *testing.T) {
+	f := makeFactory(t, "")
+
+	uu := map[string]struct {
+		gvr, ns string
+		sel     labels.Selector
+		e       []string
+	}{
+		"all": {
+			gvr: "v1/configmaps",
+			e:   []string{"fred/cm1", "fred/cm2"},
+		},
+		"namespaced": {
+			gvr: "apps/v1/deployments",
+			ns:  "fred",
+			e:   []string{"fred/blee"},
+		},
+		"otherNS": {
+			gvr: "apps/v1/deployments",
+			ns:  "blee",
+			e:   []string{},
+		},
+		"defaultNS": {
+			gvr: "v1/services",
+			ns:  "default",
+			e:   []string{"default/blee"},
+		},
+		"clusterScoped": {
+			gvr: "v1/namespaces",
+			ns:  "fred",
+			e:   []string{"fred"},
+		},
+		"selector": {
+			gvr: "v1/services",
+			sel: labels.SelectorFromSet(labels.Set{"app": "zorg"}),
+			e:   []string{},
+		},
+		"anyVersion": {
+			gvr: "policy/v1beta1/poddisruptionbudgets",
+			e:   []string{"fred/pdb1"},
+		},
+		"unknown": {
+			gvr: "v1/pods",
+			e:   []string{},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			oo, err := f.List(u.gvr, u.ns, false, u.sel)
+			assert.Nil(t, err)
+			assert.Equal(t, u.e, toFQNs(t, oo))
+		})
+	}
+}
+
+func TestFactoryGet(t *testing.T) {
+	f := makeFactory(t, "")
+
+	uu := map[string]struct {
+		gvr, path string
+		err       bool
+	}{
+		"found": {
+			gvr:  "v1/configmaps",
+			path: "fred/cm1",
+		},
+		"clusterScoped": {
+			gvr:  "v1/namespaces",
+			path: "fred",
+		},
+		"missing": {
+			gvr:  "v1/configmaps",
+			path: "fred/cm3",
+			err:  true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			o, err := f.Get(u.gvr, u.path, false, labels.Everything())
+			if u.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, []string{u.path}, toFQNs(t, []runtime.Object{o}))
+		})
+	}
+}
+
+func TestFactoryActiveNamespace(t *testing.T) {
+	f := makeFactory(t, "zorg")
+
+	oo, err := f.List("v1/services", "", false, labels.Everything())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"zorg/blee"}, toFQNs(t, oo))
+	assert.Equal(t, "zorg", f.Client().ActiveNamespace())
+	assert.Equal(t, "multi", f.Client().ActiveCluster())
+}
+
+// Helpers...
+
+func makeFactory(t *testing.T, ns string) *Factory {
+	flags := genericclioptions.NewConfigFlags(false)
+	if ns != "" {
+		flags.Namespace = &ns
+	}
+	f, err := NewManifestFactory(flags, "testdata/multi.yaml")
+	assert.Nil(t, err)
+	for _, p := range []string{"testdata/list.json", "testdata/dir"} {
+		oo, err := Load(p)
+		assert.Nil(t, err)
+		assert.Nil(t, f.Add(oo...))
+	}
+
+	return f
+}
+
+func toFQNs(t *testing.T, oo []runtime.Object) []string {
+	ss := make([]string, 0, len(oo))
+	for _, o := range oo {
+		m, ok := o.(interface {
+			GetNamespace() string
+			GetName() string
+		})
+		assert.True(t, ok)
+		if m.GetNamespace() == "" {
+			ss = append(ss, m.GetName())
+			continue
+		}
+		ss = append(ss, m.GetNamespace()+"/"+m.GetName())
+	}
+
+	return ss
+}
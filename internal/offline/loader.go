@@ -0,0 +1,119 @@
+package offline
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// StdIn indicates manifests should be read from standard input.
+const StdIn = "-"
+
+const decodeBufferSize = 4096
+
+// Load reads all manifests from a file, a directory or stdin.
+func Load(path string) ([]*unstructured.Unstructured, error) {
+	if path == StdIn {
+		return Decode(os.Stdin)
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !fi.IsDir() {
+		return loadFile(path)
+	}
+
+	var oo []*unstructured.Unstructured
+	err = filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !isManifest(p) {
+			return nil
+		}
+		ll, err := loadFile(p)
+		if err != nil {
+			return err
+		}
+		oo = append(oo, ll...)
+
+		return nil
+	})
+
+	return oo, err
+}
+
+// Decode reads a stream of yaml or json manifests.
+func Decode(r io.Reader) ([]*unstructured.Unstructured, error) {
+	var (
+		oo  []*unstructured.Unstructured
+		dec = yaml.NewYAMLOrJSONDecoder(r, decodeBufferSize)
+	)
+	for {
+		var m map[string]interface{}
+		if err := dec.Decode(&m); err != nil {
+			if errors.Is(err, io.EOF) {
+				return oo, nil
+			}
+			return nil, err
+		}
+		if len(m) == 0 {
+			continue
+		}
+		o := unstructured.Unstructured{Object: m}
+		if o.GetKind() == "" {
+			return nil, fmt.Errorf("manifest %q is missing a kind", o.GetName())
+		}
+		if !o.IsList() {
+			oo = append(oo, &o)
+			continue
+		}
+		err := o.EachListItem(func(item runtime.Object) error {
+			u, ok := item.(*unstructured.Unstructured)
+			if !ok {
+				return fmt.Errorf("expecting unstructured but got %T", item)
+			}
+			oo = append(oo, u)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func loadFile(path string) ([]*unstructured.Unstructured, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	oo, err := Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode manifest %q -- %w", path, err)
+	}
+
+	return oo, nil
+}
+
+func isManifest(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,83 @@
+package offline
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	uu := map[string]struct {
+		path  string
+		kinds []string
+		err   bool
+	}{
+		"multi": {
+			path:  "testdata/multi.yaml",
+			kinds: []string{"Namespace", "Deployment", "Service"},
+		},
+		"list": {
+			path:  "testdata/list.json",
+			kinds: []string{"ConfigMap", "ConfigMap"},
+		},
+		"dir": {
+			path:  "testdata/dir",
+			kinds: []string{"PodDisruptionBudget", "ServiceAccount"},
+		},
+		"noKind": {
+			path: "testdata/nokind.yaml",
+			err:  true,
+		},
+		"missing": {
+			path: "testdata/blee.yaml",
+			err:  true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			oo, err := Load(u.path)
+			if u.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			kk := make([]string, 0, len(oo))
+			for _, o := range oo {
+				kk = append(kk, o.GetKind())
+			}
+			assert.Equal(t, u.kinds, kk)
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	uu := map[string]struct {
+		raw   string
+		count int
+	}{
+		"empty": {},
+		"blanks": {
+			raw: "---\n---\n",
+		},
+		"single": {
+			raw:   "apiVersion: v1\nkind: Pod\nmetadata:\n  name: p1\n",
+			count: 1,
+		},
+		"json": {
+			raw:   `{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "p1"}}`,
+			count: 1,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			oo, err := Decode(strings.NewReader(u.raw))
+			assert.Nil(t, err)
+			assert.Equal(t, u.count, len(oo))
+		})
+	}
+}
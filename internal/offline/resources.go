@@ -0,0 +1,107 @@
+package offline
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultResources tracks the api resources served when no cluster is available.
+// Versions reflect the preferred versions of the default server revision.
+func DefaultResources() []*metav1.APIResourceList {
+	return []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				namespaced("configmaps", "ConfigMap", "cm"),
+				namespaced("endpoints", "Endpoints", "ep"),
+				namespaced("limitranges", "LimitRange", "limits"),
+				clusterWide("namespaces", "Namespace", "ns"),
+				clusterWide("nodes", "Node", "no"),
+				namespaced("persistentvolumeclaims", "PersistentVolumeClaim", "pvc"),
+				clusterWide("persistentvolumes", "PersistentVolume", "pv"),
+				namespaced("pods", "Pod", "po"),
+				namespaced("resourcequotas", "ResourceQuota", "quota"),
+				namespaced("secrets", "Secret"),
+				namespaced("serviceaccounts", "ServiceAccount", "sa"),
+				namespaced("services", "Service", "svc"),
+			},
+		},
+		{
+			GroupVersion: "apps/v1",
+			APIResources: []metav1.APIResource{
+				namespaced("daemonsets", "DaemonSet", "ds"),
+				namespaced("deployments", "Deployment", "deploy"),
+				namespaced("replicasets", "ReplicaSet", "rs"),
+				namespaced("statefulsets", "StatefulSet", "sts"),
+			},
+		},
+		{
+			GroupVersion: "autoscaling/v2",
+			APIResources: []metav1.APIResource{
+				namespaced("horizontalpodautoscalers", "HorizontalPodAutoscaler", "hpa"),
+			},
+		},
+		{
+			GroupVersion: "batch/v1",
+			APIResources: []metav1.APIResource{
+				namespaced("cronjobs", "CronJob", "cj"),
+				namespaced("jobs", "Job"),
+			},
+		},
+		{
+			GroupVersion: "networking.k8s.io/v1",
+			APIResources: []metav1.APIResource{
+				clusterWide("ingressclasses", "IngressClass"),
+				namespaced("ingresses", "Ingress", "ing"),
+				namespaced("networkpolicies", "NetworkPolicy", "netpol"),
+			},
+		},
+		{
+			GroupVersion: "policy/v1",
+			APIResources: []metav1.APIResource{
+				namespaced("poddisruptionbudgets", "PodDisruptionBudget", "pdb"),
+			},
+		},
+		{
+			GroupVersion: "policy/v1beta1",
+			APIResources: []metav1.APIResource{
+				clusterWide("podsecuritypolicies", "PodSecurityPolicy", "psp"),
+			},
+		},
+		{
+			GroupVersion: "rbac.authorization.k8s.io/v1",
+			APIResources: []metav1.APIResource{
+				clusterWide("clusterrolebindings", "ClusterRoleBinding"),
+				clusterWide("clusterroles", "ClusterRole"),
+				namespaced("rolebindings", "RoleBinding"),
+				namespaced("roles", "Role"),
+			},
+		},
+		{
+			GroupVersion: "apiextensions.k8s.io/v1",
+			APIResources: []metav1.APIResource{
+				clusterWide("customresourcedefinitions", "CustomResourceDefinition", "crd", "crds"),
+			},
+		},
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func namespaced(name, kind string, shortNames ...string) metav1.APIResource {
+	return newResource(name, kind, true, shortNames)
+}
+
+func clusterWide(name, kind string, shortNames ...string) metav1.APIResource {
+	return newResource(name, kind, false, shortNames)
+}
+
+func newResource(name, kind string, namespaced bool, shortNames []string) metav1.APIResource {
+	return metav1.APIResource{
+		Name:       name,
+		Kind:       kind,
+		Namespaced: namespaced,
+		ShortNames: shortNames,
+		Verbs:      metav1.Verbs{"get", "list", "watch"},
+	}
+}
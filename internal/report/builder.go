@@ -14,6 +14,7 @@ import (
 	"github.com/fvbommel/sortorder"
 	"github.com/prometheus/client_golang/prometheus/push"
 	"gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 const (
@@ -50,14 +51,42 @@ type Builder struct {
 
 // Report represents the output of a sanitization pass.
 type Report struct {
-	Score         int      `json:"score" yaml:"score"`
-	Grade         string   `json:"grade" yaml:"grade"`
-	Sections      Sections `json:"sanitizers,omitempty" yaml:"sanitizers,omitempty"`
-	Errors        []error  `json:"errors,omitempty" yaml:"errors,omitempty"`
+	Score         int         `json:"score" yaml:"score"`
+	Grade         string      `json:"grade" yaml:"grade"`
+	Sections      Sections    `json:"sanitizers,omitempty" yaml:"sanitizers,omitempty"`
+	Errors        []error     `json:"errors,omitempty" yaml:"errors,omitempty"`
+	Skips         []Skip      `json:"skipped,omitempty" yaml:"skipped,omitempty"`
+	Isolation     []Isolation `json:"isolation,omitempty" yaml:"isolation,omitempty"`
+	Garbage       []Garbage   `json:"garbage,omitempty" yaml:"garbage,omitempty"`
+	Reclaimable   int64       `json:"reclaimableStorageBytes,omitempty" yaml:"reclaimableStorageBytes,omitempty"`
 	sectionsCount int
 	totalScore    int
 }
 
+// Skip represents a check that was not evaluated.
+type Skip struct {
+	Section string `json:"section" yaml:"section"`
+	Reason  string `json:"reason" yaml:"reason"`
+}
+
+// Isolation represents a namespace network isolation summary.
+type Isolation struct {
+	Namespace   string `json:"namespace" yaml:"namespace"`
+	Policies    int    `json:"policies" yaml:"policies"`
+	DenyIngress bool   `json:"denyIngress" yaml:"denyIngress"`
+	DenyEgress  bool   `json:"denyEgress" yaml:"denyEgress"`
+	Pods        int    `json:"pods" yaml:"pods"`
+	Selected    int    `json:"selectedPods" yaml:"selectedPods"`
+}
+
+// Garbage represents an unused resource.
+type Garbage struct {
+	GVR      string `json:"gvr" yaml:"gvr"`
+	Resource string `json:"resource" yaml:"resource"`
+	Reason   string `json:"reason" yaml:"reason"`
+	Storage  int64  `json:"storageBytes,omitempty" yaml:"storageBytes,omitempty"`
+}
+
 // Sections represents a collection of sections.
 type Sections []Section
 
@@ -111,6 +140,29 @@ func (b *Builder) AddError(err error) {
 	b.Report.Errors = append(b.Report.Errors, err)
 }
 
+// AddSkip records a check that was not evaluated.
+func (b *Builder) AddSkip(section, reason string) {
+	b.Report.Skips = append(b.Report.Skips, Skip{Section: section, Reason: reason})
+}
+
+// AddIsolation records a namespace network isolation summary.
+func (b *Builder) AddIsolation(i Isolation) {
+	b.Report.Isolation = append(b.Report.Isolation, i)
+}
+
+// AddGarbage records an unused resource and the storage it holds.
+func (b *Builder) AddGarbage(g Garbage) {
+	b.Report.Garbage = append(b.Report.Garbage, g)
+	sort.SliceStable(b.Report.Garbage, func(i, j int) bool {
+		gi, gj := b.Report.Garbage[i], b.Report.Garbage[j]
+		if gi.GVR != gj.GVR {
+			return gi.GVR < gj.GVR
+		}
+		return gi.Resource < gj.Resource
+	})
+	b.Report.Reclaimable += g.Storage
+}
+
 // AddSection adds a sanitizer section to the report.
 func (b *Builder) AddSection(gvr client.GVR, singular string, o issues.Outcome, t *Tally) {
 	section := Section{
@@ -179,6 +231,9 @@ func (b *Builder) ToHTML() (string, error) {
 	if err != nil {
 		return "", err
 	}
+	if _, err = tpl.New("sections").Parse(htmlSections); err != nil {
+		return "", err
+	}
 
 	buff := bytes.NewBufferString("")
 	if err := tpl.Execute(buff, b); err != nil {
@@ -198,6 +253,16 @@ func (b *Builder) ToPrometheus(gtwy *config.PushGateway, namespace string) *push
 	return prometheusMarshal(b, gtwy, b.clusterName, namespace)
 }
 
+// ToMetrics records the sanitizer report as the current prometheus metrics.
+func (b *Builder) ToMetrics(namespace string) {
+	b.finalize()
+	if namespace == "" {
+		namespace = "all"
+	}
+	resetMetrics()
+	setMetrics(b, b.clusterName, namespace)
+}
+
 // ToScore dumps sanitizer to only the score value.
 func (b *Builder) ToScore() (int, error) {
 	b.finalize()
@@ -238,6 +303,75 @@ func (b *Builder) PrintClusterInfo(s *Sanitizer, clusterName string, metrics boo
 	s.Close()
 }
 
+// PrintSkips displays checks that were not evaluated.
+func (b *Builder) PrintSkips(s *Sanitizer) {
+	if len(b.Report.Skips) == 0 {
+		return
+	}
+
+	s.Open(Titleize("Skipped", -1), nil)
+	{
+		for _, sk := range b.Report.Skips {
+			s.Print(config.InfoLevel, 1, sk.Section)
+			s.Comment(sk.Reason)
+		}
+	}
+	s.Close()
+}
+
+// PrintIsolation displays each namespace network isolation.
+func (b *Builder) PrintIsolation(s *Sanitizer) {
+	if len(b.Report.Isolation) == 0 {
+		return
+	}
+
+	s.Open(Titleize("Network Isolation", -1), nil)
+	{
+		for _, i := range b.Report.Isolation {
+			level := config.OkLevel
+			switch {
+			case i.Policies == 0:
+				level = config.WarnLevel
+			case !i.DenyIngress || !i.DenyEgress || i.Selected < i.Pods:
+				level = config.InfoLevel
+			}
+			s.Print(level, 1, i.Namespace)
+			s.Comment(fmt.Sprintf("policies: %d, default-deny ingress: %s, egress: %s, pods selected: %d/%d",
+				i.Policies, yesNo(i.DenyIngress), yesNo(i.DenyEgress), i.Selected, i.Pods))
+		}
+	}
+	s.Close()
+}
+
+// PrintGarbage displays unused resources grouped by kind along with the storage they hold.
+func (b *Builder) PrintGarbage(s *Sanitizer) {
+	if len(b.Report.Garbage) == 0 {
+		return
+	}
+
+	s.Open(Titleize("Garbage", -1), nil)
+	{
+		gg := b.Report.Garbage
+		for i := 0; i < len(gg); {
+			j := i
+			for j < len(gg) && gg[j].GVR == gg[i].GVR {
+				j++
+			}
+			s.Print(config.InfoLevel, 1, fmt.Sprintf("%s (%d)", client.NewGVR(gg[i].GVR).R(), j-i))
+			for _, g := range gg[i:j] {
+				msg := g.Resource + " -- " + g.Reason
+				if g.Storage > 0 {
+					msg += " (" + toStorage(g.Storage) + ")"
+				}
+				s.Print(config.InfoLevel, 2, msg)
+			}
+			i = j
+		}
+		s.Comment("Estimated reclaimable storage: " + toStorage(b.Report.Reclaimable))
+	}
+	s.Close()
+}
+
 // PrintHeader prints report header to screen.
 func (b *Builder) PrintHeader(s *Sanitizer) {
 	fmt.Fprintln(s)
@@ -323,3 +457,14 @@ func toEmoji(level config.Level) (s string) {
 	}
 	return s
 }
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+func toStorage(n int64) string {
+	return resource.NewQuantity(n, resource.BinarySI).String()
+}
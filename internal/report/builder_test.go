@@ -138,6 +138,32 @@ func TestPrintReport(t *testing.T) {
 	assert.Equal(t, reportExp, buff.String())
 }
 
+func TestPrintIsolation(t *testing.T) {
+	b := report.NewBuilder()
+	b.AddIsolation(report.Isolation{Namespace: "default", Pods: 2})
+	b.AddIsolation(report.Isolation{Namespace: "fred", Policies: 2, DenyIngress: true, DenyEgress: true, Pods: 1, Selected: 1})
+
+	buff := bytes.NewBuffer([]byte(""))
+	san := report.NewSanitizer(buff, false)
+	b.PrintIsolation(san)
+
+	assert.Equal(t, isolationExp, buff.String())
+}
+
+func TestPrintGarbage(t *testing.T) {
+	b := report.NewBuilder()
+	b.AddGarbage(report.Garbage{GVR: "v1/persistentvolumeclaims", Resource: "default/pvc2", Reason: "[POP-400] Used? Unable to locate resource reference", Storage: 1 << 30})
+	b.AddGarbage(report.Garbage{GVR: "v1/configmaps", Resource: "default/cm1", Reason: "[POP-400] Used? Unable to locate resource reference"})
+	b.AddGarbage(report.Garbage{GVR: "v1/persistentvolumeclaims", Resource: "default/pvc1", Reason: "[POP-400] Used? Unable to locate resource reference", Storage: 2 << 30})
+
+	buff := bytes.NewBuffer([]byte(""))
+	san := report.NewSanitizer(buff, false)
+	b.PrintGarbage(san)
+
+	assert.Equal(t, int64(3<<30), b.Report.Reclaimable)
+	assert.Equal(t, garbageExp, buff.String())
+}
+
 func TestTitleize(t *testing.T) {
 	uu := map[string]struct {
 		count    int
@@ -186,7 +212,9 @@ var (
   - {}
 `
 
-	summaryExp = "\n\x1b[38;5;75mSUMMARY\x1b[0m\n\x1b[38;5;75m" + strings.Repeat("┅", 101) + "\x1b[0m\nYour cluster score: 100 -- A\n                                                                                \x1b[38;5;82mo          .-'-.     \x1b[0m\n                                                                                \x1b[38;5;82m o     __| A    `\\  \x1b[0m\n                                                                                \x1b[38;5;82m  o   `-,-`--._   `\\\x1b[0m\n                                                                                \x1b[38;5;82m []  .->'  a     `|-'\x1b[0m\n                                                                                \x1b[38;5;82m  `=/ (__/_       /  \x1b[0m\n                                                                                \x1b[38;5;82m    \\_,    `    _)  \x1b[0m\n                                                                                \x1b[38;5;82m       `----;  |     \x1b[0m\n\n"
-	headerExp  = "\n\x1b[38;5;122m ___     ___ _____   _____ \x1b[0m                                                     \x1b[38;5;75mK          .-'-.     \x1b[0m\n\x1b[38;5;122m| _ \\___| _ \\ __\\ \\ / / __|\x1b[0m                                                     \x1b[38;5;75m 8     __|      `\\  \x1b[0m\n\x1b[38;5;122m|  _/ _ \\  _/ _| \\ V /| _| \x1b[0m                                                     \x1b[38;5;75m  s   `-,-`--._   `\\\x1b[0m\n\x1b[38;5;122m|_| \\___/_| |___| |_| |___|\x1b[0m                                                     \x1b[38;5;75m []  .->'  a     `|-'\x1b[0m\n\x1b[38;5;75m  Biffs`em and Buffs`em!\x1b[0m                                                        \x1b[38;5;75m  `=/ (__/_       /  \x1b[0m\n                                                                                \x1b[38;5;75m    \\_,    `    _)  \x1b[0m\n                                                                                \x1b[38;5;75m       `----;  |     \x1b[0m\n\n"
-	reportExp  = "\n\x1b[38;5;75mFRED (1 SCANNED)\x1b[0m" + strings.Repeat(" ", 61) + "💥 0 😱 0 🔊 0 ✅ 1 \x1b[38;5;122m100\x1b[0m٪\n\x1b[38;5;75m" + strings.Repeat("┅", 101) + "\x1b[0m\n  · \x1b[38;5;155mblee\x1b[0m\x1b[38;5;250m" + strings.Repeat(".", 91) + "\x1b[0m✅\n    ✅ \x1b[38;5;155mBlah.\x1b[0m\n\n"
+	summaryExp   = "\n\x1b[38;5;75mSUMMARY\x1b[0m\n\x1b[38;5;75m" + strings.Repeat("┅", 101) + "\x1b[0m\nYour cluster score: 100 -- A\n                                                                                \x1b[38;5;82mo          .-'-.     \x1b[0m\n                                                                                \x1b[38;5;82m o     __| A    `\\  \x1b[0m\n                                                                                \x1b[38;5;82m  o   `-,-`--._   `\\\x1b[0m\n                                                                                \x1b[38;5;82m []  .->'  a     `|-'\x1b[0m\n                                                                                \x1b[38;5;82m  `=/ (__/_       /  \x1b[0m\n                                                                                \x1b[38;5;82m    \\_,    `    _)  \x1b[0m\n                                                                                \x1b[38;5;82m       `----;  |     \x1b[0m\n\n"
+	headerExp    = "\n\x1b[38;5;122m ___     ___ _____   _____ \x1b[0m                                                     \x1b[38;5;75mK          .-'-.     \x1b[0m\n\x1b[38;5;122m| _ \\___| _ \\ __\\ \\ / / __|\x1b[0m                                                     \x1b[38;5;75m 8     __|      `\\  \x1b[0m\n\x1b[38;5;122m|  _/ _ \\  _/ _| \\ V /| _| \x1b[0m                                                     \x1b[38;5;75m  s   `-,-`--._   `\\\x1b[0m\n\x1b[38;5;122m|_| \\___/_| |___| |_| |___|\x1b[0m                                                     \x1b[38;5;75m []  .->'  a     `|-'\x1b[0m\n\x1b[38;5;75m  Biffs`em and Buffs`em!\x1b[0m                                                        \x1b[38;5;75m  `=/ (__/_       /  \x1b[0m\n                                                                                \x1b[38;5;75m    \\_,    `    _)  \x1b[0m\n                                                                                \x1b[38;5;75m       `----;  |     \x1b[0m\n\n"
+	isolationExp = "\n\x1b[38;5;75mNETWORK ISOLATION\x1b[0m\n\x1b[38;5;75m" + strings.Repeat("┅", 101) + "\x1b[0m\n  · \x1b[38;5;220mdefault\x1b[0m\x1b[38;5;250m" + strings.Repeat(".", 88) + "\x1b[0m😱\n  · policies: 0, default-deny ingress: no, egress: no, pods selected: 0/2\n  · \x1b[38;5;155mfred\x1b[0m\x1b[38;5;250m" + strings.Repeat(".", 91) + "\x1b[0m✅\n  · policies: 2, default-deny ingress: yes, egress: yes, pods selected: 1/1\n\n"
+	garbageExp   = "\n\x1b[38;5;75mGARBAGE\x1b[0m\n\x1b[38;5;75m" + strings.Repeat("┅", 101) + "\x1b[0m\n  · \x1b[38;5;122mconfigmaps (1)\x1b[0m\x1b[38;5;250m" + strings.Repeat(".", 81) + "\x1b[0m🔊\n    🔊 \x1b[38;5;122mdefault/cm1 -- [POP-400] Used? Unable to locate resource reference\x1b[0m\n  · \x1b[38;5;122mpersistentvolumeclaims (2)\x1b[0m\x1b[38;5;250m" + strings.Repeat(".", 69) + "\x1b[0m🔊\n    🔊 \x1b[38;5;122mdefault/pvc1 -- [POP-400] Used? Unable to locate resource reference (2Gi)\x1b[0m\n    🔊 \x1b[38;5;122mdefault/pvc2 -- [POP-400] Used? Unable to locate resource reference (1Gi)\x1b[0m\n  · Estimated reclaimable storage: 3Gi\n\n"
+	reportExp    = "\n\x1b[38;5;75mFRED (1 SCANNED)\x1b[0m" + strings.Repeat(" ", 61) + "💥 0 😱 0 🔊 0 ✅ 1 \x1b[38;5;122m100\x1b[0m٪\n\x1b[38;5;75m" + strings.Repeat("┅", 101) + "\x1b[0m\n  · \x1b[38;5;155mblee\x1b[0m\x1b[38;5;250m" + strings.Repeat(".", 91) + "\x1b[0m✅\n    ✅ \x1b[38;5;155mBlah.\x1b[0m\n\n"
 )
@@ -0,0 +1,331 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"sync"
+	"text/template"
+
+	"github.com/derailed/popeye/pkg/config"
+	"github.com/prometheus/client_golang/prometheus/push"
+	"gopkg.in/yaml.v2"
+)
+
+// Fleet represents a multi clusters sanitization report.
+type Fleet struct {
+	Report FleetReport `json:"popeye" yaml:"popeye"`
+
+	mx sync.Mutex
+}
+
+// FleetReport represents the output of a fleet sanitization pass.
+type FleetReport struct {
+	Score    int            `json:"score" yaml:"score"`
+	Grade    string         `json:"grade" yaml:"grade"`
+	Clusters []FleetCluster `json:"clusters" yaml:"clusters"`
+}
+
+// FleetCluster represents a cluster sanitization report.
+type FleetCluster struct {
+	Context string `json:"context" yaml:"context"`
+	Cluster string `json:"cluster" yaml:"cluster"`
+	Error   string `json:"error,omitempty" yaml:"error,omitempty"`
+	Report  `yaml:",inline"`
+
+	metrics bool
+	builder *Builder
+}
+
+// NewFleet returns a new instance.
+func NewFleet() *Fleet {
+	return &Fleet{}
+}
+
+// AddCluster adds a cluster report.
+func (f *Fleet) AddCluster(context, cluster string, metrics bool, b *Builder) {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	c := FleetCluster{Context: context, Cluster: cluster, metrics: metrics, builder: b}
+	if b.HasContent() {
+		b.SetClusterName(cluster)
+		b.finalize()
+	}
+	c.Report = b.Report
+	f.Report.Clusters = append(f.Report.Clusters, c)
+}
+
+// AddFailure records a cluster that could not be sanitized.
+func (f *Fleet) AddFailure(context, cluster string, err error) {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	f.Report.Clusters = append(f.Report.Clusters, FleetCluster{
+		Context: context,
+		Cluster: cluster,
+		Error:   err.Error(),
+		Report:  Report{Grade: Grade(0)},
+	})
+}
+
+// HasContent checks if we actually have anything to report.
+func (f *Fleet) HasContent() bool {
+	return len(f.Report.Clusters) > 0
+}
+
+// ErrCount returns the number of errors across all clusters.
+func (f *Fleet) ErrCount() int {
+	var count int
+	for _, c := range f.Report.Clusters {
+		if c.failed() {
+			count++
+			continue
+		}
+		for _, s := range c.Sections {
+			count += s.Tally.ErrCount()
+		}
+	}
+
+	return count
+}
+
+func (f *Fleet) finalize() {
+	sort.SliceStable(f.Report.Clusters, func(i, j int) bool {
+		return f.Report.Clusters[i].Context < f.Report.Clusters[j].Context
+	})
+	var total, count int
+	for _, c := range f.Report.Clusters {
+		if c.failed() {
+			continue
+		}
+		total, count = total+c.Score, count+1
+	}
+	if count > 0 {
+		f.Report.Score = total / count
+	}
+	f.Report.Grade = Grade(f.Report.Score)
+}
+
+// ToYAML dumps fleet report to YAML.
+func (f *Fleet) ToYAML() (string, error) {
+	f.finalize()
+	raw, err := yaml.Marshal(f)
+	if err != nil {
+		return "", err
+	}
+
+	return string(raw), nil
+}
+
+// ToJSON dumps fleet report to JSON.
+func (f *Fleet) ToJSON() (string, error) {
+	f.finalize()
+	raw, err := json.Marshal(f)
+	if err != nil {
+		return "", err
+	}
+
+	return string(raw), nil
+}
+
+// ToJunit dumps fleet report to JUnit.
+func (f *Fleet) ToJunit(level config.Level) (string, error) {
+	f.finalize()
+	s := TestSuites{Name: "Popeye"}
+	for _, c := range f.Report.Clusters {
+		if c.failed() {
+			s.Errors++
+			s.Suites = append(s.Suites, TestSuite{
+				Name:      c.Context,
+				Errors:    1,
+				TestCases: []TestCase{{Name: c.Context, Errors: []Error{{Message: c.Error, Type: "error"}}}},
+			})
+			continue
+		}
+		s.Tests += len(c.Sections)
+		s.Errors += len(c.Errors)
+		for _, section := range c.Sections {
+			ts := newSuite(section, level)
+			ts.Name = c.Cluster + "/" + ts.Name
+			s.Suites = append(s.Suites, ts)
+		}
+		if len(c.Skips) > 0 {
+			ts := newSkippedSuite(c.Skips)
+			ts.Name = c.Cluster + "/" + ts.Name
+			s.Suites = append(s.Suites, ts)
+		}
+	}
+
+	raw, err := xml.MarshalIndent(s, "", "\t")
+	if err != nil {
+		return "", err
+	}
+
+	return string(raw), nil
+}
+
+// ToHTML dumps fleet report to HTML.
+func (f *Fleet) ToHTML() (string, error) {
+	f.finalize()
+
+	fMap := template.FuncMap{
+		"toEmoji": toEmoji,
+		"toTitle": Titleize,
+		"isRoot":  isRoot,
+	}
+	tpl, err := template.New("fleet").Funcs(fMap).Parse(fleetHTMLTemplate)
+	if err != nil {
+		return "", err
+	}
+	if _, err = tpl.New("sections").Parse(htmlSections); err != nil {
+		return "", err
+	}
+
+	buff := bytes.NewBufferString("")
+	if err := tpl.Execute(buff, f); err != nil {
+		return "", err
+	}
+
+	return buff.String(), nil
+}
+
+// ToPrometheus returns prometheus pusher.
+func (f *Fleet) ToPrometheus(gtwy *config.PushGateway, namespace string) *push.Pusher {
+	f.finalize()
+	if namespace == "" {
+		namespace = "all"
+	}
+	pusher := newPusher(gtwy)
+	for _, c := range f.Report.Clusters {
+		if c.failed() {
+			continue
+		}
+		setMetrics(c.builder, c.Cluster, namespace)
+	}
+
+	return pusher
+}
+
+// ToScore dumps fleet report to only the score value.
+func (f *Fleet) ToScore() (int, error) {
+	f.finalize()
+	return f.Report.Score, nil
+}
+
+// PrintReport prints out each cluster report to screen.
+func (f *Fleet) PrintReport(level config.Level, s *Sanitizer) {
+	f.finalize()
+	for _, c := range f.Report.Clusters {
+		if c.failed() {
+			s.Open(Titleize(fmt.Sprintf("General [%s]", c.Context), -1), nil)
+			s.Print(config.ErrorLevel, 1, "Connectivity")
+			s.Comment(c.Error)
+			s.Close()
+			continue
+		}
+		c.builder.PrintClusterInfo(s, c.Cluster, c.metrics)
+		c.builder.PrintSkips(s)
+		c.builder.PrintReport(level, s)
+		c.builder.PrintIsolation(s)
+		c.builder.PrintGarbage(s)
+		c.builder.PrintSummary(s)
+	}
+}
+
+// PrintSummary prints out the fleet scores table to screen.
+func (f *Fleet) PrintSummary(s *Sanitizer) {
+	f.finalize()
+	s.Open("FLEET SUMMARY", nil)
+	{
+		for _, c := range f.Report.Clusters {
+			if c.failed() {
+				s.Print(config.ErrorLevel, 1, fmt.Sprintf("%-40s %s", c.Context, "n/a"))
+				continue
+			}
+			s.Print(scoreLevel(c.Score), 1, fmt.Sprintf("%-40s %3d -- %s", c.Context, c.Score, c.Grade))
+		}
+		fmt.Fprintf(s, "\nYour fleet score: %d -- %s\n", f.Report.Score, f.Report.Grade)
+	}
+	s.Close()
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func (c FleetCluster) failed() bool {
+	return c.Error != ""
+}
+
+func scoreLevel(score int) config.Level {
+	switch {
+	case score >= targetScore:
+		return config.OkLevel
+	case score >= 50:
+		return config.WarnLevel
+	default:
+		return config.ErrorLevel
+	}
+}
+
+var fleetHTMLTemplate = htmlHead + `
+<body>
+  <div class="sanitizer">
+    <div class="title">Popeye K8s Fleet Sanitizer Report</div>
+    <div class="summary">
+      <a class="popeye-logo" href="https://github.com/derailed/popeye">
+        <img class="logo" src="https://github.com/derailed/popeye/raw/master/assets/popeye_logo.png" />
+      </a>
+      <div class="score-summary">
+        Scanned
+        <span class="cluster">{{ len .Report.Clusters }} clusters</span>
+      </div>
+      <div class="scorer">
+        <span class="grade grade-{{ .Report.Grade }}">{{ .Report.Grade }}</span>
+        <span class="section-score cluster-score"> {{ .Report.Score }} </span>
+      </div>
+    </div>
+
+    <div class="section">
+      <hr />
+      <ul class="outcome">
+        {{ range $c := .Report.Clusters }}
+        <li>
+          <div class="outcome grade-{{ $c.Grade }}">
+            {{ $c.Context }}
+          </div>
+          <div class="outcome-score grade-{{ $c.Grade }}">
+            {{ if $c.Error }}n/a{{ else }}{{ $c.Score }} -- {{ $c.Grade }}{{ end }}
+          </div>
+          <div class="clear"></div>
+        </li>
+        {{ end }}
+      </ul>
+    </div>
+
+    {{ range $c := .Report.Clusters }}
+    <div class="section">
+      <hr />
+      <div class="summary">
+        <div class="score-summary">
+          <span class="cluster">{{ $c.Context }}</span>
+        </div>
+        <div class="scorer">
+          <span class="grade grade-{{ $c.Grade }}">{{ $c.Grade }}</span>
+          <span class="section-score cluster-score"> {{ $c.Score }} </span>
+        </div>
+      </div>
+      {{ if $c.Error }}
+      <span class="msg level-3"><i class="{{ toEmoji 3 }}"></i> {{ $c.Error }}</span>
+      {{ else }}
+      {{ template "sections" $c }}
+      {{ end }}
+    </div>
+    {{ end }}
+  </div>
+</body>
+
+</html>
+`
@@ -0,0 +1,182 @@
+package report_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/internal/report"
+	"github.com/derailed/popeye/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFleetScore(t *testing.T) {
+	uu := map[string]struct {
+		levels []config.Level
+		fails  int
+		score  int
+		grade  string
+	}{
+		"single": {
+			levels: []config.Level{config.OkLevel},
+			score:  100,
+			grade:  "A",
+		},
+		"average": {
+			levels: []config.Level{config.OkLevel, config.ErrorLevel},
+			score:  50,
+			grade:  "E",
+		},
+		"failures": {
+			levels: []config.Level{config.OkLevel},
+			fails:  2,
+			score:  100,
+			grade:  "A",
+		},
+		"all-failed": {
+			fails: 1,
+			score: 0,
+			grade: "F",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			f := report.NewFleet()
+			for i, l := range u.levels {
+				f.AddCluster("ctx"+string(rune('a'+i)), "c", false, makeBuilder(l))
+			}
+			for i := 0; i < u.fails; i++ {
+				f.AddFailure("bad"+string(rune('a'+i)), "c", errors.New("boom"))
+			}
+			score, err := f.ToScore()
+
+			assert.Nil(t, err)
+			assert.Equal(t, u.score, score)
+			assert.Equal(t, u.grade, f.Report.Grade)
+		})
+	}
+}
+
+func TestFleetErrCount(t *testing.T) {
+	f := report.NewFleet()
+	f.AddCluster("c1", "c1", false, makeBuilder(config.ErrorLevel))
+	f.AddCluster("c2", "c2", false, makeBuilder(config.OkLevel))
+	f.AddFailure("c3", "c3", errors.New("boom"))
+
+	assert.True(t, f.HasContent())
+	assert.Equal(t, 2, f.ErrCount())
+}
+
+func TestFleetJSON(t *testing.T) {
+	f := report.NewFleet()
+	f.AddFailure("c2", "blee", errors.New("boom"))
+	f.AddCluster("c1", "fred", false, makeBuilder(config.OkLevel))
+	s, err := f.ToJSON()
+
+	assert.Nil(t, err)
+	assert.Equal(t, fleetJSON, s)
+}
+
+func TestFleetYAML(t *testing.T) {
+	f := report.NewFleet()
+	f.AddCluster("c1", "fred", false, makeBuilder(config.OkLevel))
+	f.AddFailure("c2", "blee", errors.New("boom"))
+	s, err := f.ToYAML()
+
+	assert.Nil(t, err)
+	assert.Equal(t, fleetYAML, s)
+}
+
+func TestFleetJunit(t *testing.T) {
+	f := report.NewFleet()
+	f.AddCluster("c1", "fred", false, makeBuilder(config.OkLevel))
+	f.AddFailure("c2", "blee", errors.New("boom"))
+	s, err := f.ToJunit(config.OkLevel)
+
+	assert.Nil(t, err)
+	assert.Equal(t, fleetJunit, s)
+}
+
+func TestFleetHTML(t *testing.T) {
+	f := report.NewFleet()
+	f.AddCluster("c1", "fred", false, makeBuilder(config.OkLevel))
+	f.AddFailure("c2", "blee", errors.New("boom"))
+	s, err := f.ToHTML()
+
+	assert.Nil(t, err)
+	assert.Contains(t, s, "Popeye K8s Fleet Sanitizer Report")
+	assert.Contains(t, s, "2 clusters")
+	assert.Contains(t, s, "FRED (1 SCANNED)")
+	assert.Contains(t, s, "boom")
+}
+
+func TestFleetPrintSummary(t *testing.T) {
+	f := report.NewFleet()
+	f.AddCluster("c1", "fred", false, makeBuilder(config.OkLevel))
+	f.AddFailure("c2", "blee", errors.New("boom"))
+
+	buff := bytes.NewBuffer([]byte(""))
+	san := report.NewSanitizer(buff, true)
+	f.PrintSummary(san)
+
+	assert.Equal(t, fleetSummaryExp, buff.String())
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func makeBuilder(l config.Level) *report.Builder {
+	b, ta := report.NewBuilder(), report.NewTally()
+	o := issues.Outcome{
+		"blee": issues.Issues{
+			issues.New(client.NewGVR("fred"), issues.Root, l, "Blah"),
+		},
+	}
+	ta.Rollup(o)
+	b.AddSection(client.NewGVR("fred"), "fred", o, ta)
+
+	return b
+}
+
+var (
+	fleetJSON = `{"popeye":{"score":100,"grade":"A","clusters":[{"context":"c1","cluster":"fred","score":100,"grade":"A","sanitizers":[{"sanitizer":"fred","gvr":"fred","tally":{"ok":1,"info":0,"warning":0,"error":0,"score":100},"issues":{"blee":[{"group":"__root__","gvr":"fred","level":0,"message":"Blah"}]}}]},{"context":"c2","cluster":"blee","error":"boom","score":0,"grade":"F"}]}}`
+
+	fleetYAML = `popeye:
+  score: 100
+  grade: A
+  clusters:
+  - context: c1
+    cluster: fred
+    score: 100
+    grade: A
+    sanitizers:
+    - sanitizer: fred
+      gvr: fred
+      tally:
+        ok: 1
+        info: 0
+        warning: 0
+        error: 0
+        score: 100
+      issues:
+        blee:
+        - group: __root__
+          gvr: fred
+          level: 0
+          message: Blah
+  - context: c2
+    cluster: blee
+    error: boom
+    score: 0
+    grade: F
+`
+
+	fleetJunit = "<testsuites name=\"Popeye\" tests=\"1\" failures=\"0\" errors=\"1\">\n\t<testsuite name=\"fred/fred\" tests=\"1\" failures=\"0\" errors=\"0\">\n\t\t<properties>\n\t\t\t<property name=\"OK\" value=\"1\"></property>\n\t\t\t<property name=\"Info\" value=\"0\"></property>\n\t\t\t<property name=\"Warn\" value=\"0\"></property>\n\t\t\t<property name=\"Error\" value=\"0\"></property>\n\t\t\t<property name=\"Score\" value=\"100%\"></property>\n\t\t</properties>\n\t\t<testcase classname=\"\" name=\"blee\"></testcase>\n\t</testsuite>\n\t<testsuite name=\"c2\" tests=\"0\" failures=\"0\" errors=\"1\">\n\t\t<properties></properties>\n\t\t<testcase classname=\"\" name=\"c2\">\n\t\t\t<error message=\"boom\" type=\"error\"></error>\n\t\t</testcase>\n\t</testsuite>\n</testsuites>"
+
+	fleetSummaryExp = "\nFLEET SUMMARY\n" + strings.Repeat("=", 101) + "\n  · c1                                       100 -- A" + strings.Repeat(".", 46) + "OK\n  · c2                                       n/a" + strings.Repeat(".", 52) + "E\n\nYour fleet score: 100 -- A\n\n"
+)
@@ -1,6 +1,31 @@
 package report
 
-var htmlTemplate = `
+var htmlTemplate = htmlHead + `
+<body>
+  <div class="sanitizer">
+    <div class="title">Popeye K8s Sanitizer Report</div>
+    <div class="summary">
+      <a class="popeye-logo" href="https://github.com/derailed/popeye">
+        <img class="logo" src="https://github.com/derailed/popeye/raw/master/assets/popeye_logo.png" />
+      </a>
+      <div class="score-summary">
+        Scanned
+        <span class="cluster">{{ .ClusterName }}</span>
+      </div>
+      <div class="scorer">
+        <span class="grade grade-{{ .Report.Grade }}">{{ .Report.Grade }}</span>
+        <span class="section-score cluster-score"> {{ .Report.Score }} </span>
+      </div>
+    </div>
+
+    {{ template "sections" . }}
+  </div>
+</body>
+
+</html>
+`
+
+var htmlHead = `
 <html>
 <head>
   <title>Popeye Sanitizer Report</title>
@@ -176,25 +201,34 @@ var htmlTemplate = `
     text-align: right;
   }
 </style>
+`
 
-<body>
-  <div class="sanitizer">
-    <div class="title">Popeye K8s Sanitizer Report</div>
-    <div class="summary">
-      <a class="popeye-logo" href="https://github.com/derailed/popeye">
-        <img class="logo" src="https://github.com/derailed/popeye/raw/master/assets/popeye_logo.png" />
-      </a>
-      <div class="score-summary">
-        Scanned
-        <span class="cluster">{{ .ClusterName }}</span>
-      </div>
-      <div class="scorer">
-        <span class="grade grade-{{ .Report.Grade }}">{{ .Report.Grade }}</span>
-        <span class="section-score cluster-score"> {{ .Report.Score }} </span>
+var htmlSections = `{{ if .Report.Skips }}
+    <div class="section">
+      <hr />
+      <div class="section-title">
+        {{ toTitle "skipped" -1 }}
       </div>
+      <ul class="outcome">
+        {{ range $skip := .Report.Skips }}
+        <li>
+          <div class="outcome level-1">
+            {{ $skip.Section }}
+          </div>
+          <div class="outcome-score level-1">
+            <i class="{{ toEmoji 1 }}"></i>
+          </div>
+          <div class="clear"></div>
+          <ul class="issues">
+            <li>
+              <span class=" msg level-1">{{ $skip.Reason }}</span>
+            </li>
+          </ul>
+        </li>
+        {{ end }}
+      </ul>
     </div>
-
-    {{ range $section := .Report.Sections }}
+    {{ end }}{{ range $section := .Report.Sections }}
     <div class="section">
       <hr />
       <div class="section-title">
@@ -259,9 +293,4 @@ var htmlTemplate = `
       {{ end }}
       </ul>
     </div>
-    {{ end }}
-  </div>
-</body>
-
-</html>
-`
+    {{ end }}`
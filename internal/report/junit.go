@@ -38,6 +38,7 @@ type TestCase struct {
 	Name      string   `xml:"name,attr"`
 	Failures  []Failure
 	Errors    []Error
+	Skipped   *Skipped
 }
 
 // Property represents key/value pair.
@@ -53,6 +54,12 @@ type Failure struct {
 	Type    string   `xml:"type,attr"`
 }
 
+// Skipped represents a test that was not evaluated.
+type Skipped struct {
+	XMLName xml.Name `xml:"skipped"`
+	Message string   `xml:"message,attr"`
+}
+
 // Error represents a test error..
 type Error struct {
 	XMLName xml.Name `xml:"error"`
@@ -70,6 +77,9 @@ func junitMarshal(b *Builder, level config.Level) ([]byte, error) {
 	for _, section := range b.Report.Sections {
 		s.Suites = append(s.Suites, newSuite(section, level))
 	}
+	if len(b.Report.Skips) > 0 {
+		s.Suites = append(s.Suites, newSkippedSuite(b.Report.Skips))
+	}
 
 	return xml.MarshalIndent(s, "", "\t")
 }
@@ -90,6 +100,21 @@ func newSuite(s Section, level config.Level) TestSuite {
 	return ts
 }
 
+func newSkippedSuite(ss []Skip) TestSuite {
+	ts := TestSuite{
+		Name:  "skipped",
+		Tests: len(ss),
+	}
+	for _, s := range ss {
+		ts.TestCases = append(ts.TestCases, TestCase{
+			Name:    s.Section,
+			Skipped: &Skipped{Message: s.Reason},
+		})
+	}
+
+	return ts
+}
+
 func newTestCase(res string, ii issues.Issues) TestCase {
 	ns, n := namespaced(res)
 	tc := TestCase{
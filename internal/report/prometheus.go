@@ -57,7 +57,12 @@ var (
 
 func prometheusMarshal(b *Builder, gtwy *config.PushGateway, cluster, namespace string) *push.Pusher {
 	pusher := newPusher(gtwy)
+	setMetrics(b, cluster, namespace)
 
+	return pusher
+}
+
+func setMetrics(b *Builder, cluster, namespace string) {
 	score.WithLabelValues(cluster, namespace, b.Report.Grade).Set(float64(b.Report.Score))
 	errs.WithLabelValues(cluster, namespace).Set(float64(len(b.Report.Errors)))
 
@@ -68,13 +73,25 @@ func prometheusMarshal(b *Builder, gtwy *config.PushGateway, cluster, namespace
 		}
 		sanitizersScore.WithLabelValues(cluster, namespace, section.Title).Set(float64(section.Tally.score))
 	}
-	return pusher
 }
 
-func newPusher(gtwy *config.PushGateway) *push.Pusher {
+func resetMetrics() {
+	score.Reset()
+	errs.Reset()
+	sanitizers.Reset()
+	sanitizersScore.Reset()
+}
+
+// NewRegistry returns a registry tracking the sanitizers metrics.
+func NewRegistry() *prometheus.Registry {
 	registry := prometheus.NewRegistry()
 	registry.MustRegister(score, errs, sanitizers, sanitizersScore)
-	p := push.New(*gtwy.Address, "popeye").Gatherer(registry)
+
+	return registry
+}
+
+func newPusher(gtwy *config.PushGateway) *push.Pusher {
+	p := push.New(*gtwy.Address, "popeye").Gatherer(NewRegistry())
 	if isSet(gtwy.BasicAuth.User) && isSet(gtwy.BasicAuth.Password) {
 		fmt.Println("Using auth! ", *gtwy.BasicAuth.User, *gtwy.BasicAuth.Password)
 		p = p.BasicAuth(*gtwy.BasicAuth.User, *gtwy.BasicAuth.Password)
@@ -31,6 +31,16 @@ func (t *Tally) Score() int {
 	return t.score
 }
 
+// OkCount returns the number of resources without concerns.
+func (t *Tally) OkCount() int {
+	return t.counts[0]
+}
+
+// InfoCount returns the number of infos found.
+func (t *Tally) InfoCount() int {
+	return t.counts[1]
+}
+
 // ErrCount returns the number of errors found.
 func (t *Tally) ErrCount() int {
 	return t.counts[3]
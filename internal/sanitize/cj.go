@@ -0,0 +1,155 @@
+package sanitize
+
+import (
+	"context"
+	"time"
+
+	"github.com/derailed/popeye/internal"
+	"github.com/derailed/popeye/internal/issues"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+const (
+	// maxJobsHistory tracks the max number of finished jobs a CronJob should retain.
+	maxJobsHistory = 10
+
+	// maxFailedJobs tracks the number of failed jobs past which they are piling up.
+	maxFailedJobs = 3
+)
+
+type (
+	// CronJobLister list available CronJobs on a cluster.
+	CronJobLister interface {
+		ListCronJobs() map[string]*batchv1.CronJob
+	}
+
+	// CJLister represents cronjobs and deps listers.
+	CJLister interface {
+		CronJobLister
+		JobLister
+		PodSecurityLister
+	}
+
+	// CronJob tracks CronJob sanitization.
+	CronJob struct {
+		*issues.Collector
+		CJLister
+	}
+)
+
+// NewCronJob returns a new sanitizer.
+func NewCronJob(co *issues.Collector, lister CJLister) *CronJob {
+	return &CronJob{
+		Collector: co,
+		CJLister:  lister,
+	}
+}
+
+// Sanitize cleanse the resource.
+func (c *CronJob) Sanitize(ctx context.Context) error {
+	now := time.Now()
+	for fqn, cj := range c.ListCronJobs() {
+		c.InitOutcome(fqn)
+		ctx = internal.WithFQN(ctx, fqn)
+
+		checkDeprecation(ctx, c.Collector, "CronJob", cj)
+		c.checkHistory(ctx, cj)
+		jobs := c.ownedJobs(cj)
+		c.checkFailedJobs(ctx, jobs)
+		c.checkSchedule(ctx, now, cj, jobs)
+		c.checkContainers(ctx, cj.Spec.JobTemplate.Spec.Template.Spec)
+		checkHardening(ctx, c.Collector, cj.Spec.JobTemplate.Spec.Template)
+		checkPodSecurity(ctx, c.Collector, c, cj.Namespace, cj.Spec.JobTemplate.Spec.Template)
+
+		if c.NoConcerns(fqn) && c.Config.ExcludeFQN(internal.MustExtractSectionGVR(ctx), fqn) {
+			c.ClearOutcome(fqn)
+		}
+	}
+
+	return nil
+}
+
+// CheckHistory checks if the CronJob retains too many finished jobs.
+func (c *CronJob) checkHistory(ctx context.Context, cj *batchv1.CronJob) {
+	if l := cj.Spec.SuccessfulJobsHistoryLimit; l != nil && *l > maxJobsHistory {
+		c.AddCode(ctx, 1406, *l, maxJobsHistory)
+	}
+	if l := cj.Spec.FailedJobsHistoryLimit; l != nil && *l > maxJobsHistory {
+		c.AddCode(ctx, 1407, *l, maxJobsHistory)
+	}
+}
+
+// CheckFailedJobs checks if failed jobs are piling up.
+func (c *CronJob) checkFailedJobs(ctx context.Context, jobs []*batchv1.Job) {
+	var failed int
+	for _, job := range jobs {
+		if _, ok := jobFailed(job); ok {
+			failed++
+		}
+	}
+	if failed >= maxFailedJobs {
+		c.AddCode(ctx, 1402, failed)
+	}
+}
+
+// CheckSchedule checks the CronJob schedule, missed runs and overlapping jobs.
+func (c *CronJob) checkSchedule(ctx context.Context, now time.Time, cj *batchv1.CronJob, jobs []*batchv1.Job) {
+	s, err := parseSchedule(cj.Spec.Schedule)
+	if err != nil {
+		c.AddCode(ctx, 1404, cj.Spec.Schedule, err)
+		return
+	}
+	if cj.Spec.Suspend != nil && *cj.Spec.Suspend {
+		c.AddCode(ctx, 1403)
+		return
+	}
+
+	if last := cj.Status.LastScheduleTime; last != nil {
+		// Missing the next run may just be a delay. Missing the one after is not.
+		if n := s.next(s.next(last.Time)); !n.IsZero() && now.After(n) {
+			c.AddCode(ctx, 1405, duration.HumanDuration(now.Sub(last.Time)))
+		}
+	}
+
+	if cj.Spec.ConcurrencyPolicy != "" && cj.Spec.ConcurrencyPolicy != batchv1.AllowConcurrent {
+		return
+	}
+	interval := s.interval(now)
+	if interval == 0 {
+		return
+	}
+	for _, job := range jobs {
+		if jobFinished(job) || job.Status.StartTime == nil {
+			continue
+		}
+		if now.Sub(job.Status.StartTime.Time) > interval {
+			c.AddCode(ctx, 1408, job.Name, duration.HumanDuration(interval))
+			return
+		}
+	}
+}
+
+// CheckContainers runs thru cronjob template and checks pod configuration.
+func (c *CronJob) checkContainers(ctx context.Context, spec v1.PodSpec) {
+	co := NewContainer(internal.MustExtractFQN(ctx), c)
+	for _, ic := range spec.InitContainers {
+		co.sanitize(ctx, ic, false)
+	}
+	for _, cc := range spec.Containers {
+		co.sanitize(ctx, cc, false)
+	}
+}
+
+// OwnedJobs returns the jobs spawned by the given CronJob.
+func (c *CronJob) ownedJobs(cj *batchv1.CronJob) []*batchv1.Job {
+	var jobs []*batchv1.Job
+	for _, job := range c.ListJobs() {
+		if job.Namespace == cj.Namespace && isOwnedBy(job.OwnerReferences, "CronJob", cj.Name) {
+			jobs = append(jobs, job)
+		}
+	}
+
+	return jobs
+}
@@ -0,0 +1,178 @@
+package sanitize
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/pkg/config"
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCronJobSanitize(t *testing.T) {
+	gvr := client.NewGVR("batch/v1/cronjobs")
+	uu := map[string]struct {
+		opts   cjOpts
+		jobs   []*batchv1.Job
+		issues issues.Issues
+	}{
+		"good": {
+			opts:   cjOpts{schedule: "*/5 * * * *", last: ago(time.Minute)},
+			jobs:   []*batchv1.Job{makeJob("j1", jobOpts{owner: "cj1", completed: true})},
+			issues: issues.Issues{},
+		},
+		"deprecated": {
+			opts: cjOpts{schedule: "*/5 * * * *", rev: "batch/v1beta1"},
+			issues: issues.Issues{
+				issues.New(gvr, issues.Root, config.WarnLevel, `[POP-403] Deprecated CronJob API group "batch/v1beta1" removed in v1.25. Use "batch/v1/cronjobs" instead`),
+			},
+		},
+		"invalid": {
+			opts: cjOpts{schedule: "*/5 * * *"},
+			issues: issues.Issues{
+				issues.New(gvr, issues.Root, config.ErrorLevel, `[POP-1404] Invalid schedule "*/5 * * *" -- expected 5 fields but found 4`),
+			},
+		},
+		"suspended": {
+			opts: cjOpts{schedule: "*/5 * * * *", suspend: true, last: ago(24 * time.Hour)},
+			issues: issues.Issues{
+				issues.New(gvr, issues.Root, config.InfoLevel, "[POP-1403] CronJob is suspended"),
+			},
+		},
+		"missed": {
+			opts: cjOpts{schedule: "*/5 * * * *", last: ago(2 * time.Hour)},
+			issues: issues.Issues{
+				issues.New(gvr, issues.Root, config.WarnLevel, "[POP-1405] Last scheduled run was 120m ago. Missed scheduled runs?"),
+			},
+		},
+		"history": {
+			opts: cjOpts{schedule: "@daily", successful: 20, failed: 15},
+			issues: issues.Issues{
+				issues.New(gvr, issues.Root, config.InfoLevel, "[POP-1406] Successful jobs history limit 20 exceeds 10"),
+				issues.New(gvr, issues.Root, config.InfoLevel, "[POP-1407] Failed jobs history limit 15 exceeds 10"),
+			},
+		},
+		"failedJobs": {
+			opts: cjOpts{schedule: "@daily"},
+			jobs: []*batchv1.Job{
+				makeJob("j1", jobOpts{owner: "cj1", failed: "DeadlineExceeded"}),
+				makeJob("j2", jobOpts{owner: "cj1", failed: "DeadlineExceeded"}),
+				makeJob("j3", jobOpts{owner: "cj1", failed: "BackoffLimitExceeded"}),
+				makeJob("j4", jobOpts{owner: "cj2", failed: "BackoffLimitExceeded"}),
+			},
+			issues: issues.Issues{
+				issues.New(gvr, issues.Root, config.WarnLevel, "[POP-1402] 3 failed jobs piling up"),
+			},
+		},
+		"longRunning": {
+			opts: cjOpts{schedule: "*/5 * * * *", last: ago(time.Minute)},
+			jobs: []*batchv1.Job{makeJob("j1", jobOpts{owner: "cj1", started: ago(time.Hour)})},
+			issues: issues.Issues{
+				issues.New(gvr, issues.Root, config.WarnLevel, `[POP-1408] Concurrency policy Allow with job "j1" running longer than the schedule interval (5m)`),
+			},
+		},
+		"longRunningForbid": {
+			opts:   cjOpts{schedule: "*/5 * * * *", last: ago(time.Minute), policy: batchv1.ForbidConcurrent},
+			jobs:   []*batchv1.Job{makeJob("j1", jobOpts{owner: "cj1", started: ago(time.Hour)})},
+			issues: issues.Issues{},
+		},
+		"container": {
+			opts: cjOpts{schedule: "@daily", coOpts: coOpts{image: "fred:latest", rcpu: "10m", rmem: "10Mi"}},
+			issues: issues.Issues{
+				issues.New(client.NewGVR("containers"), "c1", config.WarnLevel, `[POP-101] Image tagged "latest" in use`),
+				issues.New(client.NewGVR("containers"), "c1", config.WarnLevel, "[POP-107] No resource limits defined"),
+			},
+		},
+	}
+
+	ctx := makeContext("batch/v1/cronjobs", "cronjobs")
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			l := cjLister{
+				cjs:       map[string]*batchv1.CronJob{"default/cj1": makeCronJob("cj1", u.opts)},
+				jobLister: jobLister{jobs: make(map[string]*batchv1.Job, len(u.jobs))},
+			}
+			for _, j := range u.jobs {
+				l.jobs["default/"+j.Name] = j
+			}
+			cj := NewCronJob(issues.NewCollector(loadCodes(t), makeConfig(t)), &l)
+
+			assert.Nil(t, cj.Sanitize(ctx))
+			assert.Equal(t, u.issues, cj.Outcome()["default/cj1"])
+		})
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+type cjLister struct {
+	jobLister
+	cjs map[string]*batchv1.CronJob
+}
+
+func (l *cjLister) ListNamespaces() map[string]*v1.Namespace {
+	return map[string]*v1.Namespace{}
+}
+
+func (l *cjLister) ListCronJobs() map[string]*batchv1.CronJob {
+	return l.cjs
+}
+
+type cjOpts struct {
+	coOpts
+	schedule           string
+	rev                string
+	suspend            bool
+	last               *metav1.Time
+	successful, failed int32
+	policy             batchv1.ConcurrencyPolicy
+}
+
+func makeCronJob(n string, opts cjOpts) *batchv1.CronJob {
+	if opts.image == "" {
+		opts.image, opts.rcpu, opts.rmem, opts.lcpu, opts.lmem = "fred:0.0.1", "10m", "10Mi", "10m", "10Mi"
+	}
+	cj := batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: n, Namespace: "default"},
+		Spec: batchv1.CronJobSpec{
+			Schedule:          opts.schedule,
+			Suspend:           &opts.suspend,
+			ConcurrencyPolicy: opts.policy,
+			JobTemplate: batchv1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{
+					Template: v1.PodTemplateSpec{
+						Spec: v1.PodSpec{
+							Containers: []v1.Container{makeContainer("c1", opts.coOpts)},
+						},
+					},
+				},
+			},
+		},
+		Status: batchv1.CronJobStatus{LastScheduleTime: opts.last},
+	}
+	if opts.rev != "" {
+		cj.Annotations = map[string]string{
+			"kubectl.kubernetes.io/last-applied-configuration": `{"apiVersion": "` + opts.rev + `", "kind": "CronJob"}`,
+		}
+	}
+	if opts.successful > 0 {
+		cj.Spec.SuccessfulJobsHistoryLimit = &opts.successful
+	}
+	if opts.failed > 0 {
+		cj.Spec.FailedJobsHistoryLimit = &opts.failed
+	}
+	hardenTemplate(&cj.Spec.JobTemplate.Spec.Template)
+
+	return &cj
+}
+
+func ago(d time.Duration) *metav1.Time {
+	t := metav1.NewTime(time.Now().Add(-d))
+	return &t
+}
@@ -2,19 +2,14 @@ package sanitize
 
 import (
 	"context"
-	"strings"
 
 	"github.com/derailed/popeye/internal"
 	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/image"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
-// Docker image latest tag.
-const imageTagLatest = "latest"
-
-const defaultRegistry = "docker.io"
-
 type (
 	// LimitCollector represents a collector with resource limits.
 	LimitCollector interface {
@@ -39,45 +34,28 @@ func (c *Container) sanitize(ctx context.Context, co v1.Container, checkProbes b
 	ctx = internal.WithFQN(ctx, c.fqn)
 	ctx = internal.WithGroup(ctx, client.NewGVR("containers"), co.Name)
 	c.checkImageTags(ctx, co.Image)
-	if c.allowedRegistryListExists() {
-		c.checkImageRegistry(ctx, co.Image)
-	}
+	c.checkImagePolicy(ctx, co)
+	c.checkPullPolicy(ctx, co)
 	c.checkResources(ctx, co)
 	if checkProbes {
 		c.checkProbes(ctx, co)
 	}
 	c.checkNamedPorts(ctx, co)
+	c.checkCredentials(ctx, co)
 }
 
-func (c *Container) checkImageTags(ctx context.Context, image string) {
-	tokens := strings.Split(image, ":")
-	if len(tokens) < 2 {
+func (c *Container) checkImageTags(ctx context.Context, img string) {
+	ref := image.Parse(img)
+	if ref.Tag == "" && ref.Digest == "" {
 		c.AddSubCode(ctx, 100)
 		return
 	}
 
-	if tokens[1] == imageTagLatest {
+	if ref.Tag == image.LatestTag {
 		c.AddSubCode(ctx, 101)
 	}
 }
 
-func (c *Container) checkImageRegistry(ctx context.Context, image string) {
-	registries := c.LimitCollector.AllowedRegistries()
-	tokens := strings.Split(image, "/")
-
-	if len(tokens) == 1 {
-		tokens[0] = defaultRegistry
-	}
-
-	for i := 0; i < len(registries); i++ {
-		if tokens[0] == registries[i] {
-			return
-		}
-	}
-
-	c.AddSubCode(ctx, 113, image)
-}
-
 func (c *Container) checkProbes(ctx context.Context, co v1.Container) {
 	if co.LivenessProbe == nil && co.ReadinessProbe == nil {
 		c.AddSubCode(ctx, 102)
@@ -93,6 +71,7 @@ func (c *Container) checkProbes(ctx context.Context, co v1.Container) {
 		c.AddSubCode(ctx, 104)
 	}
 	c.checkNamedProbe(ctx, co.ReadinessProbe, false)
+	c.checkProbesQuality(ctx, co)
 }
 
 func (c *Container) checkNamedProbe(ctx context.Context, p *v1.Probe, liveness bool) {
@@ -160,7 +139,3 @@ func (c *Container) checkMetrics(ctx context.Context, qos qos, list, clist v1.Re
 		}
 	}
 }
-
-func (c *Container) allowedRegistryListExists() bool {
-	return len(c.LimitCollector.AllowedRegistries()) > 0
-}
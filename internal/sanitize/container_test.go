@@ -177,6 +177,8 @@ func TestContainerCheckImageTags(t *testing.T) {
 		"cool":   {image: "cool:1.2.3", issues: 0},
 		"noRev":  {pissues: 1, image: "fred", issues: 1, severity: config.ErrorLevel},
 		"latest": {pissues: 1, image: "fred:latest", issues: 1, severity: config.WarnLevel},
+		"port":   {pissues: 1, image: "localhost:5000/fred", issues: 1, severity: config.ErrorLevel},
+		"digest": {image: "fred@sha256:4f0c5b2a", issues: 0},
 	}
 
 	ctx := makeContext("containers", "container")
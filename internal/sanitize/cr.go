@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/derailed/popeye/internal"
+	"github.com/derailed/popeye/internal/authz"
 	"github.com/derailed/popeye/internal/cache"
 	"github.com/derailed/popeye/internal/issues"
 )
@@ -43,10 +44,15 @@ func (c *ClusterRole) Sanitize(ctx context.Context) error {
 }
 
 func (c *ClusterRole) checkInUse(ctx context.Context, refs *sync.Map) {
-	for fqn := range c.ListClusterRoles() {
+	for fqn, cr := range c.ListClusterRoles() {
 		c.InitOutcome(fqn)
 		ctx = internal.WithFQN(ctx, fqn)
 
+		checkDeprecation(ctx, c.Collector, "ClusterRole", cr)
+		if checkRules(ctx, c.Collector, cr.ObjectMeta, cr.Rules) {
+			c.checkExposure(ctx, cr.Name)
+		}
+
 		_, ok := refs.Load(cache.ResFqn(cache.ClusterRoleKey, fqn))
 		if !ok {
 			c.AddCode(ctx, 400)
@@ -56,3 +62,19 @@ func (c *ClusterRole) checkInUse(ctx context.Context, refs *sync.Map) {
 		}
 	}
 }
+
+// CheckExposure checks if the cluster role is bound to public subjects.
+func (c *ClusterRole) checkExposure(ctx context.Context, name string) {
+	crbs := c.ListClusterRoleBindings()
+	for _, fqn := range authz.ClusterRoleBindingKeys(crbs) {
+		if crb := crbs[fqn]; crb.RoleRef.Kind == "ClusterRole" && crb.RoleRef.Name == name {
+			checkExposure(ctx, c.Collector, "ClusterRoleBinding", fqn, crb.Subjects)
+		}
+	}
+	rbs := c.ListRoleBindings()
+	for _, fqn := range authz.RoleBindingKeys(rbs) {
+		if rb := rbs[fqn]; rb.RoleRef.Kind == "ClusterRole" && rb.RoleRef.Name == name {
+			checkExposure(ctx, c.Collector, "RoleBinding", fqn, rb.Subjects)
+		}
+	}
+}
@@ -54,6 +54,7 @@ func (c *ClusterRoleBinding) checkInUse(ctx context.Context) {
 		c.InitOutcome(fqn)
 		ctx = internal.WithFQN(ctx, fqn)
 
+		checkDeprecation(ctx, c.Collector, "ClusterRoleBinding", crb)
 		switch crb.RoleRef.Kind {
 		case "ClusterRole":
 			if _, ok := c.ListClusterRoles()[crb.RoleRef.Name]; !ok {
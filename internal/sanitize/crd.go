@@ -0,0 +1,102 @@
+package sanitize
+
+import (
+	"context"
+
+	"github.com/derailed/popeye/internal"
+	"github.com/derailed/popeye/internal/issues"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type (
+	// CRDLister list available CustomResourceDefinitions on a cluster.
+	CRDLister interface {
+		ListCustomResourceDefinitions() map[string]*unstructured.Unstructured
+	}
+
+	// CustomResourceDefinition tracks CustomResourceDefinition sanitization.
+	CustomResourceDefinition struct {
+		*issues.Collector
+		CRDLister
+	}
+
+	// crdVersion represents a crd served version.
+	crdVersion struct {
+		name                        string
+		served, storage, deprecated bool
+	}
+)
+
+// NewCustomResourceDefinition returns a new sanitizer.
+func NewCustomResourceDefinition(co *issues.Collector, lister CRDLister) *CustomResourceDefinition {
+	return &CustomResourceDefinition{
+		Collector: co,
+		CRDLister: lister,
+	}
+}
+
+// Sanitize cleanse the resource.
+func (c *CustomResourceDefinition) Sanitize(ctx context.Context) error {
+	for fqn, crd := range c.ListCustomResourceDefinitions() {
+		c.InitOutcome(fqn)
+		ctx = internal.WithFQN(ctx, fqn)
+
+		checkDeprecation(ctx, c.Collector, "CustomResourceDefinition", crd)
+		c.checkVersions(ctx, crd)
+
+		if c.NoConcerns(fqn) && c.Config.ExcludeFQN(internal.MustExtractSectionGVR(ctx), fqn) {
+			c.ClearOutcome(fqn)
+		}
+	}
+
+	return nil
+}
+
+// CheckVersions checks for deprecated versions still being served.
+func (c *CustomResourceDefinition) checkVersions(ctx context.Context, crd *unstructured.Unstructured) {
+	group, _, _ := unstructured.NestedString(crd.Object, "spec", "group")
+	plural, _, _ := unstructured.NestedString(crd.Object, "spec", "names", "plural")
+	vv := crdVersions(crd)
+
+	var replacement string
+	for _, v := range vv {
+		if !v.served || v.deprecated {
+			continue
+		}
+		if replacement == "" || v.storage {
+			replacement = group + "/" + v.name + "/" + plural
+		}
+	}
+	for _, v := range vv {
+		if !v.served || !v.deprecated {
+			continue
+		}
+		if replacement == "" {
+			c.AddCode(ctx, 1701, v.name)
+			continue
+		}
+		c.AddCode(ctx, 1700, v.name, replacement)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func crdVersions(crd *unstructured.Unstructured) []crdVersion {
+	vv, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	cc := make([]crdVersion, 0, len(vv))
+	for _, v := range vv {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		var cv crdVersion
+		cv.name, _, _ = unstructured.NestedString(m, "name")
+		cv.served, _, _ = unstructured.NestedBool(m, "served")
+		cv.storage, _, _ = unstructured.NestedBool(m, "storage")
+		cv.deprecated, _, _ = unstructured.NestedBool(m, "deprecated")
+		cc = append(cc, cv)
+	}
+
+	return cc
+}
@@ -0,0 +1,139 @@
+package sanitize
+
+import (
+	"testing"
+
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestCRDSanitize(t *testing.T) {
+	uu := map[string]struct {
+		rev    string
+		vv     []interface{}
+		issues issues.Issues
+	}{
+		"good": {
+			rev: "apiextensions.k8s.io/v1",
+			vv: []interface{}{
+				crdVersion{name: "v1", served: true, storage: true}.toMap(),
+			},
+			issues: issues.Issues{},
+		},
+		"notServed": {
+			rev: "apiextensions.k8s.io/v1",
+			vv: []interface{}{
+				crdVersion{name: "v1alpha1", deprecated: true}.toMap(),
+				crdVersion{name: "v1", served: true, storage: true}.toMap(),
+			},
+			issues: issues.Issues{},
+		},
+		"deprecatedServed": {
+			rev: "apiextensions.k8s.io/v1",
+			vv: []interface{}{
+				crdVersion{name: "v1beta1", served: true, deprecated: true}.toMap(),
+				crdVersion{name: "v1alpha1", served: true}.toMap(),
+				crdVersion{name: "v1", served: true, storage: true}.toMap(),
+			},
+			issues: issues.Issues{
+				issues.Issue{
+					GVR:     "apiextensions.k8s.io/v1/customresourcedefinitions",
+					Group:   issues.Root,
+					Level:   config.WarnLevel,
+					Message: `[POP-1700] Served version "v1beta1" is deprecated. Use "fred.io/v1/freds" instead`,
+				},
+			},
+		},
+		"noReplacement": {
+			rev: "apiextensions.k8s.io/v1",
+			vv: []interface{}{
+				crdVersion{name: "v1beta1", served: true, storage: true, deprecated: true}.toMap(),
+			},
+			issues: issues.Issues{
+				issues.Issue{
+					GVR:     "apiextensions.k8s.io/v1/customresourcedefinitions",
+					Group:   issues.Root,
+					Level:   config.WarnLevel,
+					Message: `[POP-1701] Served version "v1beta1" is deprecated with no replacement version`,
+				},
+			},
+		},
+		"removedAPI": {
+			rev: "apiextensions.k8s.io/v1beta1",
+			vv: []interface{}{
+				crdVersion{name: "v1", served: true, storage: true}.toMap(),
+			},
+			issues: issues.Issues{
+				issues.Issue{
+					GVR:     "apiextensions.k8s.io/v1/customresourcedefinitions",
+					Group:   issues.Root,
+					Level:   config.WarnLevel,
+					Message: `[POP-403] Deprecated CustomResourceDefinition API group "apiextensions.k8s.io/v1beta1" removed in v1.22. Use "apiextensions.k8s.io/v1/customresourcedefinitions" instead`,
+				},
+			},
+		},
+	}
+
+	ctx := makeContext("apiextensions.k8s.io/v1/customresourcedefinitions", "crd")
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			crd := NewCustomResourceDefinition(
+				issues.NewCollector(loadCodes(t), makeConfig(t)),
+				makeCRDLister(u.rev, u.vv),
+			)
+
+			assert.Nil(t, crd.Sanitize(ctx))
+			assert.Equal(t, u.issues, crd.Outcome()["freds.fred.io"])
+		})
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+type crdLister struct {
+	rev string
+	vv  []interface{}
+}
+
+func makeCRDLister(rev string, vv []interface{}) crdLister {
+	return crdLister{rev: rev, vv: vv}
+}
+
+func (c crdLister) ListCustomResourceDefinitions() map[string]*unstructured.Unstructured {
+	return map[string]*unstructured.Unstructured{
+		"freds.fred.io": makeCRD(c.rev, c.vv),
+	}
+}
+
+func makeCRD(rev string, vv []interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": rev,
+			"kind":       "CustomResourceDefinition",
+			"metadata": map[string]interface{}{
+				"name": "freds.fred.io",
+			},
+			"spec": map[string]interface{}{
+				"group": "fred.io",
+				"names": map[string]interface{}{
+					"plural": "freds",
+					"kind":   "Fred",
+				},
+				"versions": vv,
+			},
+		},
+	}
+}
+
+func (v crdVersion) toMap() map[string]interface{} {
+	return map[string]interface{}{
+		"name":       v.name,
+		"served":     v.served,
+		"storage":    v.storage,
+		"deprecated": v.deprecated,
+	}
+}
@@ -0,0 +1,149 @@
+package sanitize
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// MinSecretLen tracks the minimal length of a high entropy secret.
+	minSecretLen = 32
+
+	// MinSecretEntropy tracks the shannon entropy above which a value looks random.
+	minSecretEntropy = 4.5
+
+	// MinNamedSecretLen tracks the minimal length of a secret assigned to a sensitive name.
+	minNamedSecretLen = 8
+
+	// MinNamedSecretEntropy tracks the entropy of a secret assigned to a sensitive name.
+	minNamedSecretEntropy = 2.5
+)
+
+var (
+	// CredentialPatterns tracks well known credential formats.
+	credentialPatterns = []struct {
+		kind string
+		rx   *regexp.Regexp
+	}{
+		{kind: "private key", rx: regexp.MustCompile(`-----BEGIN (?:[A-Z0-9]+ )*PRIVATE KEY-----`)},
+		{kind: "AWS access key", rx: regexp.MustCompile(`\b(?:AKIA|ASIA|ABIA|ACCA)[0-9A-Z]{16}\b`)},
+		{kind: "JSON web token", rx: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{8,}\.eyJ[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}`)},
+	}
+
+	// BasicAuthRX tracks urls with embedded credentials.
+	basicAuthRX = regexp.MustCompile(`[A-Za-z][A-Za-z0-9+.-]*://[^\s/@:]+:([^\s/@]+)@`)
+
+	// SensitiveNameRX tracks names hinting at a secret value.
+	sensitiveNameRX = regexp.MustCompile(`(?i)(passw(or)?d|pwd|secret|token|api[_-]?key|access[_-]?key|private[_-]?key|credential)`)
+
+	// SecretRefNameRX tracks names referencing a Secret or a file rather than holding a secret.
+	secretRefNameRX = regexp.MustCompile(`(?i)(secret[_-]?(name|ref)|_secret|[_-]file)\z`)
+
+	// SecretTokenRX tracks values shaped like a base64 or hex encoded secret.
+	secretTokenRX = regexp.MustCompile(`\A[A-Za-z0-9+/=_\-.]+\z`)
+)
+
+// CheckCredentials checks container env, command and args for hard-coded secrets.
+func (c *Container) checkCredentials(ctx context.Context, co v1.Container) {
+	for _, e := range co.Env {
+		if e.Value == "" || c.AllowedEnv(e.Name) {
+			continue
+		}
+		if kind, ok := detectSecret(e.Name, e.Value); ok {
+			c.AddSubCode(ctx, 114, kind, fmt.Sprintf("env %q", e.Name))
+		}
+	}
+	c.checkArgsCredentials(ctx, "command", co.Command)
+	c.checkArgsCredentials(ctx, "args", co.Args)
+}
+
+func (c *Container) checkArgsCredentials(ctx context.Context, field string, args []string) {
+	for i, arg := range args {
+		name, value := "", arg
+		if tokens := strings.SplitN(arg, "=", 2); len(tokens) == 2 && strings.HasPrefix(tokens[0], "-") {
+			name, value = tokens[0], tokens[1]
+		} else if i > 0 && strings.HasPrefix(args[i-1], "-") {
+			name = args[i-1]
+		}
+		if kind, ok := detectSecret(name, value); ok {
+			c.AddSubCode(ctx, 114, kind, fmt.Sprintf("%s[%d]", field, i))
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+// DetectSecret returns the kind of secret a value holds if any. The name assigned to
+// the value lowers the entropy required to flag it when it hints at a secret.
+func detectSecret(name, value string) (string, bool) {
+	for _, p := range credentialPatterns {
+		if p.rx.MatchString(value) {
+			return p.kind, true
+		}
+	}
+	for _, m := range basicAuthRX.FindAllStringSubmatch(value, -1) {
+		if !isPlaceholder(m[1]) {
+			return "basic auth credentials", true
+		}
+	}
+	if isPlaceholder(value) || strings.HasPrefix(value, "/") || strings.ContainsAny(value, " \t\n") {
+		return "", false
+	}
+	if name != "" && sensitiveNameRX.MatchString(name) && !isSecretRef(name, value) {
+		if len(value) >= minNamedSecretLen && entropy(value) >= minNamedSecretEntropy {
+			return "secret", true
+		}
+		return "", false
+	}
+	if !secretTokenRX.MatchString(value) {
+		return "", false
+	}
+	if len(value) >= minSecretLen && entropy(value) >= minSecretEntropy {
+		return "high entropy secret", true
+	}
+
+	return "", false
+}
+
+// IsSecretRef checks if a name refers to a file or to a Secret by its resource name.
+func isSecretRef(name, value string) bool {
+	if !secretRefNameRX.MatchString(name) {
+		return false
+	}
+	if strings.HasSuffix(strings.ToLower(name), "file") {
+		return true
+	}
+
+	return len(validation.IsDNS1123Subdomain(value)) == 0
+}
+
+// IsPlaceholder checks if a value references an env var or a template.
+func isPlaceholder(s string) bool {
+	return strings.HasPrefix(s, "$") || strings.Contains(s, "$(") || strings.Contains(s, "${") || strings.Contains(s, "{{")
+}
+
+// Entropy computes the shannon entropy of a string in bits per char.
+func entropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	freqs := make(map[rune]int)
+	for _, r := range s {
+		freqs[r]++
+	}
+	var e float64
+	n := float64(len([]rune(s)))
+	for _, f := range freqs {
+		p := float64(f) / n
+		e -= p * math.Log2(p)
+	}
+
+	return e
+}
@@ -0,0 +1,126 @@
+package sanitize
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/derailed/popeye/internal"
+	"github.com/derailed/popeye/internal/deprecation"
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/internal/offline"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/version"
+)
+
+// unscheduledRemoval tracks deprecated apis without a removal version.
+const unscheduledRemoval = "a future release"
+
+// APIObject represents a versioned resource.
+type APIObject interface {
+	metav1.Object
+	GetObjectKind() schema.ObjectKind
+}
+
+// CheckDeprecation checks a resource api version against the deprecated apis table.
+// When a target version is set, apis removed by that version are reported as errors.
+// Otherwise apis are checked against the cluster version.
+func checkDeprecation(ctx context.Context, c *issues.Collector, kind string, o APIObject) {
+	rev := apiRev(internal.MustExtractFQN(ctx), kind, o)
+	if rev == "" {
+		return
+	}
+	api, ok := deprecation.Default().Lookup(rev, kind)
+	if !ok {
+		return
+	}
+
+	removed := unscheduledRemoval
+	if api.Removed != "" {
+		removed = "v" + api.Removed
+	}
+	target, err := deprecation.ParseVersion(c.Config.TargetVersion())
+	if err != nil {
+		if api.DeprecatedIn(clusterVersion(ctx)) {
+			c.AddCode(ctx, 403, kind, rev, removed, api.Replacement)
+		}
+		return
+	}
+	switch {
+	case api.RemovedIn(target):
+		c.AddCode(ctx, 407, kind, rev, removed, target, api.Replacement)
+	case api.DeprecatedIn(target):
+		c.AddCode(ctx, 403, kind, rev, removed, api.Replacement)
+	}
+}
+
+// ClusterVersion returns the api server version, defaulting to the offline version.
+func clusterVersion(ctx context.Context) deprecation.Version {
+	info, ok := ctx.Value(internal.KeyVersion).(*version.Info)
+	if !ok || info == nil {
+		info = &offline.DefaultVersion
+	}
+	v, err := deprecation.ParseVersion(info.Major + "." + info.Minor)
+	if err != nil {
+		v, _ = deprecation.ParseVersion(offline.DefaultVersion.GitVersion)
+	}
+
+	return v
+}
+
+// ApiRev returns the api version a resource was deployed with.
+func apiRev(fqn, kind string, o APIObject) string {
+	if rev, err := resourceRev(fqn, kind, o.GetAnnotations()); err == nil {
+		return rev
+	}
+	if rev := revFromLink(o.GetSelfLink()); rev != "" {
+		return rev
+	}
+
+	// Manifests retain their declared api version.
+	return o.GetObjectKind().GroupVersionKind().GroupVersion().String()
+}
+
+// ResourceRev is resource was deployed via kubectl check annotation for manifest rev.
+func resourceRev(fqn string, kind string, a map[string]string) (string, error) {
+	raw, ok := a["kubectl.kubernetes.io/last-applied-configuration"]
+	if !ok {
+		return "", fmt.Errorf("Raw resource manifest not available for %s", fqn)
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &m); err != nil {
+		return "", err
+	}
+	if m["kind"] == kind {
+		return m["apiVersion"].(string), nil
+	}
+
+	return "", errors.New("no matching resource kind")
+}
+
+// RevFromLink. extract resource version from selflink.
+func revFromLink(link string) string {
+	tokens := strings.Split(link, "/")
+	if len(tokens) < 4 {
+		return ""
+	}
+	if isVersion(tokens[2]) {
+		return tokens[2]
+	}
+	return path.Join(tokens[2], tokens[3])
+}
+
+func isVersion(s string) bool {
+	vers := []string{"v1", "v1beta1", "v1beta2", "v2beta1", "v2beta2"}
+	for _, v := range vers {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
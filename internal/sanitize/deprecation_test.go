@@ -0,0 +1,125 @@
+package sanitize
+
+import (
+	"context"
+	"testing"
+
+	"github.com/derailed/popeye/internal"
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/pkg/config"
+	"github.com/stretchr/testify/assert"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+)
+
+func TestCheckDeprecation(t *testing.T) {
+	uu := map[string]struct {
+		rev, target, version string
+		issues               issues.Issues
+	}{
+		"current": {
+			rev:    "networking.k8s.io/v1",
+			issues: issues.Issues{},
+		},
+		"deprecated": {
+			rev: "extensions/v1beta1",
+			issues: issues.Issues{
+				issues.Issue{
+					GVR:     "networking.k8s.io/v1/ingresses",
+					Group:   issues.Root,
+					Level:   config.WarnLevel,
+					Message: `[POP-403] Deprecated Ingress API group "extensions/v1beta1" removed in v1.22. Use "networking.k8s.io/v1/ingresses" instead`,
+				},
+			},
+		},
+		"untargetedBeforeDeprecation": {
+			rev:     "networking.k8s.io/v1beta1",
+			version: "18",
+			issues:  issues.Issues{},
+		},
+		"untargetedDeprecated": {
+			rev:     "networking.k8s.io/v1beta1",
+			version: "21",
+			issues: issues.Issues{
+				issues.Issue{
+					GVR:     "networking.k8s.io/v1/ingresses",
+					Group:   issues.Root,
+					Level:   config.WarnLevel,
+					Message: `[POP-403] Deprecated Ingress API group "networking.k8s.io/v1beta1" removed in v1.22. Use "networking.k8s.io/v1/ingresses" instead`,
+				},
+			},
+		},
+		"beforeDeprecation": {
+			rev:    "networking.k8s.io/v1beta1",
+			target: "1.18",
+			issues: issues.Issues{},
+		},
+		"deprecatedInTarget": {
+			rev:    "networking.k8s.io/v1beta1",
+			target: "1.20",
+			issues: issues.Issues{
+				issues.Issue{
+					GVR:     "networking.k8s.io/v1/ingresses",
+					Group:   issues.Root,
+					Level:   config.WarnLevel,
+					Message: `[POP-403] Deprecated Ingress API group "networking.k8s.io/v1beta1" removed in v1.22. Use "networking.k8s.io/v1/ingresses" instead`,
+				},
+			},
+		},
+		"removedInTarget": {
+			rev:    "extensions/v1beta1",
+			target: "v1.25",
+			issues: issues.Issues{
+				issues.Issue{
+					GVR:     "networking.k8s.io/v1/ingresses",
+					Group:   issues.Root,
+					Level:   config.ErrorLevel,
+					Message: `[POP-407] Ingress API group "extensions/v1beta1" removed in v1.22 breaks upgrade to v1.25. Use "networking.k8s.io/v1/ingresses" instead`,
+				},
+			},
+		},
+	}
+
+	ctx := makeContext("networking.k8s.io/v1/ingresses", "ingress")
+	ctx = internal.WithFQN(ctx, "default/ing")
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			c := issues.NewCollector(loadCodes(t), makeTargetConfig(t, u.target))
+			c.InitOutcome("default/ing")
+			ctx := ctx
+			if u.version != "" {
+				ctx = context.WithValue(ctx, internal.KeyVersion, &version.Info{Major: "1", Minor: u.version})
+			}
+			checkDeprecation(ctx, c, "Ingress", makeVersionedIngress(u.rev))
+
+			assert.Equal(t, u.issues, c.Outcome()["default/ing"])
+		})
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func makeTargetConfig(t *testing.T, target string) *config.Config {
+	f := config.NewFlags()
+	f.TargetVersion = &target
+	c, err := config.NewConfig(f)
+	assert.Nil(t, err)
+
+	return c
+}
+
+func makeVersionedIngress(rev string) *netv1.Ingress {
+	return &netv1.Ingress{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: rev,
+			Kind:       "Ingress",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ing",
+			Namespace: "default",
+		},
+	}
+}
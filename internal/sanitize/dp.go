@@ -2,11 +2,7 @@ package sanitize
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
-	"fmt"
-	"path"
-	"strings"
+	"time"
 
 	"github.com/derailed/popeye/internal"
 	"github.com/derailed/popeye/internal/client"
@@ -29,7 +25,9 @@ type DPLister interface {
 	PodSelectorLister
 	ConfigLister
 	DeploymentLister
+	PodSecurityLister
 	ListServiceAccounts() map[string]*v1.ServiceAccount
+	ListNodes() map[string]*v1.Node
 }
 
 // Deployment tracks Deployment sanitization.
@@ -48,14 +46,18 @@ func NewDeployment(co *issues.Collector, lister DPLister) *Deployment {
 
 // Sanitize cleanse the resource.
 func (d *Deployment) Sanitize(ctx context.Context) error {
-	over := pullOverAllocs(ctx)
+	over, now := pullOverAllocs(ctx), time.Now()
 	for fqn, dp := range d.ListDeployments() {
 		d.InitOutcome(fqn)
 		ctx = internal.WithFQN(ctx, fqn)
 
-		d.checkDeprecation(ctx, dp)
+		checkDeprecation(ctx, d.Collector, "Deployment", dp)
 		d.checkDeployment(ctx, dp)
+		checkDeploymentRollout(ctx, d.Collector, now, dp)
+		checkPlacement(ctx, d.Collector, d, dp.Namespace, dp.Spec.Selector, dp.Spec.Replicas, dp.Spec.Template.Spec)
 		d.checkContainers(ctx, dp.Spec.Template.Spec)
+		checkHardening(ctx, d.Collector, dp.Spec.Template)
+		checkPodSecurity(ctx, d.Collector, d, dp.Namespace, dp.Spec.Template)
 		pmx := client.PodsMetrics{}
 		podsMetrics(d, pmx)
 		d.checkUtilization(ctx, over, dp, pmx)
@@ -68,21 +70,6 @@ func (d *Deployment) Sanitize(ctx context.Context) error {
 	return nil
 }
 
-func (d *Deployment) checkDeprecation(ctx context.Context, dp *appsv1.Deployment) {
-	const current = "apps/v1"
-
-	fqn := internal.MustExtractFQN(ctx)
-	rev, err := resourceRev(fqn, "Deployment", dp.Annotations)
-	if err != nil {
-		if rev = revFromLink(dp.SelfLink); rev == "" {
-			return
-		}
-	}
-	if rev != current {
-		d.AddCode(ctx, 403, "Deployment", rev, current)
-	}
-}
-
 // CheckDeployment checks if deployment contract is currently happy or not.
 func (d *Deployment) checkDeployment(ctx context.Context, dp *appsv1.Deployment) {
 	if dp.Spec.Replicas == nil || (dp.Spec.Replicas != nil && *dp.Spec.Replicas == 0) {
@@ -199,43 +186,3 @@ func computePodResources(spec v1.PodSpec) (cpu, mem resource.Quantity) {
 
 	return
 }
-
-// ResourceRev is resource was deployed via kubectl check annotation for manifest rev.
-func resourceRev(fqn string, kind string, a map[string]string) (string, error) {
-	raw, ok := a["kubectl.kubernetes.io/last-applied-configuration"]
-	if !ok {
-		return "", fmt.Errorf("Raw resource manifest not available for %s", fqn)
-	}
-
-	var m map[string]interface{}
-	if err := json.Unmarshal([]byte(raw), &m); err != nil {
-		return "", err
-	}
-	if m["kind"] == kind {
-		return m["apiVersion"].(string), nil
-	}
-
-	return "", errors.New("no matching resource kind")
-}
-
-// RevFromLink. extract resource version from selflink.
-func revFromLink(link string) string {
-	tokens := strings.Split(link, "/")
-	if len(tokens) < 4 {
-		return ""
-	}
-	if isVersion(tokens[2]) {
-		return tokens[2]
-	}
-	return path.Join(tokens[2], tokens[3])
-}
-
-func isVersion(s string) bool {
-	vers := []string{"v1", "v1beta1", "v1beta2", "v2beta1", "v2beta2"}
-	for _, v := range vers {
-		if s == v {
-			return true
-		}
-	}
-	return false
-}
@@ -70,7 +70,7 @@ func TestDPSanitize(t *testing.T) {
 		},
 		"deprecated": {
 			lister: makeDPLister(dpOpts{
-				rev:       "extensions/v1",
+				rev:       "extensions/v1beta1",
 				reps:      1,
 				availReps: 1,
 				coOpts: coOpts{
@@ -88,7 +88,7 @@ func TestDPSanitize(t *testing.T) {
 					client.NewGVR("apps/v1/deployments"),
 					issues.Root,
 					config.WarnLevel,
-					`[POP-403] Deprecated Deployment API group "extensions/v1". Use "apps/v1" instead`,
+					`[POP-403] Deprecated Deployment API group "extensions/v1beta1" removed in v1.16. Use "apps/v1/deployments" instead`,
 				),
 			},
 		},
@@ -131,6 +131,27 @@ func TestDPSanitize(t *testing.T) {
 				issues.New(client.NewGVR("apps/v1/deployments"), issues.Root, config.ErrorLevel, "[POP-501] Unhealthy 1 desired but have 0 available"),
 			},
 		},
+		"hostNetwork": {
+			lister: makeDPLister(dpOpts{
+				rev:       "apps/v1",
+				reps:      1,
+				availReps: 1,
+				coOpts: coOpts{
+					image: "fred:0.0.1",
+					rcpu:  "10m",
+					rmem:  "10Mi",
+					lcpu:  "10m",
+					lmem:  "10Mi",
+				},
+				ccpu:        "10m",
+				cmem:        "10Mi",
+				hostNetwork: true,
+			}),
+			issues: issues.Issues{
+				issues.New(client.NewGVR("apps/v1/deployments"), issues.Root, config.WarnLevel, "[POP-312] Pod shares the host network namespace"),
+				issues.New(client.NewGVR("apps/v1/deployments"), issues.Root, config.InfoLevel, `[POP-1802] Would be rejected if namespace enforced Pod Security Standard "baseline" -- host namespaces`),
+			},
+		},
 	}
 
 	ctx := makeContext("apps/v1/deployments", "deployment")
@@ -348,11 +369,12 @@ func TestDPSanitizeUtilization(t *testing.T) {
 type (
 	dpOpts struct {
 		coOpts
-		rev        string
-		reps       int32
-		availReps  int32
-		collisions int32
-		ccpu, cmem string
+		rev         string
+		reps        int32
+		availReps   int32
+		collisions  int32
+		ccpu, cmem  string
+		hostNetwork bool
 	}
 
 	dp struct {
@@ -404,10 +426,18 @@ func (d *dp) PodMEMLimit() float64 {
 	return 100
 }
 
+func (d *dp) ListNamespaces() map[string]*v1.Namespace {
+	return map[string]*v1.Namespace{}
+}
+
 func (d *dp) ListServiceAccounts() map[string]*v1.ServiceAccount {
 	return nil
 }
 
+func (d *dp) ListNodes() map[string]*v1.Node {
+	return map[string]*v1.Node{}
+}
+
 func (d *dp) ListPodsMetrics() map[string]*mv1beta1.PodMetrics {
 	return map[string]*mv1beta1.PodMetrics{
 		cache.FQN("default", "p1"): makeMxPod(d.opts.ccpu, d.opts.cmem),
@@ -425,7 +455,7 @@ func (d *dp) DeploymentPreferredRev() string {
 }
 
 func makeDP(n string, o dpOpts) *appsv1.Deployment {
-	return &appsv1.Deployment{
+	dp := appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      n,
 			Namespace: "default",
@@ -446,6 +476,9 @@ func makeDP(n string, o dpOpts) *appsv1.Deployment {
 					Containers: []v1.Container{
 						makeContainer("c1", o.coOpts),
 					},
+					TopologySpreadConstraints: []v1.TopologySpreadConstraint{
+						{MaxSkew: 1, TopologyKey: zoneLabel, WhenUnsatisfiable: v1.ScheduleAnyway},
+					},
 				},
 			},
 		},
@@ -454,4 +487,8 @@ func makeDP(n string, o dpOpts) *appsv1.Deployment {
 			CollisionCount:    &o.collisions,
 		},
 	}
+	hardenTemplate(&dp.Spec.Template)
+	dp.Spec.Template.Spec.HostNetwork = o.hostNetwork
+
+	return &dp
 }
@@ -29,6 +29,7 @@ type (
 		PodsMetricsLister
 		PodSelectorLister
 		ConfigLister
+		PodSecurityLister
 		DaemonLister
 	}
 )
@@ -49,8 +50,10 @@ func (d *DaemonSet) Sanitize(ctx context.Context) error {
 		ctx = internal.WithFQN(ctx, fqn)
 
 		d.checkDaemonSet(ctx, ds)
-		d.checkDeprecation(ctx, ds)
+		checkDeprecation(ctx, d.Collector, "DaemonSet", ds)
 		d.checkContainers(ctx, ds.Spec.Template.Spec)
+		checkHardening(ctx, d.Collector, ds.Spec.Template)
+		checkPodSecurity(ctx, d.Collector, d, ds.Namespace, ds.Spec.Template)
 		pmx := client.PodsMetrics{}
 		podsMetrics(d, pmx)
 		d.checkUtilization(ctx, over, ds, pmx)
@@ -72,20 +75,6 @@ func (d *DaemonSet) checkDaemonSet(ctx context.Context, ds *appsv1.DaemonSet) {
 	}
 }
 
-func (d *DaemonSet) checkDeprecation(ctx context.Context, ds *appsv1.DaemonSet) {
-	const current = "apps/v1"
-
-	rev, err := resourceRev(internal.MustExtractFQN(ctx), "DaemonSet", ds.Annotations)
-	if err != nil {
-		if rev = revFromLink(ds.SelfLink); rev == "" {
-			return
-		}
-	}
-	if rev != current {
-		d.AddCode(ctx, 403, "DaemonSet", rev, current)
-	}
-}
-
 // CheckContainers runs thru deployment template and checks pod configuration.
 func (d *DaemonSet) checkContainers(ctx context.Context, spec v1.PodSpec) {
 	c := NewContainer(internal.MustExtractFQN(ctx), d)
@@ -47,10 +47,10 @@ func TestDSSanitize(t *testing.T) {
 				},
 				ccpu: "10m",
 				cmem: "10Mi",
-				rev:  "extensions/v1",
+				rev:  "extensions/v1beta1",
 			}),
 			issues: issues.Issues{
-				issues.Issue{GVR: "apps/v1/deployments", Group: "__root__", Level: config.WarnLevel, Message: `[POP-403] Deprecated DaemonSet API group "extensions/v1". Use "apps/v1" instead`},
+				issues.Issue{GVR: "apps/v1/deployments", Group: "__root__", Level: config.WarnLevel, Message: `[POP-403] Deprecated DaemonSet API group "extensions/v1beta1" removed in v1.16. Use "apps/v1/daemonsets" instead`},
 			},
 		},
 	}
@@ -296,6 +296,10 @@ func (d *ds) PodMEMLimit() float64 {
 	return 100
 }
 
+func (d *ds) ListNamespaces() map[string]*v1.Namespace {
+	return map[string]*v1.Namespace{}
+}
+
 func (d *ds) ListServiceAccounts() map[string]*v1.ServiceAccount {
 	return nil
 }
@@ -313,7 +317,7 @@ func (d *ds) ListDaemonSets() map[string]*appsv1.DaemonSet {
 }
 
 func makeDS(n string, o dsOpts) *appsv1.DaemonSet {
-	return &appsv1.DaemonSet{
+	ds := appsv1.DaemonSet{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      n,
 			Namespace: "default",
@@ -338,4 +342,7 @@ func makeDS(n string, o dsOpts) *appsv1.DaemonSet {
 		},
 		Status: appsv1.DaemonSetStatus{},
 	}
+	hardenTemplate(&ds.Spec.Template)
+
+	return &ds
 }
@@ -0,0 +1,91 @@
+package sanitize
+
+import (
+	"sort"
+
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/pkg/config"
+	v1 "k8s.io/api/core/v1"
+)
+
+// Garbage tracks a resource deemed unused.
+type Garbage struct {
+	GVR     client.GVR
+	FQN     string
+	Reason  string
+	Storage int64
+}
+
+// GarbageCodes tracks the issue codes denoting an unused resource.
+var garbageCodes = map[config.ID]struct{}{
+	210:  {},
+	400:  {},
+	600:  {},
+	601:  {},
+	606:  {},
+	900:  {},
+	1005: {},
+	1110: {},
+	1121: {},
+}
+
+// ComputeGarbage returns the unused resources flagged in a sanitizer outcome sorted by name.
+// Sizes tracks the storage bytes held by each resource if any.
+func ComputeGarbage(gvr client.GVR, o issues.Outcome, sizes map[string]int64) []Garbage {
+	gg := make([]Garbage, 0)
+	for fqn, ii := range o {
+		for _, i := range ii {
+			if i.IsSubIssue() {
+				continue
+			}
+			if code, ok := i.Code(); !ok || !isGarbageCode(code) {
+				continue
+			}
+			gg = append(gg, Garbage{GVR: gvr, FQN: fqn, Reason: i.Message, Storage: sizes[fqn]})
+			break
+		}
+	}
+	sort.Slice(gg, func(i, j int) bool {
+		return gg[i].FQN < gg[j].FQN
+	})
+
+	return gg
+}
+
+// PersistentVolumeSizes returns the storage bytes held by each PersistentVolume.
+func PersistentVolumeSizes(pvs map[string]*v1.PersistentVolume) map[string]int64 {
+	sizes := make(map[string]int64, len(pvs))
+	for fqn, pv := range pvs {
+		if q, ok := pv.Spec.Capacity[v1.ResourceStorage]; ok {
+			sizes[fqn] = q.Value()
+		}
+	}
+
+	return sizes
+}
+
+// PersistentVolumeClaimSizes returns the storage bytes held by each PersistentVolumeClaim.
+// The claim capacity is used when bound, defaulting to its requested storage.
+func PersistentVolumeClaimSizes(pvcs map[string]*v1.PersistentVolumeClaim) map[string]int64 {
+	sizes := make(map[string]int64, len(pvcs))
+	for fqn, pvc := range pvcs {
+		if q, ok := pvc.Status.Capacity[v1.ResourceStorage]; ok {
+			sizes[fqn] = q.Value()
+			continue
+		}
+		if q, ok := pvc.Spec.Resources.Requests[v1.ResourceStorage]; ok {
+			sizes[fqn] = q.Value()
+		}
+	}
+
+	return sizes
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func isGarbageCode(code config.ID) bool {
+	_, ok := garbageCodes[code]
+	return ok
+}
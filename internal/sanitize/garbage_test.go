@@ -0,0 +1,92 @@
+package sanitize
+
+import (
+	"testing"
+
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/pkg/config"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestComputeGarbage(t *testing.T) {
+	gvr := client.NewGVR("v1/persistentvolumeclaims")
+	unused := issues.New(gvr, issues.Root, config.InfoLevel, "[POP-400] Used? Unable to locate resource reference")
+	uu := map[string]struct {
+		o     issues.Outcome
+		sizes map[string]int64
+		e     []Garbage
+	}{
+		"none": {
+			o: issues.Outcome{
+				"default/pvc1": issues.Issues{
+					issues.New(gvr, issues.Root, config.ErrorLevel, "[POP-1003] Pending claim detected"),
+				},
+			},
+			e: []Garbage{},
+		},
+		"unused": {
+			o: issues.Outcome{
+				"default/pvc2": issues.Issues{unused},
+				"default/pvc1": issues.Issues{
+					issues.New(gvr, issues.Root, config.WarnLevel, "[POP-1004] Lost claim detected"),
+					unused,
+				},
+			},
+			sizes: map[string]int64{"default/pvc1": 1 << 30},
+			e: []Garbage{
+				{GVR: gvr, FQN: "default/pvc1", Reason: unused.Message, Storage: 1 << 30},
+				{GVR: gvr, FQN: "default/pvc2", Reason: unused.Message},
+			},
+		},
+		"subIssue": {
+			o: issues.Outcome{
+				"default/pvc1": issues.Issues{
+					issues.New(gvr, "c1", config.InfoLevel, "[POP-400] Used? Unable to locate resource reference"),
+				},
+			},
+			e: []Garbage{},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, ComputeGarbage(gvr, u.o, u.sizes))
+		})
+	}
+}
+
+func TestPersistentVolumeClaimSizes(t *testing.T) {
+	bound, pending := makeSizedPVC("pvc1", "10Gi", "20Gi"), makeSizedPVC("pvc2", "5Gi", "")
+
+	assert.Equal(t, map[string]int64{
+		"default/pvc1": 20 << 30,
+		"default/pvc2": 5 << 30,
+	}, PersistentVolumeClaimSizes(map[string]*v1.PersistentVolumeClaim{
+		"default/pvc1": bound,
+		"default/pvc2": pending,
+	}))
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func makeSizedPVC(n, request, capacity string) *v1.PersistentVolumeClaim {
+	pvc := v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: n, Namespace: "default"},
+		Spec: v1.PersistentVolumeClaimSpec{
+			Resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse(request)},
+			},
+		},
+	}
+	if capacity != "" {
+		pvc.Status.Capacity = v1.ResourceList{v1.ResourceStorage: resource.MustParse(capacity)}
+	}
+
+	return &pvc
+}
@@ -0,0 +1,201 @@
+package sanitize
+
+import (
+	"context"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/derailed/popeye/internal"
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/issues"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// SeccompPodAnnotation tracks the legacy pod seccomp profile annotation.
+	seccompPodAnnotation = "seccomp.security.alpha.kubernetes.io/pod"
+
+	// SeccompContainerAnnotationPrefix tracks the legacy container seccomp profile annotations prefix.
+	seccompContainerAnnotationPrefix = "container.seccomp.security.alpha.kubernetes.io/"
+)
+
+var (
+	// DangerousCaps tracks capabilities granting host level privileges.
+	dangerousCaps = map[v1.Capability]struct{}{
+		"ALL":             {},
+		"BPF":             {},
+		"DAC_READ_SEARCH": {},
+		"MAC_ADMIN":       {},
+		"MAC_OVERRIDE":    {},
+		"NET_ADMIN":       {},
+		"NET_RAW":         {},
+		"PERFMON":         {},
+		"SYS_ADMIN":       {},
+		"SYS_BOOT":        {},
+		"SYS_MODULE":      {},
+		"SYS_PTRACE":      {},
+		"SYS_RAWIO":       {},
+		"SYS_TIME":        {},
+	}
+
+	// SensitiveHostPaths tracks host paths granting control over the node.
+	sensitiveHostPaths = map[string]struct{}{
+		"/":                   {},
+		"/boot":               {},
+		"/dev":                {},
+		"/etc":                {},
+		"/etc/kubernetes":     {},
+		"/proc":               {},
+		"/root":               {},
+		"/run":                {},
+		"/run/containerd":     {},
+		"/sys":                {},
+		"/var/lib/docker":     {},
+		"/var/lib/kubelet":    {},
+		"/var/run":            {},
+		"/var/run/containerd": {},
+		"/var/run/crio":       {},
+		"/var/run/docker":     {},
+	}
+)
+
+// CheckHardening checks a pod template and its containers security contexts are locked down.
+func checkHardening(ctx context.Context, c *issues.Collector, tpl v1.PodTemplateSpec) {
+	spec := tpl.Spec
+	if spec.HostNetwork {
+		c.AddCode(ctx, 312, "network")
+	}
+	if spec.HostPID {
+		c.AddCode(ctx, 312, "PID")
+	}
+	if spec.HostIPC {
+		c.AddCode(ctx, 312, "IPC")
+	}
+	for _, vol := range spec.Volumes {
+		if vol.HostPath == nil {
+			continue
+		}
+		if isSensitiveHostPath(vol.HostPath.Path) {
+			c.AddCode(ctx, 314, vol.HostPath.Path, vol.Name)
+			continue
+		}
+		c.AddCode(ctx, 313, vol.HostPath.Path, vol.Name)
+	}
+
+	fqn, gvr := internal.MustExtractFQN(ctx), internal.MustExtractSectionGVR(ctx)
+	for _, co := range podContainers(spec) {
+		if c.Config.ExcludeContainer(gvr, fqn, co.Name) {
+			continue
+		}
+		checkContainerHardening(internal.WithGroup(ctx, client.NewGVR("containers"), co.Name), c, tpl, co)
+	}
+}
+
+func checkContainerHardening(ctx context.Context, c *issues.Collector, tpl v1.PodTemplateSpec, co v1.Container) {
+	sec := co.SecurityContext
+	if sec == nil {
+		sec = &v1.SecurityContext{}
+	}
+	if sec.Privileged != nil && *sec.Privileged {
+		c.AddSubCode(ctx, 307)
+	}
+	if sec.AllowPrivilegeEscalation == nil || *sec.AllowPrivilegeEscalation {
+		c.AddSubCode(ctx, 308)
+	}
+	if caps := addedDangerousCaps(sec.Capabilities); len(caps) > 0 {
+		c.AddSubCode(ctx, 309, strings.Join(caps, ", "))
+	}
+	if !dropsAllCaps(sec.Capabilities) {
+		c.AddSubCode(ctx, 310)
+	}
+	if sec.ReadOnlyRootFilesystem == nil || !*sec.ReadOnlyRootFilesystem {
+		c.AddSubCode(ctx, 311)
+	}
+	if !hasSeccompProfile(tpl, co) {
+		c.AddSubCode(ctx, 315)
+	}
+	if !hasAppArmorProfile(tpl, co) {
+		c.AddSubCode(ctx, 316)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+// PodContainers returns all init, regular and ephemeral containers.
+func podContainers(spec v1.PodSpec) []v1.Container {
+	cc := make([]v1.Container, 0, len(spec.InitContainers)+len(spec.Containers)+len(spec.EphemeralContainers))
+	cc = append(cc, spec.InitContainers...)
+	cc = append(cc, spec.Containers...)
+	for _, ec := range spec.EphemeralContainers {
+		cc = append(cc, v1.Container(ec.EphemeralContainerCommon))
+	}
+
+	return cc
+}
+
+func addedDangerousCaps(caps *v1.Capabilities) []string {
+	if caps == nil {
+		return nil
+	}
+	var cc []string
+	for _, c := range caps.Add {
+		if _, ok := dangerousCaps[normalizeCap(c)]; ok {
+			cc = append(cc, string(c))
+		}
+	}
+	sort.Strings(cc)
+
+	return cc
+}
+
+func dropsAllCaps(caps *v1.Capabilities) bool {
+	if caps == nil {
+		return false
+	}
+	for _, c := range caps.Drop {
+		if normalizeCap(c) == "ALL" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func normalizeCap(c v1.Capability) v1.Capability {
+	return v1.Capability(strings.TrimPrefix(strings.ToUpper(string(c)), "CAP_"))
+}
+
+func isSensitiveHostPath(p string) bool {
+	p = path.Clean(p)
+	if strings.HasSuffix(p, ".sock") {
+		return true
+	}
+	_, ok := sensitiveHostPaths[p]
+
+	return ok
+}
+
+func hasSeccompProfile(tpl v1.PodTemplateSpec, co v1.Container) bool {
+	if co.SecurityContext != nil && co.SecurityContext.SeccompProfile != nil {
+		return co.SecurityContext.SeccompProfile.Type != v1.SeccompProfileTypeUnconfined
+	}
+	if a, ok := tpl.Annotations[seccompContainerAnnotationPrefix+co.Name]; ok {
+		return a != "unconfined"
+	}
+	if tpl.Spec.SecurityContext != nil && tpl.Spec.SecurityContext.SeccompProfile != nil {
+		return tpl.Spec.SecurityContext.SeccompProfile.Type != v1.SeccompProfileTypeUnconfined
+	}
+	if a, ok := tpl.Annotations[seccompPodAnnotation]; ok {
+		return a != "unconfined"
+	}
+
+	return false
+}
+
+func hasAppArmorProfile(tpl v1.PodTemplateSpec, co v1.Container) bool {
+	a, ok := tpl.Annotations[appArmorAnnotationPrefix+co.Name]
+
+	return ok && a != "unconfined"
+}
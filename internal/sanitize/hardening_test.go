@@ -0,0 +1,139 @@
+package sanitize
+
+import (
+	"testing"
+
+	"github.com/derailed/popeye/internal"
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/pkg/config"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestPodCheckHardening(t *testing.T) {
+	uu := map[string]struct {
+		pod    *v1.Pod
+		issues issues.Issues
+	}{
+		"hardened": {
+			pod:    makeHardenedPod(),
+			issues: issues.Issues{},
+		},
+		"privileged": {
+			pod: func() *v1.Pod {
+				po := makeHardenedPod()
+				priv, escalate := true, true
+				sec := po.Spec.Containers[0].SecurityContext
+				sec.Privileged, sec.AllowPrivilegeEscalation = &priv, &escalate
+				sec.Capabilities = &v1.Capabilities{Add: []v1.Capability{"NET_BIND_SERVICE", "CAP_SYS_ADMIN", "NET_RAW"}}
+				return po
+			}(),
+			issues: issues.Issues{
+				issues.New(client.NewGVR("containers"), "c1", config.ErrorLevel, "[POP-307] Container is running in privileged mode"),
+				issues.New(client.NewGVR("containers"), "c1", config.WarnLevel, "[POP-308] Container allows privilege escalation. Set allowPrivilegeEscalation to false"),
+				issues.New(client.NewGVR("containers"), "c1", config.ErrorLevel, "[POP-309] Container adds dangerous capabilities CAP_SYS_ADMIN, NET_RAW"),
+				issues.New(client.NewGVR("containers"), "c1", config.InfoLevel, "[POP-310] Container does not drop all capabilities. Add ALL to capabilities drop"),
+			},
+		},
+		"bare": {
+			pod: func() *v1.Pod {
+				po := makeHardenedPod()
+				po.Spec.SecurityContext, po.Annotations = nil, nil
+				po.Spec.InitContainers[0].SecurityContext = nil
+				return po
+			}(),
+			issues: issues.Issues{
+				issues.New(client.NewGVR("containers"), "i1", config.WarnLevel, "[POP-308] Container allows privilege escalation. Set allowPrivilegeEscalation to false"),
+				issues.New(client.NewGVR("containers"), "i1", config.InfoLevel, "[POP-310] Container does not drop all capabilities. Add ALL to capabilities drop"),
+				issues.New(client.NewGVR("containers"), "i1", config.InfoLevel, "[POP-311] Container root filesystem is writable. Set readOnlyRootFilesystem to true"),
+				issues.New(client.NewGVR("containers"), "i1", config.InfoLevel, "[POP-315] Container runs without a seccomp profile"),
+				issues.New(client.NewGVR("containers"), "i1", config.InfoLevel, "[POP-316] Container runs without an AppArmor profile"),
+				issues.New(client.NewGVR("containers"), "c1", config.InfoLevel, "[POP-315] Container runs without a seccomp profile"),
+				issues.New(client.NewGVR("containers"), "c1", config.InfoLevel, "[POP-316] Container runs without an AppArmor profile"),
+			},
+		},
+		"legacySeccomp": {
+			pod: func() *v1.Pod {
+				po := makeHardenedPod()
+				po.Spec.SecurityContext = nil
+				po.Annotations[seccompPodAnnotation] = "runtime/default"
+				po.Annotations[seccompContainerAnnotationPrefix+"c1"] = "unconfined"
+				return po
+			}(),
+			issues: issues.Issues{
+				issues.New(client.NewGVR("containers"), "c1", config.InfoLevel, "[POP-315] Container runs without a seccomp profile"),
+			},
+		},
+		"hostAccess": {
+			pod: func() *v1.Pod {
+				po := makeHardenedPod()
+				po.Spec.HostNetwork, po.Spec.HostPID = true, true
+				po.Spec.Volumes = []v1.Volume{
+					makeHostPathVolume("logs", "/var/log/fred"),
+					makeHostPathVolume("docker", "/var/run/docker.sock"),
+					makeHostPathVolume("root", "/etc/"),
+				}
+				return po
+			}(),
+			issues: issues.Issues{
+				issues.New(client.NewGVR("v1/pods"), issues.Root, config.WarnLevel, "[POP-312] Pod shares the host network namespace"),
+				issues.New(client.NewGVR("v1/pods"), issues.Root, config.WarnLevel, "[POP-312] Pod shares the host PID namespace"),
+				issues.New(client.NewGVR("v1/pods"), issues.Root, config.WarnLevel, `[POP-313] Mounts host path "/var/log/fred" via volume "logs"`),
+				issues.New(client.NewGVR("v1/pods"), issues.Root, config.ErrorLevel, `[POP-314] Mounts sensitive host path "/var/run/docker.sock" via volume "docker"`),
+				issues.New(client.NewGVR("v1/pods"), issues.Root, config.ErrorLevel, `[POP-314] Mounts sensitive host path "/etc/" via volume "root"`),
+			},
+		},
+		"ephemeral": {
+			pod: func() *v1.Pod {
+				po := makeHardenedPod()
+				po.Spec.EphemeralContainers = []v1.EphemeralContainer{
+					{EphemeralContainerCommon: v1.EphemeralContainerCommon{Name: "debug", Image: "busybox:1.35"}},
+				}
+				return po
+			}(),
+			issues: issues.Issues{
+				issues.New(client.NewGVR("containers"), "debug", config.WarnLevel, "[POP-308] Container allows privilege escalation. Set allowPrivilegeEscalation to false"),
+				issues.New(client.NewGVR("containers"), "debug", config.InfoLevel, "[POP-310] Container does not drop all capabilities. Add ALL to capabilities drop"),
+				issues.New(client.NewGVR("containers"), "debug", config.InfoLevel, "[POP-311] Container root filesystem is writable. Set readOnlyRootFilesystem to true"),
+				issues.New(client.NewGVR("containers"), "debug", config.InfoLevel, "[POP-316] Container runs without an AppArmor profile"),
+			},
+		},
+	}
+
+	ctx := makeContext("v1/pods", "po")
+	ctx = internal.WithFQN(ctx, "default/p1")
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			p := NewPod(issues.NewCollector(loadCodes(t), makeConfig(t)), nil)
+			p.InitOutcome("default/p1")
+
+			checkHardening(ctx, p.Collector, podTemplate(u.pod))
+			assert.Equal(t, u.issues, p.Outcome()["default/p1"])
+		})
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func makeHardenedPod() *v1.Pod {
+	po := makePod("p1")
+	po.Spec = v1.PodSpec{
+		InitContainers: []v1.Container{{Name: "i1", Image: "fred:0.0.1"}},
+		Containers:     []v1.Container{{Name: "c1", Image: "fred:0.0.1"}},
+	}
+	hardenPod(po)
+
+	return po
+}
+
+func makeHostPathVolume(name, path string) v1.Volume {
+	return v1.Volume{
+		Name: name,
+		VolumeSource: v1.VolumeSource{
+			HostPath: &v1.HostPathVolumeSource{Path: path},
+		},
+	}
+}
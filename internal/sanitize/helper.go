@@ -46,17 +46,6 @@ func ToPerc(v1, v2 int64) int64 {
 	return int64((float64(v1) / float64(v2)) * 100)
 }
 
-// In checks if a string is in a list of strings.
-func in(ll []string, s string) bool {
-	for _, l := range ll {
-		if l == s {
-			return true
-		}
-	}
-
-	return false
-}
-
 // ToMC converts quantity to millicores.
 func toMC(q resource.Quantity) int64 {
 	return q.MilliValue()
@@ -54,21 +54,6 @@ func TestToPerc(t *testing.T) {
 	}
 }
 
-func TestIn(t *testing.T) {
-	uu := []struct {
-		l []string
-		s string
-		e bool
-	}{
-		{[]string{"a", "b", "c"}, "a", true},
-		{[]string{"a", "b", "c"}, "z", false},
-	}
-
-	for _, u := range uu {
-		assert.Equal(t, u.e, in(u.l, u.s))
-	}
-}
-
 func TestToMCRatio(t *testing.T) {
 	uu := []struct {
 		q1, q2 resource.Quantity
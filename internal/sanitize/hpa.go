@@ -2,16 +2,29 @@ package sanitize
 
 import (
 	"context"
+	"encoding/json"
+	"sort"
+	"strings"
 
 	"github.com/derailed/popeye/internal"
 	"github.com/derailed/popeye/internal/cache"
 	"github.com/derailed/popeye/internal/issues"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	mv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 )
 
+const (
+	// LastAppliedAnnotation tracks kubectl last applied configuration.
+	lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+	// FailedGetResourceMetric tracks HPA failures to fetch resource metrics.
+	failedGetResourceMetric = "FailedGetResourceMetric"
+)
+
 type (
 	// PodMetricsLister handles pods metrics.
 	PodMetricsLister interface {
@@ -35,7 +48,14 @@ type (
 		DeploymentLister
 		StatefulSetLister
 		ClusterMetricsLister
-		ListHorizontalPodAutoscalers() map[string]*autoscalingv1.HorizontalPodAutoscaler
+		PodSelectorLister
+		ScaleLister
+		ListHorizontalPodAutoscalers() map[string]*autoscalingv2.HorizontalPodAutoscaler
+	}
+
+	// ScaleLister lists resolved scale subresources keyed by target.
+	ScaleLister interface {
+		ListScales() map[string]*autoscalingv1.Scale
 	}
 )
 
@@ -49,37 +69,64 @@ func NewHorizontalPodAutoscaler(co *issues.Collector, lister HpaLister) *Horizon
 
 // Sanitize an horizontalpodautoscaler.
 func (h *HorizontalPodAutoscaler) Sanitize(ctx context.Context) error {
-	var (
-		tcpu, tmem resource.Quantity
-		current    int32
-	)
+	var tcpu, tmem resource.Quantity
 	res := h.ListAvailableMetrics(h.ListNodes())
-	for fqn, hpa := range h.ListHorizontalPodAutoscalers() {
+	hpas := h.ListHorizontalPodAutoscalers()
+	targets := hpaTargets(hpas)
+	for fqn, hpa := range hpas {
 		h.InitOutcome(fqn)
 		ctx = internal.WithFQN(ctx, fqn)
-		var rcpu, rmem resource.Quantity
+		checkDeprecation(ctx, h.Collector, "HorizontalPodAutoscaler", hpa)
+		h.checkReplicas(ctx, hpa)
+		h.checkConditions(ctx, hpa)
 		ns, _ := namespaced(fqn)
-		switch hpa.Spec.ScaleTargetRef.Kind {
+		ref := hpa.Spec.ScaleTargetRef
+		h.checkTargets(ctx, fqn, ref, targets[cache.ScaleFQN(ns, ref.Kind, ref.Name)])
+
+		var (
+			spec    *v1.PodSpec
+			current int32
+		)
+		switch ref.Kind {
 		case "Deployment":
-			dpFqn, dps := cache.FQN(ns, hpa.Spec.ScaleTargetRef.Name), h.ListDeployments()
-			if dp, ok := dps[dpFqn]; ok {
-				rcpu, rmem = podResources(dp.Spec.Template.Spec)
-				current = dp.Status.AvailableReplicas
-			} else {
+			dpFqn, dps := cache.FQN(ns, ref.Name), h.ListDeployments()
+			dp, ok := dps[dpFqn]
+			if !ok {
 				h.AddCode(ctx, 600, fqn, dpFqn)
 				continue
 			}
+			spec, current = &dp.Spec.Template.Spec, dp.Status.AvailableReplicas
+			h.checkAppliedReplicas(ctx, ref, dp.ObjectMeta)
 		case "StatefulSet":
-			stsFqn, sts := cache.FQN(ns, hpa.Spec.ScaleTargetRef.Name), h.ListStatefulSets()
-			if st, ok := sts[stsFqn]; ok {
-				rcpu, rmem = podResources(st.Spec.Template.Spec)
-				current = st.Status.CurrentReplicas
-			} else {
+			stsFqn, sts := cache.FQN(ns, ref.Name), h.ListStatefulSets()
+			st, ok := sts[stsFqn]
+			if !ok {
 				h.AddCode(ctx, 601, fqn, stsFqn)
 				continue
 			}
+			spec, current = &st.Spec.Template.Spec, st.Status.CurrentReplicas
+			h.checkAppliedReplicas(ctx, ref, st.ObjectMeta)
+		default:
+			ss := h.ListScales()
+			if ss == nil {
+				break
+			}
+			sc, ok := ss[cache.ScaleFQN(ns, ref.Kind, ref.Name)]
+			if !ok {
+				h.AddCode(ctx, 606, fqn, ref.Kind, cache.FQN(ns, ref.Name))
+				continue
+			}
+			if sc == nil {
+				break
+			}
+			spec, current = h.scaledPodSpec(ns, sc), sc.Status.Replicas
 		}
 
+		var rcpu, rmem resource.Quantity
+		if spec != nil {
+			rcpu, rmem = podResources(*spec)
+			h.checkMetrics(ctx, hpa.Spec.Metrics, *spec)
+		}
 		rList := v1.ResourceList{v1.ResourceCPU: rcpu, v1.ResourceMemory: rmem}
 		list := h.checkResources(ctx, hpa.Spec.MaxReplicas, current, rList, res)
 		tcpu.Add(*list.Cpu())
@@ -94,6 +141,83 @@ func (h *HorizontalPodAutoscaler) Sanitize(ctx context.Context) error {
 	return nil
 }
 
+func (h *HorizontalPodAutoscaler) checkReplicas(ctx context.Context, hpa *autoscalingv2.HorizontalPodAutoscaler) {
+	min := int32(1)
+	if hpa.Spec.MinReplicas != nil {
+		min = *hpa.Spec.MinReplicas
+	}
+	if min == hpa.Spec.MaxReplicas {
+		h.AddCode(ctx, 608, min)
+	}
+}
+
+func (h *HorizontalPodAutoscaler) checkConditions(ctx context.Context, hpa *autoscalingv2.HorizontalPodAutoscaler) {
+	for _, c := range hpa.Status.Conditions {
+		switch {
+		case c.Type == autoscalingv2.ScalingLimited && c.Status == v1.ConditionTrue && hpa.Status.CurrentReplicas >= hpa.Spec.MaxReplicas:
+			h.AddCode(ctx, 611, hpa.Spec.MaxReplicas, c.Reason)
+		case c.Type == autoscalingv2.ScalingActive && c.Status == v1.ConditionFalse && c.Reason == failedGetResourceMetric:
+			h.AddCode(ctx, 612, c.Message)
+		}
+	}
+}
+
+// CheckTargets checks if other HPAs scale the same workload.
+func (h *HorizontalPodAutoscaler) checkTargets(ctx context.Context, fqn string, ref autoscalingv2.CrossVersionObjectReference, hpas []string) {
+	others := make([]string, 0, len(hpas))
+	for _, hfqn := range hpas {
+		if hfqn != fqn {
+			others = append(others, hfqn)
+		}
+	}
+	if len(others) > 0 {
+		h.AddCode(ctx, 610, ref.Kind+"/"+ref.Name, strings.Join(others, ", "))
+	}
+}
+
+// CheckAppliedReplicas checks if a target replicas are declaratively managed ie GitOps.
+func (h *HorizontalPodAutoscaler) checkAppliedReplicas(ctx context.Context, ref autoscalingv2.CrossVersionObjectReference, m metav1.ObjectMeta) {
+	if appliesReplicas(m) {
+		h.AddCode(ctx, 609, ref.Kind+"/"+ref.Name)
+	}
+}
+
+// CheckMetrics checks utilization metrics can be computed from the pods requests.
+func (h *HorizontalPodAutoscaler) checkMetrics(ctx context.Context, mm []autoscalingv2.MetricSpec, spec v1.PodSpec) {
+	for _, m := range mm {
+		switch {
+		case m.Resource != nil && m.Resource.Target.Type == autoscalingv2.UtilizationMetricType:
+			for _, co := range spec.Containers {
+				if _, ok := co.Resources.Requests[m.Resource.Name]; !ok {
+					h.AddCode(ctx, 607, m.Resource.Name, co.Name, m.Resource.Name)
+				}
+			}
+		case m.ContainerResource != nil && m.ContainerResource.Target.Type == autoscalingv2.UtilizationMetricType:
+			for _, co := range spec.Containers {
+				if co.Name != m.ContainerResource.Container {
+					continue
+				}
+				if _, ok := co.Resources.Requests[m.ContainerResource.Name]; !ok {
+					h.AddCode(ctx, 607, m.ContainerResource.Name, co.Name, m.ContainerResource.Name)
+				}
+			}
+		}
+	}
+}
+
+// ScaledPodSpec returns the pod spec of a scale target via its selected pods.
+func (h *HorizontalPodAutoscaler) scaledPodSpec(ns string, sc *autoscalingv1.Scale) *v1.PodSpec {
+	sel, err := metav1.ParseToLabelSelector(sc.Status.Selector)
+	if err != nil {
+		return nil
+	}
+	for _, po := range h.ListPodsBySelector(ns, sel) {
+		return &po.Spec
+	}
+
+	return nil
+}
+
 func (h *HorizontalPodAutoscaler) checkResources(ctx context.Context, max, current int32, rList, res v1.ResourceList) v1.ResourceList {
 	rcpu, rmem := rList.Cpu(), rList.Memory()
 	acpu, amem := *res.Cpu(), *res.Memory()
@@ -130,3 +254,51 @@ func (h *HorizontalPodAutoscaler) checkUtilization(ctx context.Context, tcpu, tm
 		h.AddCode(ctx, 605, asMB(tmem), asMB(amem), asMB(mem))
 	}
 }
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+// HpaTargets returns the HPAs scaling each target.
+func hpaTargets(hpas map[string]*autoscalingv2.HorizontalPodAutoscaler) map[string][]string {
+	tt := make(map[string][]string, len(hpas))
+	for fqn, hpa := range hpas {
+		ns, _ := namespaced(fqn)
+		ref := hpa.Spec.ScaleTargetRef
+		k := cache.ScaleFQN(ns, ref.Kind, ref.Name)
+		tt[k] = append(tt[k], fqn)
+	}
+	for _, ff := range tt {
+		sort.Strings(ff)
+	}
+
+	return tt
+}
+
+// AppliesReplicas checks if replicas are set by an applied manifest ie kubectl apply,
+// server-side apply or a GitOps controller.
+func appliesReplicas(m metav1.ObjectMeta) bool {
+	if raw, ok := m.Annotations[lastAppliedAnnotation]; ok {
+		var o struct {
+			Spec struct {
+				Replicas *int32 `json:"replicas"`
+			} `json:"spec"`
+		}
+		if err := json.Unmarshal([]byte(raw), &o); err == nil && o.Spec.Replicas != nil {
+			return true
+		}
+	}
+	for _, mf := range m.ManagedFields {
+		if mf.Operation != metav1.ManagedFieldsOperationApply || mf.FieldsV1 == nil {
+			continue
+		}
+		var ff map[string]map[string]interface{}
+		if err := json.Unmarshal(mf.FieldsV1.Raw, &ff); err != nil {
+			continue
+		}
+		if _, ok := ff["f:spec"]["f:replicas"]; ok {
+			return true
+		}
+	}
+
+	return false
+}
@@ -4,9 +4,13 @@ import (
 	"testing"
 
 	"github.com/derailed/popeye/internal/cache"
+	"github.com/derailed/popeye/internal/client"
 	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/pkg/config"
 	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	mv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
@@ -22,9 +26,9 @@ func TestHPASanitizeDP(t *testing.T) {
 			newDpHpa(
 				hpaOpts{
 					name: "d1",
-					ccpu: "20m",
-					cmem: "20Mi",
-					max:  1,
+					ccpu: "40m",
+					cmem: "40Mi",
+					max:  2,
 					coOpts: coOpts{
 						rcpu: "1m",
 						rmem: "10Mi",
@@ -39,7 +43,7 @@ func TestHPASanitizeDP(t *testing.T) {
 					name: "bozo",
 					ccpu: "20m",
 					cmem: "20Mi",
-					max:  1,
+					max:  2,
 					coOpts: coOpts{
 						rcpu: "1m",
 						rmem: "10Mi",
@@ -53,8 +57,8 @@ func TestHPASanitizeDP(t *testing.T) {
 				hpaOpts{
 					name: "d1",
 					ccpu: "10m",
-					cmem: "20Mi",
-					max:  1,
+					cmem: "40Mi",
+					max:  2,
 					coOpts: coOpts{
 						rcpu: "10m",
 						rmem: "10Mi",
@@ -69,7 +73,7 @@ func TestHPASanitizeDP(t *testing.T) {
 					name: "d1",
 					ccpu: "10m",
 					cmem: "10Mi",
-					max:  1,
+					max:  2,
 					coOpts: coOpts{
 						rcpu: "1m",
 						rmem: "10Mi",
@@ -105,7 +109,7 @@ func TestHPASanitizeSTS(t *testing.T) {
 					name: "sts1",
 					ccpu: "10m",
 					cmem: "10Mi",
-					max:  1,
+					max:  2,
 					coOpts: coOpts{
 						rcpu: "1m",
 						rmem: "1Mi",
@@ -120,7 +124,7 @@ func TestHPASanitizeSTS(t *testing.T) {
 					name: "bozo",
 					ccpu: "20m",
 					cmem: "20Mi",
-					max:  1,
+					max:  2,
 					coOpts: coOpts{
 						rcpu: "1m",
 						rmem: "10Mi",
@@ -152,7 +156,7 @@ func TestHPASanitizeSTS(t *testing.T) {
 					name: "sts1",
 					ccpu: "10m",
 					cmem: "10Mi",
-					max:  1,
+					max:  2,
 					coOpts: coOpts{
 						rcpu: "1m",
 						rmem: "10Mi",
@@ -178,6 +182,146 @@ func TestHPASanitizeSTS(t *testing.T) {
 	}
 }
 
+func TestHPASanitizeV2(t *testing.T) {
+	gvr := client.NewGVR("autoscaling/v2/horizontalpodautoscalers")
+	uu := map[string]struct {
+		hpas   []*autoscalingv2.HorizontalPodAutoscaler
+		dp     *appsv1.Deployment
+		scales map[string]*autoscalingv1.Scale
+		issues issues.Issues
+	}{
+		"cool": {
+			hpas:   []*autoscalingv2.HorizontalPodAutoscaler{makeHPAv2("h1", "Deployment", "d1", hpaV2Opts{})},
+			issues: issues.Issues{},
+		},
+		"minMax": {
+			hpas: []*autoscalingv2.HorizontalPodAutoscaler{makeHPAv2("h1", "Deployment", "d1", hpaV2Opts{min: 3})},
+			issues: issues.Issues{
+				issues.New(gvr, issues.Root, config.WarnLevel, "[POP-608] MinReplicas and MaxReplicas are both 3. The HPA will never scale"),
+			},
+		},
+		"noRequests": {
+			hpas: []*autoscalingv2.HorizontalPodAutoscaler{makeHPAv2("h1", "Deployment", "d1", hpaV2Opts{metric: autoscalingv2.UtilizationMetricType})},
+			dp:   makeDP("d1", dpOpts{coOpts: coOpts{rmem: "10Mi"}}),
+			issues: issues.Issues{
+				issues.New(gvr, issues.Root, config.ErrorLevel, "[POP-607] cpu utilization target is unusable since container c1 has no cpu request"),
+			},
+		},
+		"averageValue": {
+			hpas:   []*autoscalingv2.HorizontalPodAutoscaler{makeHPAv2("h1", "Deployment", "d1", hpaV2Opts{metric: autoscalingv2.AverageValueMetricType})},
+			dp:     makeDP("d1", dpOpts{coOpts: coOpts{rmem: "10Mi"}}),
+			issues: issues.Issues{},
+		},
+		"lastApplied": {
+			hpas: []*autoscalingv2.HorizontalPodAutoscaler{makeHPAv2("h1", "Deployment", "d1", hpaV2Opts{})},
+			dp: func() *appsv1.Deployment {
+				dp := makeDP("d1", dpOpts{coOpts: coOpts{rcpu: "10m", rmem: "10Mi"}})
+				dp.Annotations = map[string]string{lastAppliedAnnotation: `{"spec": {"replicas": 2}}`}
+				return dp
+			}(),
+			issues: issues.Issues{
+				issues.New(gvr, issues.Root, config.WarnLevel, "[POP-609] Target Deployment/d1 declares spec.replicas in its applied configuration. Syncs will fight the HPA"),
+			},
+		},
+		"serverSideApply": {
+			hpas: []*autoscalingv2.HorizontalPodAutoscaler{makeHPAv2("h1", "Deployment", "d1", hpaV2Opts{})},
+			dp: func() *appsv1.Deployment {
+				dp := makeDP("d1", dpOpts{coOpts: coOpts{rcpu: "10m", rmem: "10Mi"}})
+				dp.ManagedFields = []metav1.ManagedFieldsEntry{
+					{Manager: "kube-controller-manager", Operation: metav1.ManagedFieldsOperationUpdate, FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec": {"f:replicas": {}}}`)}},
+					{Manager: "argocd-controller", Operation: metav1.ManagedFieldsOperationApply, FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec": {"f:replicas": {}, "f:template": {}}}`)}},
+				}
+				return dp
+			}(),
+			issues: issues.Issues{
+				issues.New(gvr, issues.Root, config.WarnLevel, "[POP-609] Target Deployment/d1 declares spec.replicas in its applied configuration. Syncs will fight the HPA"),
+			},
+		},
+		"duplicates": {
+			hpas: []*autoscalingv2.HorizontalPodAutoscaler{
+				makeHPAv2("h1", "Deployment", "d1", hpaV2Opts{}),
+				makeHPAv2("h2", "Deployment", "d1", hpaV2Opts{}),
+			},
+			issues: issues.Issues{
+				issues.New(gvr, issues.Root, config.ErrorLevel, "[POP-610] Target Deployment/d1 is also scaled by HPA default/h2"),
+			},
+		},
+		"limited": {
+			hpas: []*autoscalingv2.HorizontalPodAutoscaler{makeHPAv2("h1", "Deployment", "d1", hpaV2Opts{
+				current: 3,
+				condition: autoscalingv2.HorizontalPodAutoscalerCondition{
+					Type:   autoscalingv2.ScalingLimited,
+					Status: v1.ConditionTrue,
+					Reason: "TooManyReplicas",
+				},
+			})},
+			issues: issues.Issues{
+				issues.New(gvr, issues.Root, config.WarnLevel, "[POP-611] HPA is stuck at max replicas 3 (TooManyReplicas)"),
+			},
+		},
+		"limitedBelowMax": {
+			hpas: []*autoscalingv2.HorizontalPodAutoscaler{makeHPAv2("h1", "Deployment", "d1", hpaV2Opts{
+				current: 1,
+				condition: autoscalingv2.HorizontalPodAutoscalerCondition{
+					Type:   autoscalingv2.ScalingLimited,
+					Status: v1.ConditionTrue,
+					Reason: "TooFewReplicas",
+				},
+			})},
+			issues: issues.Issues{},
+		},
+		"noMetrics": {
+			hpas: []*autoscalingv2.HorizontalPodAutoscaler{makeHPAv2("h1", "Deployment", "d1", hpaV2Opts{
+				condition: autoscalingv2.HorizontalPodAutoscalerCondition{
+					Type:    autoscalingv2.ScalingActive,
+					Status:  v1.ConditionFalse,
+					Reason:  failedGetResourceMetric,
+					Message: "missing request for cpu",
+				},
+			})},
+			issues: issues.Issues{
+				issues.New(gvr, issues.Root, config.ErrorLevel, "[POP-612] Unable to fetch resource metrics (missing request for cpu)"),
+			},
+		},
+		"scaleTarget": {
+			hpas: []*autoscalingv2.HorizontalPodAutoscaler{makeHPAv2("h1", "Rollout", "r1", hpaV2Opts{metric: autoscalingv2.UtilizationMetricType})},
+			scales: map[string]*autoscalingv1.Scale{
+				"default/Rollout/r1": {Status: autoscalingv1.ScaleStatus{Replicas: 1, Selector: "app=r1"}},
+			},
+			issues: issues.Issues{
+				issues.New(gvr, issues.Root, config.ErrorLevel, "[POP-607] cpu utilization target is unusable since container c1 has no cpu request"),
+			},
+		},
+		"noScaleTarget": {
+			hpas:   []*autoscalingv2.HorizontalPodAutoscaler{makeHPAv2("h1", "Rollout", "r1", hpaV2Opts{})},
+			scales: map[string]*autoscalingv1.Scale{},
+			issues: issues.Issues{
+				issues.New(gvr, issues.Root, config.ErrorLevel, "[POP-606] HPA default/h1 references a Rollout default/r1 which does not exist"),
+			},
+		},
+		"unresolvedScales": {
+			hpas:   []*autoscalingv2.HorizontalPodAutoscaler{makeHPAv2("h1", "Rollout", "r1", hpaV2Opts{})},
+			issues: issues.Issues{},
+		},
+		"unresolvedScaleTarget": {
+			hpas:   []*autoscalingv2.HorizontalPodAutoscaler{makeHPAv2("h1", "Rollout", "r1", hpaV2Opts{})},
+			scales: map[string]*autoscalingv1.Scale{"default/Rollout/r1": nil},
+			issues: issues.Issues{},
+		},
+	}
+
+	ctx := makeContext("autoscaling/v2/horizontalpodautoscalers", "hpa")
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			h := NewHorizontalPodAutoscaler(issues.NewCollector(loadCodes(t), makeConfig(t)), newHpaV2(u.hpas, u.dp, u.scales))
+
+			assert.Nil(t, h.Sanitize(ctx))
+			assert.Equal(t, u.issues, h.Outcome()["default/h1"])
+		})
+	}
+}
+
 // ----------------------------------------------------------------------------
 // Helpers...
 
@@ -225,12 +369,20 @@ func newStsHpa(opts hpaOpts) *hpa {
 	return &h
 }
 
-func (h *hpa) ListHorizontalPodAutoscalers() map[string]*autoscalingv1.HorizontalPodAutoscaler {
-	return map[string]*autoscalingv1.HorizontalPodAutoscaler{
+func (h *hpa) ListHorizontalPodAutoscalers() map[string]*autoscalingv2.HorizontalPodAutoscaler {
+	return map[string]*autoscalingv2.HorizontalPodAutoscaler{
 		cache.FQN("default", h.name): makeHPA(h.name, h.opts.refType, h.opts.ref, h.opts.max),
 	}
 }
 
+func (h *hpa) ListScales() map[string]*autoscalingv1.Scale {
+	return nil
+}
+
+func (h *hpa) ListPodsBySelector(string, *metav1.LabelSelector) map[string]*v1.Pod {
+	return map[string]*v1.Pod{}
+}
+
 func (h *hpa) ListNodesMetrics() map[string]*mv1beta1.NodeMetrics {
 	return map[string]*mv1beta1.NodeMetrics{}
 }
@@ -239,6 +391,10 @@ func (h *hpa) ListNodes() map[string]*v1.Node {
 	return map[string]*v1.Node{}
 }
 
+func (h *hpa) ListNamespaces() map[string]*v1.Namespace {
+	return map[string]*v1.Namespace{}
+}
+
 func (h *hpa) ListPods() map[string]*v1.Pod {
 	return map[string]*v1.Pod{}
 }
@@ -263,18 +419,91 @@ func (h *hpa) GetPod(string, map[string]string) *v1.Pod {
 	return &v1.Pod{}
 }
 
-func makeHPA(n, kind, dp string, max int32) *autoscalingv1.HorizontalPodAutoscaler {
-	return &autoscalingv1.HorizontalPodAutoscaler{
+func makeHPA(n, kind, dp string, max int32) *autoscalingv2.HorizontalPodAutoscaler {
+	return &autoscalingv2.HorizontalPodAutoscaler{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      n,
 			Namespace: "default",
 		},
-		Spec: autoscalingv1.HorizontalPodAutoscalerSpec{
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
 			MaxReplicas: max,
-			ScaleTargetRef: autoscalingv1.CrossVersionObjectReference{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
 				Kind: kind,
 				Name: dp,
 			},
 		},
 	}
 }
+
+type hpaV2Opts struct {
+	min, current int32
+	metric       autoscalingv2.MetricTargetType
+	condition    autoscalingv2.HorizontalPodAutoscalerCondition
+}
+
+type hpaV2 struct {
+	*hpa
+	hpas   map[string]*autoscalingv2.HorizontalPodAutoscaler
+	dp     *appsv1.Deployment
+	scales map[string]*autoscalingv1.Scale
+}
+
+func newHpaV2(hh []*autoscalingv2.HorizontalPodAutoscaler, dp *appsv1.Deployment, scales map[string]*autoscalingv1.Scale) *hpaV2 {
+	if dp == nil {
+		dp = makeDP("d1", dpOpts{coOpts: coOpts{rcpu: "10m", rmem: "10Mi"}})
+	}
+	hpas := make(map[string]*autoscalingv2.HorizontalPodAutoscaler, len(hh))
+	for _, h := range hh {
+		hpas[cache.MetaFQN(h.ObjectMeta)] = h
+	}
+
+	return &hpaV2{
+		hpa:    newDpHpa(hpaOpts{ccpu: "10", cmem: "10Gi"}),
+		hpas:   hpas,
+		dp:     dp,
+		scales: scales,
+	}
+}
+
+func (h *hpaV2) ListHorizontalPodAutoscalers() map[string]*autoscalingv2.HorizontalPodAutoscaler {
+	return h.hpas
+}
+
+func (h *hpaV2) ListDeployments() map[string]*appsv1.Deployment {
+	return map[string]*appsv1.Deployment{cache.MetaFQN(h.dp.ObjectMeta): h.dp}
+}
+
+func (h *hpaV2) ListScales() map[string]*autoscalingv1.Scale {
+	return h.scales
+}
+
+func (h *hpaV2) ListPodsBySelector(string, *metav1.LabelSelector) map[string]*v1.Pod {
+	po := makePod("p1")
+	po.Spec.Containers = []v1.Container{makeContainer("c1", coOpts{rmem: "10Mi"})}
+
+	return map[string]*v1.Pod{"default/p1": po}
+}
+
+func makeHPAv2(n, kind, target string, o hpaV2Opts) *autoscalingv2.HorizontalPodAutoscaler {
+	hpa := makeHPA(n, kind, target, 3)
+	if o.min > 0 {
+		hpa.Spec.MinReplicas = &o.min
+	}
+	if o.metric != "" {
+		hpa.Spec.Metrics = []autoscalingv2.MetricSpec{
+			{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Name:   v1.ResourceCPU,
+					Target: autoscalingv2.MetricTarget{Type: o.metric},
+				},
+			},
+		}
+	}
+	hpa.Status.CurrentReplicas = o.current
+	if o.condition.Type != "" {
+		hpa.Status.Conditions = []autoscalingv2.HorizontalPodAutoscalerCondition{o.condition}
+	}
+
+	return hpa
+}
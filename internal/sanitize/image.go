@@ -0,0 +1,44 @@
+package sanitize
+
+import (
+	"context"
+
+	"github.com/derailed/popeye/internal/image"
+	"github.com/derailed/popeye/pkg/config"
+	v1 "k8s.io/api/core/v1"
+)
+
+// CheckImagePolicy checks a container image against the spinach images policy.
+func (c *Container) checkImagePolicy(ctx context.Context, co v1.Container) {
+	ref, policy := image.Parse(co.Image), c.ImagePolicy()
+	// Legacy allowed registries are merged into the registries policy.
+	registries := policy.Registries
+	registries.Allow = append(append(config.Patterns{}, registries.Allow...), c.AllowedRegistries()...)
+	if registries.Denied(ref.Registry) {
+		c.AddSubCode(ctx, 115, co.Image)
+	} else if !registries.Allowed(ref.Registry) {
+		c.AddSubCode(ctx, 113, co.Image)
+	}
+	if policy.Repositories.Denied(ref.Name()) {
+		c.AddSubCode(ctx, 117, co.Image)
+	} else if !policy.Repositories.Allowed(ref.Name()) {
+		c.AddSubCode(ctx, 116, co.Image)
+	}
+	if ns, _ := namespaced(c.fqn); ref.Digest == "" && policy.RequireDigest(ns) {
+		c.AddSubCode(ctx, 118, co.Image)
+	}
+	if ref.Tag != "" && policy.DeniedTag(ref.Tag) {
+		c.AddSubCode(ctx, 119, co.Image, ref.Tag)
+	}
+}
+
+// CheckPullPolicy checks the image pull policy is consistent with the image reference.
+func (c *Container) checkPullPolicy(ctx context.Context, co v1.Container) {
+	ref := image.Parse(co.Image)
+	switch {
+	case ref.Mutable() && co.ImagePullPolicy != "" && co.ImagePullPolicy != v1.PullAlways:
+		c.AddSubCode(ctx, 120, co.Image, co.ImagePullPolicy)
+	case ref.Digest != "" && co.ImagePullPolicy == v1.PullAlways:
+		c.AddSubCode(ctx, 121, co.Image)
+	}
+}
@@ -0,0 +1,166 @@
+package sanitize
+
+import (
+	"testing"
+
+	"github.com/derailed/popeye/internal"
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/pkg/config"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestContainerCheckImagePolicy(t *testing.T) {
+	uu := map[string]struct {
+		image      string
+		registries []string
+		images     config.Images
+		issues     issues.Issues
+	}{
+		"noPolicy": {
+			image:  "fred:1.0",
+			issues: issues.Issues{},
+		},
+		"legacyRegistries": {
+			image:      "library/nginx:1.23",
+			registries: []string{"docker.io"},
+			issues:     issues.Issues{},
+		},
+		"legacyRegistriesToast": {
+			image:      "localhost:5000/nginx:1.23",
+			registries: []string{"docker.io"},
+			issues: issues.Issues{
+				issues.New(client.NewGVR("containers"), "c1", config.ErrorLevel, "[POP-113] Container image localhost:5000/nginx:1.23 is not hosted on an allowed docker registry"),
+			},
+		},
+		"registryGlob": {
+			image: "us.gcr.io/fred/blee:1.0",
+			images: config.Images{
+				Registries: config.ImageRules{Allow: config.Patterns{"*.gcr.io"}},
+			},
+			issues: issues.Issues{},
+		},
+		"registryDenied": {
+			image: "ghcr.io/fred/blee:1.0",
+			images: config.Images{
+				Registries: config.ImageRules{Allow: config.Patterns{"rx:.*"}, Deny: config.Patterns{"ghcr.io"}},
+			},
+			issues: issues.Issues{
+				issues.New(client.NewGVR("containers"), "c1", config.ErrorLevel, "[POP-115] Container image ghcr.io/fred/blee:1.0 is hosted on a denied registry"),
+			},
+		},
+		"repositories": {
+			image: "bitnami/redis:7.0",
+			images: config.Images{
+				Repositories: config.ImageRules{Allow: config.Patterns{"docker.io/library/*"}},
+			},
+			issues: issues.Issues{
+				issues.New(client.NewGVR("containers"), "c1", config.ErrorLevel, "[POP-116] Container image bitnami/redis:7.0 is not from an allowed repository"),
+			},
+		},
+		"repositoryDenied": {
+			image: "bitnami/redis:7.0",
+			images: config.Images{
+				Repositories: config.ImageRules{Deny: config.Patterns{"rx:^docker.io/bitnami/"}},
+			},
+			issues: issues.Issues{
+				issues.New(client.NewGVR("containers"), "c1", config.ErrorLevel, "[POP-117] Container image bitnami/redis:7.0 is from a denied repository"),
+			},
+		},
+		"digest": {
+			image: "fred:1.0",
+			images: config.Images{
+				Digests: config.DigestRules{Namespaces: config.Patterns{"rx:^def"}},
+				Tags:    config.TagRules{Deny: config.Patterns{"1.0", "dev"}},
+			},
+			issues: issues.Issues{
+				issues.New(client.NewGVR("containers"), "c1", config.ErrorLevel, "[POP-118] Container image fred:1.0 is not pinned by digest"),
+				issues.New(client.NewGVR("containers"), "c1", config.ErrorLevel, `[POP-119] Container image fred:1.0 uses denied tag "1.0"`),
+			},
+		},
+		"pinned": {
+			image: "fred@sha256:4f0c5b2a",
+			images: config.Images{
+				Digests: config.DigestRules{Namespaces: config.Patterns{"default"}},
+			},
+			issues: issues.Issues{},
+		},
+	}
+
+	ctx := makeContext("containers", "container")
+	ctx = internal.WithFQN(ctx, "default/p1")
+	ctx = internal.WithGroup(ctx, client.NewGVR("containers"), "c1")
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			cfg := makeConfig(t)
+			cfg.Registries, cfg.Images = u.registries, u.images
+			co := issues.NewCollector(loadCodes(t), cfg)
+			co.InitOutcome("default/p1")
+			c := NewContainer("default/p1", &rangeCollector{co})
+
+			c.checkImagePolicy(ctx, v1.Container{Name: "c1", Image: u.image})
+			assert.Equal(t, u.issues, c.Outcome()["default/p1"])
+		})
+	}
+}
+
+func TestContainerCheckPullPolicy(t *testing.T) {
+	uu := map[string]struct {
+		image  string
+		policy v1.PullPolicy
+		issues issues.Issues
+	}{
+		"default": {
+			image:  "fred",
+			issues: issues.Issues{},
+		},
+		"latestAlways": {
+			image:  "fred:latest",
+			policy: v1.PullAlways,
+			issues: issues.Issues{},
+		},
+		"latestIfNotPresent": {
+			image:  "fred:latest",
+			policy: v1.PullIfNotPresent,
+			issues: issues.Issues{
+				issues.New(client.NewGVR("containers"), "c1", config.WarnLevel, "[POP-120] Image fred:latest uses a mutable tag with pull policy IfNotPresent. Use Always or pin a version"),
+			},
+		},
+		"untaggedNever": {
+			image:  "localhost:5000/fred",
+			policy: v1.PullNever,
+			issues: issues.Issues{
+				issues.New(client.NewGVR("containers"), "c1", config.WarnLevel, "[POP-120] Image localhost:5000/fred uses a mutable tag with pull policy Never. Use Always or pin a version"),
+			},
+		},
+		"versioned": {
+			image:  "fred:1.0",
+			policy: v1.PullIfNotPresent,
+			issues: issues.Issues{},
+		},
+		"digestAlways": {
+			image:  "fred@sha256:4f0c5b2a",
+			policy: v1.PullAlways,
+			issues: issues.Issues{
+				issues.New(client.NewGVR("containers"), "c1", config.InfoLevel, "[POP-121] Image fred@sha256:4f0c5b2a is pinned by digest. Pull policy Always is unnecessary"),
+			},
+		},
+	}
+
+	ctx := makeContext("containers", "container")
+	ctx = internal.WithFQN(ctx, "default/p1")
+	ctx = internal.WithGroup(ctx, client.NewGVR("containers"), "c1")
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			co := issues.NewCollector(loadCodes(t), makeConfig(t))
+			co.InitOutcome("default/p1")
+			c := NewContainer("default/p1", &rangeCollector{co})
+
+			c.checkPullPolicy(ctx, v1.Container{Name: "c1", Image: u.image, ImagePullPolicy: u.policy})
+			assert.Equal(t, u.issues, c.Outcome()["default/p1"])
+		})
+	}
+}
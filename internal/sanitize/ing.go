@@ -2,12 +2,20 @@ package sanitize
 
 import (
 	"context"
-	netv1 "k8s.io/api/networking/v1"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/derailed/popeye/internal"
+	"github.com/derailed/popeye/internal/cache"
 	"github.com/derailed/popeye/internal/issues"
+	v1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
 )
 
+// ingressClassAnnotation tracks the legacy ingress class annotation.
+const ingressClassAnnotation = "kubernetes.io/ingress.class"
+
 type (
 	// Ingress tracks Ingress sanitization.
 	Ingress struct {
@@ -20,9 +28,18 @@ type (
 		ListIngresses() map[string]*netv1.Ingress
 	}
 
+	// IngressClassLister list ingress classes.
+	IngressClassLister interface {
+		ListIngressClasses() map[string]*netv1.IngressClass
+	}
+
 	// IngressLister list available Ingresss on a cluster.
 	IngressLister interface {
 		IngLister
+		IngressClassLister
+		EndPointLister
+		ListServices() map[string]*v1.Service
+		ListSecrets() map[string]*v1.Secret
 	}
 )
 
@@ -36,11 +53,16 @@ func NewIngress(co *issues.Collector, lister IngressLister) *Ingress {
 
 // Sanitize cleanse the resource.
 func (i *Ingress) Sanitize(ctx context.Context) error {
+	claims := i.claims()
 	for fqn, ing := range i.ListIngresses() {
 		i.InitOutcome(fqn)
 		ctx = internal.WithFQN(ctx, fqn)
 
-		i.checkDeprecation(ctx, ing)
+		checkDeprecation(ctx, i.Collector, "Ingress", ing)
+		i.checkClass(ctx, ing)
+		i.checkBackends(ctx, ing)
+		i.checkTLS(ctx, ing)
+		i.checkClaims(ctx, fqn, ing, claims)
 
 		if i.NoConcerns(fqn) && i.Config.ExcludeFQN(internal.MustExtractSectionGVR(ctx), fqn) {
 			i.ClearOutcome(fqn)
@@ -50,15 +72,192 @@ func (i *Ingress) Sanitize(ctx context.Context) error {
 	return nil
 }
 
-func (i *Ingress) checkDeprecation(ctx context.Context, ing *netv1.Ingress) {
-	const current = "networking.k8s.io/v1"
-	rev, err := resourceRev(internal.MustExtractFQN(ctx), "Ingress", ing.Annotations)
-	if err != nil {
-		if rev = revFromLink(ing.SelfLink); rev == "" {
-			return
+// CheckClass checks the ingress class references an existing IngressClass.
+// The check is skipped when IngressClasses are unavailable.
+func (i *Ingress) checkClass(ctx context.Context, ing *netv1.Ingress) {
+	class, ics := ingressClass(ing), i.ListIngressClasses()
+	if class == "" || ics == nil {
+		return
+	}
+	if _, ok := ics[class]; !ok {
+		i.AddCode(ctx, 1604, class)
+	}
+}
+
+// CheckBackends checks backend services exist, expose the referenced port and have ready endpoints.
+func (i *Ingress) checkBackends(ctx context.Context, ing *netv1.Ingress) {
+	svcs, seen := i.ListServices(), make(map[string]struct{})
+	for _, b := range ingressBackends(ing) {
+		if b.Service == nil {
+			continue
+		}
+		fqn := cache.FQN(ing.Namespace, b.Service.Name)
+		svc, ok := svcs[fqn]
+		if !ok {
+			i.AddCode(ctx, 1600, b.Service.Name)
+			continue
+		}
+		if !hasServicePort(svc, b.Service.Port) {
+			i.AddCode(ctx, 1601, b.Service.Name, backendPort(b.Service.Port))
+		}
+		if _, ok := seen[fqn]; ok || svc.Spec.Type == v1.ServiceTypeExternalName {
+			continue
+		}
+		seen[fqn] = struct{}{}
+		if !hasReadyEndpoints(i.GetEndpoints(fqn)) {
+			i.AddCode(ctx, 1605, b.Service.Name)
+		}
+	}
+}
+
+// CheckTLS checks TLS secrets exist and are of the expected type.
+func (i *Ingress) checkTLS(ctx context.Context, ing *netv1.Ingress) {
+	secs := i.ListSecrets()
+	for _, tls := range ing.Spec.TLS {
+		if tls.SecretName == "" {
+			continue
+		}
+		sec, ok := secs[cache.FQN(ing.Namespace, tls.SecretName)]
+		if !ok {
+			i.AddCode(ctx, 1602, tls.SecretName)
+			continue
+		}
+		if sec.Type != v1.SecretTypeTLS {
+			i.AddCode(ctx, 1603, tls.SecretName, sec.Type)
+		}
+	}
+}
+
+// CheckClaims checks no other ingress of the same class claims the same host and path.
+func (i *Ingress) checkClaims(ctx context.Context, fqn string, ing *netv1.Ingress, claims map[string][]string) {
+	seen := make(map[string]struct{})
+	for _, r := range ing.Spec.Rules {
+		if r.HTTP == nil {
+			continue
+		}
+		for _, p := range r.HTTP.Paths {
+			key := claimKey(ingressClass(ing), r.Host, p.Path)
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			others := make([]string, 0, len(claims[key]))
+			for _, o := range claims[key] {
+				if o != fqn {
+					others = append(others, o)
+				}
+			}
+			if len(others) > 0 {
+				i.AddCode(ctx, 1606, claimHost(r.Host), claimPath(p.Path), strings.Join(others, ", "))
+			}
+		}
+	}
+}
+
+// Claims tracks all ingresses claiming a given class, host and path.
+func (i *Ingress) claims() map[string][]string {
+	claims := make(map[string][]string)
+	for fqn, ing := range i.ListIngresses() {
+		seen := make(map[string]struct{})
+		for _, r := range ing.Spec.Rules {
+			if r.HTTP == nil {
+				continue
+			}
+			for _, p := range r.HTTP.Paths {
+				key := claimKey(ingressClass(ing), r.Host, p.Path)
+				if _, ok := seen[key]; ok {
+					continue
+				}
+				seen[key] = struct{}{}
+				claims[key] = append(claims[key], fqn)
+			}
+		}
+	}
+	for _, ff := range claims {
+		sort.Strings(ff)
+	}
+
+	return claims
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func ingressClass(ing *netv1.Ingress) string {
+	if ing.Spec.IngressClassName != nil {
+		return *ing.Spec.IngressClassName
+	}
+
+	return ing.Annotations[ingressClassAnnotation]
+}
+
+func ingressBackends(ing *netv1.Ingress) []netv1.IngressBackend {
+	var bb []netv1.IngressBackend
+	if ing.Spec.DefaultBackend != nil {
+		bb = append(bb, *ing.Spec.DefaultBackend)
+	}
+	for _, r := range ing.Spec.Rules {
+		if r.HTTP == nil {
+			continue
+		}
+		for _, p := range r.HTTP.Paths {
+			bb = append(bb, p.Backend)
+		}
+	}
+
+	return bb
+}
+
+func hasServicePort(svc *v1.Service, port netv1.ServiceBackendPort) bool {
+	for _, p := range svc.Spec.Ports {
+		if port.Name != "" && p.Name == port.Name {
+			return true
+		}
+		if port.Name == "" && p.Port == port.Number {
+			return true
+		}
+	}
+
+	return false
+}
+
+func backendPort(port netv1.ServiceBackendPort) string {
+	if port.Name != "" {
+		return port.Name
+	}
+
+	return strconv.Itoa(int(port.Number))
+}
+
+func hasReadyEndpoints(ep *v1.Endpoints) bool {
+	if ep == nil {
+		return false
+	}
+	for _, s := range ep.Subsets {
+		if len(s.Addresses) > 0 {
+			return true
 		}
 	}
-	if rev != current {
-		i.AddCode(ctx, 403, "Ingress", rev, current)
+
+	return false
+}
+
+func claimKey(class, host, path string) string {
+	return class + "|" + claimHost(host) + "|" + claimPath(path)
+}
+
+func claimHost(h string) string {
+	if h == "" {
+		return "*"
+	}
+
+	return h
+}
+
+func claimPath(p string) string {
+	if p == "" {
+		return "/"
 	}
+
+	return p
 }
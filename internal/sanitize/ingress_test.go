@@ -3,9 +3,11 @@ package sanitize
 import (
 	"testing"
 
+	"github.com/derailed/popeye/internal/client"
 	"github.com/derailed/popeye/internal/issues"
 	"github.com/derailed/popeye/pkg/config"
 	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -25,7 +27,7 @@ func TestIngressSanitize(t *testing.T) {
 				{
 					GVR:     "networking.k8s.io/v1",
 					Group:   issues.Root,
-					Message: `[POP-403] Deprecated Ingress API group "extensions/v1beta1". Use "networking.k8s.io/v1" instead`,
+					Message: `[POP-403] Deprecated Ingress API group "extensions/v1beta1" removed in v1.22. Use "networking.k8s.io/v1/ingresses" instead`,
 					Level:   config.WarnLevel,
 				},
 			},
@@ -44,6 +46,103 @@ func TestIngressSanitize(t *testing.T) {
 	}
 }
 
+func TestIngressReferences(t *testing.T) {
+	gvr := client.NewGVR("networking.k8s.io/v1/ingresses")
+	uu := map[string]struct {
+		opts      ingOpts
+		others    []*netv1.Ingress
+		noClasses bool
+		issues    issues.Issues
+	}{
+		"good": {
+			opts:   ingOpts{class: "nginx", svc: "s1", port: "http", tls: "tls1"},
+			issues: issues.Issues{},
+		},
+		"portNumber": {
+			opts:   ingOpts{svc: "s1", number: 80},
+			issues: issues.Issues{},
+		},
+		"noClass": {
+			opts: ingOpts{class: "traefik", svc: "s1", port: "http"},
+			issues: issues.Issues{
+				issues.New(gvr, issues.Root, config.ErrorLevel, `[POP-1604] IngressClass "traefik" does not exist`),
+			},
+		},
+		"classesUnavailable": {
+			opts:      ingOpts{class: "traefik", svc: "s1", port: "http"},
+			noClasses: true,
+			issues:    issues.Issues{},
+		},
+		"legacyClass": {
+			opts: ingOpts{annotation: "traefik", svc: "s1", port: "http"},
+			issues: issues.Issues{
+				issues.New(gvr, issues.Root, config.ErrorLevel, `[POP-1604] IngressClass "traefik" does not exist`),
+			},
+		},
+		"noService": {
+			opts: ingOpts{svc: "s2", port: "http"},
+			issues: issues.Issues{
+				issues.New(gvr, issues.Root, config.ErrorLevel, `[POP-1600] Ingress backend service "s2" does not exist`),
+			},
+		},
+		"noPort": {
+			opts: ingOpts{svc: "s1", number: 8080},
+			issues: issues.Issues{
+				issues.New(gvr, issues.Root, config.ErrorLevel, `[POP-1601] Ingress backend service "s1" does not expose port 8080`),
+			},
+		},
+		"noEndpoints": {
+			opts: ingOpts{svc: "s3", port: "http"},
+			issues: issues.Issues{
+				issues.New(gvr, issues.Root, config.ErrorLevel, `[POP-1605] Ingress backend service "s3" has no ready endpoints`),
+			},
+		},
+		"noTLS": {
+			opts: ingOpts{svc: "s1", port: "http", tls: "tls2"},
+			issues: issues.Issues{
+				issues.New(gvr, issues.Root, config.ErrorLevel, `[POP-1602] TLS secret "tls2" does not exist`),
+			},
+		},
+		"badTLS": {
+			opts: ingOpts{svc: "s1", port: "http", tls: "sec1"},
+			issues: issues.Issues{
+				issues.New(gvr, issues.Root, config.ErrorLevel, `[POP-1603] TLS secret "sec1" is of type "Opaque". Expecting "kubernetes.io/tls"`),
+			},
+		},
+		"conflict": {
+			opts: ingOpts{class: "nginx", svc: "s1", port: "http"},
+			others: []*netv1.Ingress{
+				makeRuleIngress("i2", ingOpts{class: "nginx", svc: "s1", port: "http"}),
+				makeRuleIngress("i3", ingOpts{class: "nginx", svc: "s1", port: "http"}),
+			},
+			issues: issues.Issues{
+				issues.New(gvr, issues.Root, config.WarnLevel, `[POP-1606] Host "fred.com" path "/" is also claimed by ingress default/i2, default/i3`),
+			},
+		},
+		"otherClass": {
+			opts: ingOpts{class: "nginx", svc: "s1", port: "http"},
+			others: []*netv1.Ingress{
+				makeRuleIngress("i2", ingOpts{class: "internal", svc: "s1", port: "http"}),
+			},
+			issues: issues.Issues{},
+		},
+	}
+
+	ctx := makeContext("networking.k8s.io/v1/ingresses", "ingresses")
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			l := makeIngLister(append([]*netv1.Ingress{makeRuleIngress("i1", u.opts)}, u.others...))
+			l.noClasses = u.noClasses
+			i := NewIngress(issues.NewCollector(loadCodes(t), makeConfig(t)), l)
+
+			assert.Nil(t, i.Sanitize(ctx))
+			assert.Equal(t, u.issues, i.Outcome()["default/i1"])
+		})
+	}
+}
+
+// ----------------------------------------------------------------------------
 // Helpers...
 
 type ingress struct {
@@ -60,6 +159,22 @@ func (i ingress) ListIngresses() map[string]*netv1.Ingress {
 	}
 }
 
+func (i ingress) ListIngressClasses() map[string]*netv1.IngressClass {
+	return map[string]*netv1.IngressClass{}
+}
+
+func (i ingress) ListServices() map[string]*v1.Service {
+	return map[string]*v1.Service{}
+}
+
+func (i ingress) GetEndpoints(string) *v1.Endpoints {
+	return nil
+}
+
+func (i ingress) ListSecrets() map[string]*v1.Secret {
+	return map[string]*v1.Secret{}
+}
+
 func makeIngress(url string) *netv1.Ingress {
 	return &netv1.Ingress{
 		ObjectMeta: metav1.ObjectMeta{
@@ -67,3 +182,110 @@ func makeIngress(url string) *netv1.Ingress {
 		},
 	}
 }
+
+type (
+	ingOpts struct {
+		class, annotation string
+		svc, port         string
+		number            int32
+		tls               string
+	}
+
+	ingLister struct {
+		ings      map[string]*netv1.Ingress
+		noClasses bool
+	}
+)
+
+func makeIngLister(ii []*netv1.Ingress) *ingLister {
+	l := ingLister{ings: make(map[string]*netv1.Ingress, len(ii))}
+	for _, i := range ii {
+		l.ings["default/"+i.Name] = i
+	}
+
+	return &l
+}
+
+func (l *ingLister) ListIngresses() map[string]*netv1.Ingress {
+	return l.ings
+}
+
+func (l *ingLister) ListIngressClasses() map[string]*netv1.IngressClass {
+	if l.noClasses {
+		return nil
+	}
+	return map[string]*netv1.IngressClass{
+		"nginx":    {ObjectMeta: metav1.ObjectMeta{Name: "nginx"}},
+		"internal": {ObjectMeta: metav1.ObjectMeta{Name: "internal"}},
+	}
+}
+
+func (l *ingLister) ListServices() map[string]*v1.Service {
+	ports := []v1.ServicePort{{Name: "http", Port: 80}}
+	return map[string]*v1.Service{
+		"default/s1": makeSvc("s1", svcOpts{ports: ports}),
+		"default/s3": makeSvc("s3", svcOpts{ports: ports}),
+	}
+}
+
+func (l *ingLister) GetEndpoints(fqn string) *v1.Endpoints {
+	if fqn == "default/s1" {
+		return makeEp("s1", "1.1.1.1")
+	}
+
+	return nil
+}
+
+func (l *ingLister) ListSecrets() map[string]*v1.Secret {
+	tls := makeSecret("tls1")
+	tls.Type = v1.SecretTypeTLS
+	sec := makeSecret("sec1")
+	sec.Type = v1.SecretTypeOpaque
+
+	return map[string]*v1.Secret{
+		"default/tls1": tls,
+		"default/sec1": sec,
+	}
+}
+
+func makeRuleIngress(n string, opts ingOpts) *netv1.Ingress {
+	ing := netv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      n,
+			Namespace: "default",
+		},
+	}
+	if opts.class != "" {
+		ing.Spec.IngressClassName = &opts.class
+	}
+	if opts.annotation != "" {
+		ing.Annotations = map[string]string{ingressClassAnnotation: opts.annotation}
+	}
+	if opts.tls != "" {
+		ing.Spec.TLS = []netv1.IngressTLS{{Hosts: []string{"fred.com"}, SecretName: opts.tls}}
+	}
+	pt := netv1.PathTypePrefix
+	ing.Spec.Rules = []netv1.IngressRule{
+		{
+			Host: "fred.com",
+			IngressRuleValue: netv1.IngressRuleValue{
+				HTTP: &netv1.HTTPIngressRuleValue{
+					Paths: []netv1.HTTPIngressPath{
+						{
+							Path:     "/",
+							PathType: &pt,
+							Backend: netv1.IngressBackend{
+								Service: &netv1.IngressServiceBackend{
+									Name: opts.svc,
+									Port: netv1.ServiceBackendPort{Name: opts.port, Number: opts.number},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return &ing
+}
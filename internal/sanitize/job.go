@@ -0,0 +1,122 @@
+package sanitize
+
+import (
+	"context"
+
+	"github.com/derailed/popeye/internal"
+	"github.com/derailed/popeye/internal/issues"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type (
+	// JobLister list available Jobs on a cluster.
+	JobLister interface {
+		ListJobs() map[string]*batchv1.Job
+	}
+
+	// JLister represents jobs and deps listers.
+	JLister interface {
+		JobLister
+		PodSecurityLister
+	}
+
+	// Job tracks Job sanitization.
+	Job struct {
+		*issues.Collector
+		JLister
+	}
+)
+
+// NewJob returns a new sanitizer.
+func NewJob(co *issues.Collector, lister JLister) *Job {
+	return &Job{
+		Collector: co,
+		JLister:   lister,
+	}
+}
+
+// Sanitize cleanse the resource.
+func (j *Job) Sanitize(ctx context.Context) error {
+	for fqn, job := range j.ListJobs() {
+		j.InitOutcome(fqn)
+		ctx = internal.WithFQN(ctx, fqn)
+
+		j.checkJob(ctx, job)
+		j.checkContainers(ctx, job.Spec.Template.Spec)
+		checkHardening(ctx, j.Collector, job.Spec.Template)
+		checkPodSecurity(ctx, j.Collector, j, job.Namespace, job.Spec.Template)
+
+		if j.NoConcerns(fqn) && j.Config.ExcludeFQN(internal.MustExtractSectionGVR(ctx), fqn) {
+			j.ClearOutcome(fqn)
+		}
+	}
+
+	return nil
+}
+
+// CheckJob checks if the job failed or lingers once finished.
+func (j *Job) checkJob(ctx context.Context, job *batchv1.Job) {
+	if reason, ok := jobFailed(job); ok {
+		j.AddCode(ctx, 1401, reason)
+	}
+	// CronJob jobs are cleaned up by the CronJob history limits.
+	if isOwnedBy(job.OwnerReferences, "CronJob", "") {
+		return
+	}
+	if job.Spec.TTLSecondsAfterFinished == nil {
+		j.AddCode(ctx, 1400)
+	}
+}
+
+// CheckContainers runs thru job template and checks pod configuration.
+func (j *Job) checkContainers(ctx context.Context, spec v1.PodSpec) {
+	c := NewContainer(internal.MustExtractFQN(ctx), j)
+	for _, co := range spec.InitContainers {
+		c.sanitize(ctx, co, false)
+	}
+	for _, co := range spec.Containers {
+		c.sanitize(ctx, co, false)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+// jobFailed checks if a job failed and returns the failure reason.
+func jobFailed(job *batchv1.Job) (string, bool) {
+	for _, c := range job.Status.Conditions {
+		if c.Type != batchv1.JobFailed || c.Status != v1.ConditionTrue {
+			continue
+		}
+		if c.Reason != "" {
+			return c.Reason, true
+		}
+		return "Failed", true
+	}
+
+	return "", false
+}
+
+// jobFinished checks if a job completed or failed.
+func jobFinished(job *batchv1.Job) bool {
+	for _, c := range job.Status.Conditions {
+		if (c.Type == batchv1.JobComplete || c.Type == batchv1.JobFailed) && c.Status == v1.ConditionTrue {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isOwnedBy checks if a resource is owned by the given kind and name if any.
+func isOwnedBy(refs []metav1.OwnerReference, kind, name string) bool {
+	for _, r := range refs {
+		if r.Kind == kind && (name == "" || r.Name == name) {
+			return true
+		}
+	}
+
+	return false
+}
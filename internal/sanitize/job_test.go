@@ -0,0 +1,124 @@
+package sanitize
+
+import (
+	"testing"
+
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/pkg/config"
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestJobSanitize(t *testing.T) {
+	uu := map[string]struct {
+		opts   jobOpts
+		issues issues.Issues
+	}{
+		"good": {
+			opts:   jobOpts{ttl: true},
+			issues: issues.Issues{},
+		},
+		"noTTL": {
+			opts: jobOpts{},
+			issues: issues.Issues{
+				issues.New(client.NewGVR("batch/v1/jobs"), issues.Root, config.InfoLevel, "[POP-1400] No ttlSecondsAfterFinished set. Finished job will linger"),
+			},
+		},
+		"cronjob": {
+			opts:   jobOpts{owner: "cj1"},
+			issues: issues.Issues{},
+		},
+		"failed": {
+			opts: jobOpts{ttl: true, failed: "BackoffLimitExceeded"},
+			issues: issues.Issues{
+				issues.New(client.NewGVR("batch/v1/jobs"), issues.Root, config.WarnLevel, "[POP-1401] Failed job lingering (BackoffLimitExceeded)"),
+			},
+		},
+		"container": {
+			opts: jobOpts{ttl: true, coOpts: coOpts{image: "fred"}},
+			issues: issues.Issues{
+				issues.New(client.NewGVR("containers"), "c1", config.ErrorLevel, "[POP-100] Untagged docker image in use"),
+				issues.New(client.NewGVR("containers"), "c1", config.WarnLevel, "[POP-106] No resources requests/limits defined"),
+			},
+		},
+	}
+
+	ctx := makeContext("batch/v1/jobs", "jobs")
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			l := jobLister{jobs: map[string]*batchv1.Job{"default/j1": makeJob("j1", u.opts)}}
+			j := NewJob(issues.NewCollector(loadCodes(t), makeConfig(t)), &l)
+
+			assert.Nil(t, j.Sanitize(ctx))
+			assert.Equal(t, u.issues, j.Outcome()["default/j1"])
+		})
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+type jobLister struct {
+	jobs map[string]*batchv1.Job
+}
+
+func (l *jobLister) ListNamespaces() map[string]*v1.Namespace {
+	return map[string]*v1.Namespace{}
+}
+
+func (l *jobLister) ListJobs() map[string]*batchv1.Job {
+	return l.jobs
+}
+
+type jobOpts struct {
+	coOpts
+	ttl       bool
+	owner     string
+	failed    string
+	completed bool
+	started   *metav1.Time
+}
+
+func makeJob(n string, opts jobOpts) *batchv1.Job {
+	if opts.image == "" {
+		opts.image, opts.rcpu, opts.rmem, opts.lcpu, opts.lmem = "fred:0.0.1", "10m", "10Mi", "10m", "10Mi"
+	}
+	job := batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: n, Namespace: "default"},
+		Spec: batchv1.JobSpec{
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{makeContainer("c1", opts.coOpts)},
+				},
+			},
+		},
+		Status: batchv1.JobStatus{StartTime: opts.started},
+	}
+	if opts.ttl {
+		ttl := int32(60)
+		job.Spec.TTLSecondsAfterFinished = &ttl
+	}
+	if opts.owner != "" {
+		job.OwnerReferences = []metav1.OwnerReference{{Kind: "CronJob", Name: opts.owner}}
+	}
+	if opts.failed != "" {
+		job.Status.Conditions = append(job.Status.Conditions, batchv1.JobCondition{
+			Type:   batchv1.JobFailed,
+			Status: v1.ConditionTrue,
+			Reason: opts.failed,
+		})
+	}
+	if opts.completed {
+		job.Status.Conditions = append(job.Status.Conditions, batchv1.JobCondition{
+			Type:   batchv1.JobComplete,
+			Status: v1.ConditionTrue,
+		})
+	}
+
+	hardenTemplate(&job.Spec.Template)
+	return &job
+}
@@ -0,0 +1,122 @@
+package sanitize
+
+import (
+	"context"
+	"sort"
+
+	"github.com/derailed/popeye/internal"
+	"github.com/derailed/popeye/internal/issues"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+type (
+	// LimitRangeLister list available LimitRanges on a cluster.
+	LimitRangeLister interface {
+		ListLimitRanges() map[string]*v1.LimitRange
+	}
+
+	// LRLister represents limitranges and deps listers.
+	LRLister interface {
+		LimitRangeLister
+		ListPods() map[string]*v1.Pod
+	}
+
+	// LimitRange tracks LimitRange sanitization.
+	LimitRange struct {
+		*issues.Collector
+		LRLister
+	}
+)
+
+// NewLimitRange returns a new sanitizer.
+func NewLimitRange(co *issues.Collector, lister LRLister) *LimitRange {
+	return &LimitRange{
+		Collector: co,
+		LRLister:  lister,
+	}
+}
+
+// Sanitize cleanse the resource.
+func (l *LimitRange) Sanitize(ctx context.Context) error {
+	for fqn, lr := range l.ListLimitRanges() {
+		l.InitOutcome(fqn)
+		ctx = internal.WithFQN(ctx, fqn)
+
+		l.checkContainers(ctx, lr)
+
+		if l.NoConcerns(fqn) && l.Config.ExcludeFQN(internal.MustExtractSectionGVR(ctx), fqn) {
+			l.ClearOutcome(fqn)
+		}
+	}
+
+	return nil
+}
+
+// CheckContainers checks the namespace pods containers against the LimitRange constraints.
+func (l *LimitRange) checkContainers(ctx context.Context, lr *v1.LimitRange) {
+	pods := l.ListPods()
+	fqns := make([]string, 0, len(pods))
+	for fqn, po := range pods {
+		if po.Namespace == lr.Namespace {
+			fqns = append(fqns, fqn)
+		}
+	}
+	sort.Strings(fqns)
+
+	for _, item := range lr.Spec.Limits {
+		if item.Type != v1.LimitTypeContainer {
+			continue
+		}
+		for _, fqn := range fqns {
+			spec := pods[fqn].Spec
+			for _, co := range spec.InitContainers {
+				l.checkContainer(ctx, fqn, co, item)
+			}
+			for _, co := range spec.Containers {
+				l.checkContainer(ctx, fqn, co, item)
+			}
+		}
+	}
+}
+
+func (l *LimitRange) checkContainer(ctx context.Context, pfqn string, co v1.Container, item v1.LimitRangeItem) {
+	for _, res := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+		req, hasReq := co.Resources.Requests[res]
+		lim, hasLim := co.Resources.Limits[res]
+
+		// A container without a limit gets the default limit which must not be below its request.
+		if dl, ok := item.Default[res]; ok && hasReq && !hasLim && req.Cmp(dl) > 0 {
+			l.AddCode(ctx, 1510, pfqn, co.Name, res, req.String(), dl.String())
+		}
+
+		ratio, ok := item.MaxLimitRequestRatio[res]
+		if !ok {
+			continue
+		}
+		// Missing requests default to the container limit prior to applying the LimitRange defaults.
+		if !hasReq {
+			if hasLim {
+				req, hasReq = lim, true
+			} else {
+				req, hasReq = item.DefaultRequest[res]
+			}
+		}
+		if !hasLim {
+			lim, hasLim = item.Default[res]
+		}
+		if !hasReq || !hasLim || req.IsZero() {
+			continue
+		}
+		if r := toRatio(lim, req); r > ratio.AsApproximateFloat64() {
+			l.AddCode(ctx, 1511, pfqn, co.Name, res, r, ratio.String())
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func toRatio(lim, req resource.Quantity) float64 {
+	return lim.AsApproximateFloat64() / req.AsApproximateFloat64()
+}
@@ -0,0 +1,115 @@
+package sanitize
+
+import (
+	"testing"
+
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/pkg/config"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLimitRangeSanitize(t *testing.T) {
+	uu := map[string]struct {
+		ns     string
+		opts   coOpts
+		issues issues.Issues
+	}{
+		"good": {
+			ns:     "default",
+			opts:   coOpts{rcpu: "200m", rmem: "128Mi", lcpu: "400m", lmem: "256Mi"},
+			issues: issues.Issues{},
+		},
+		"defaulted": {
+			ns:     "default",
+			issues: issues.Issues{},
+		},
+		"requestOverDefault": {
+			ns:   "default",
+			opts: coOpts{rcpu: "1", rmem: "512Mi"},
+			issues: issues.Issues{
+				issues.New(client.NewGVR("v1/limitranges"), issues.Root, config.ErrorLevel, `[POP-1510] Pod default/p1 container "c1" cpu request 1 exceeds LimitRange default limit 500m`),
+				issues.New(client.NewGVR("v1/limitranges"), issues.Root, config.ErrorLevel, `[POP-1510] Pod default/p1 container "c1" memory request 512Mi exceeds LimitRange default limit 256Mi`),
+			},
+		},
+		"ratio": {
+			ns:   "default",
+			opts: coOpts{rcpu: "100m", rmem: "64Mi", lcpu: "1", lmem: "128Mi"},
+			issues: issues.Issues{
+				issues.New(client.NewGVR("v1/limitranges"), issues.Root, config.ErrorLevel, `[POP-1511] Pod default/p1 container "c1" cpu limit/request ratio 10.00 exceeds max 5`),
+			},
+		},
+		"ratioDefaultLimit": {
+			ns:   "default",
+			opts: coOpts{rcpu: "50m", rmem: "64Mi"},
+			issues: issues.Issues{
+				issues.New(client.NewGVR("v1/limitranges"), issues.Root, config.ErrorLevel, `[POP-1511] Pod default/p1 container "c1" cpu limit/request ratio 10.00 exceeds max 5`),
+			},
+		},
+		"otherNamespace": {
+			ns:     "fred",
+			opts:   coOpts{rcpu: "100m", rmem: "64Mi", lcpu: "1", lmem: "128Mi"},
+			issues: issues.Issues{},
+		},
+	}
+
+	ctx := makeContext("v1/limitranges", "limitranges")
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			po := makePod("p1")
+			po.Namespace = u.ns
+			po.Spec.Containers = []v1.Container{makeContainer("c1", u.opts)}
+			l := lrLister{
+				lrs:  map[string]*v1.LimitRange{"default/lr1": makeLimitRange("lr1")},
+				pods: map[string]*v1.Pod{u.ns + "/p1": po},
+			}
+			lr := NewLimitRange(issues.NewCollector(loadCodes(t), makeConfig(t)), &l)
+
+			assert.Nil(t, lr.Sanitize(ctx))
+			assert.Equal(t, u.issues, lr.Outcome()["default/lr1"])
+		})
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+type lrLister struct {
+	lrs  map[string]*v1.LimitRange
+	pods map[string]*v1.Pod
+}
+
+func (l *lrLister) ListLimitRanges() map[string]*v1.LimitRange {
+	return l.lrs
+}
+
+func (l *lrLister) ListPods() map[string]*v1.Pod {
+	return l.pods
+}
+
+func makeLimitRange(n string) *v1.LimitRange {
+	return &v1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      n,
+			Namespace: "default",
+		},
+		Spec: v1.LimitRangeSpec{
+			Limits: []v1.LimitRangeItem{
+				{
+					Type:           v1.LimitTypePod,
+					Default:        makeRes("100m", "64Mi"),
+					DefaultRequest: makeRes("10m", "8Mi"),
+				},
+				{
+					Type:                 v1.LimitTypeContainer,
+					Default:              makeRes("500m", "256Mi"),
+					DefaultRequest:       makeRes("100m", "64Mi"),
+					MaxLimitRequestRatio: v1.ResourceList{v1.ResourceCPU: *makeQty("5")},
+				},
+			},
+		},
+	}
+}
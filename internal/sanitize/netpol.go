@@ -0,0 +1,217 @@
+package sanitize
+
+import (
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	nv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// DNSNamespace tracks the namespace hosting the cluster DNS.
+	dnsNamespace = "kube-system"
+
+	// DNSPort tracks the cluster DNS port.
+	dnsPort = 53
+
+	// NamespaceNameLabel tracks the namespace name label set by the api server.
+	namespaceNameLabel = "kubernetes.io/metadata.name"
+)
+
+type (
+	// NPLister list available NetworkPolicies.
+	NPLister interface {
+		ListNetworkPolicies() map[string]*nv1.NetworkPolicy
+	}
+
+	// NetworkIsolation tracks a namespace network isolation.
+	NetworkIsolation struct {
+		Namespace   string
+		Policies    int
+		DenyIngress bool
+		DenyEgress  bool
+		Pods        int
+		Selected    int
+	}
+)
+
+// ComputeNetworkIsolation returns the network isolation of each namespace sorted by name.
+func ComputeNetworkIsolation(nss map[string]*v1.Namespace, pods map[string]*v1.Pod, nps map[string]*nv1.NetworkPolicy) []NetworkIsolation {
+	index := make(map[string]*NetworkIsolation, len(nss))
+	for ns := range nss {
+		index[ns] = &NetworkIsolation{Namespace: ns}
+	}
+	for _, np := range nps {
+		iso, ok := index[np.Namespace]
+		if !ok {
+			continue
+		}
+		iso.Policies++
+		iso.DenyIngress = iso.DenyIngress || isDefaultDeny(np, nv1.PolicyTypeIngress)
+		iso.DenyEgress = iso.DenyEgress || isDefaultDeny(np, nv1.PolicyTypeEgress)
+	}
+	for _, po := range pods {
+		iso, ok := index[po.Namespace]
+		if !ok {
+			continue
+		}
+		iso.Pods++
+		if isSelectedByPolicy(nps, po) {
+			iso.Selected++
+		}
+	}
+
+	ii := make([]NetworkIsolation, 0, len(index))
+	for _, iso := range index {
+		ii = append(ii, *iso)
+	}
+	sort.Slice(ii, func(i, j int) bool {
+		return ii[i].Namespace < ii[j].Namespace
+	})
+
+	return ii
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+// NamespacePolicies returns the NetworkPolicies defined in a namespace.
+func namespacePolicies(nps map[string]*nv1.NetworkPolicy, ns string) []*nv1.NetworkPolicy {
+	var pp []*nv1.NetworkPolicy
+	for _, np := range nps {
+		if np.Namespace == ns {
+			pp = append(pp, np)
+		}
+	}
+
+	return pp
+}
+
+// HasPolicyType checks if a policy applies to the given traffic direction.
+// Policies without types always apply to ingress and to egress if they define egress rules.
+func hasPolicyType(np *nv1.NetworkPolicy, t nv1.PolicyType) bool {
+	if len(np.Spec.PolicyTypes) == 0 {
+		return t == nv1.PolicyTypeIngress || len(np.Spec.Egress) > 0
+	}
+	for _, pt := range np.Spec.PolicyTypes {
+		if pt == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsDefaultDeny checks if a policy denies all traffic in a direction for all pods in its namespace.
+func isDefaultDeny(np *nv1.NetworkPolicy, t nv1.PolicyType) bool {
+	if !isEmptySelector(&np.Spec.PodSelector) || !hasPolicyType(np, t) {
+		return false
+	}
+	if t == nv1.PolicyTypeIngress {
+		return len(np.Spec.Ingress) == 0
+	}
+
+	return len(np.Spec.Egress) == 0
+}
+
+func isSelectedByPolicy(nps map[string]*nv1.NetworkPolicy, po *v1.Pod) bool {
+	for _, np := range namespacePolicies(nps, po.Namespace) {
+		if matchSelector(&np.Spec.PodSelector, po.Labels) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AllowsAllIngress checks if an ingress rule lets traffic in from any pod in any namespace.
+func allowsAllIngress(rule nv1.NetworkPolicyIngressRule) bool {
+	if len(rule.Ports) > 0 {
+		return false
+	}
+	if len(rule.From) == 0 {
+		return true
+	}
+	for _, from := range rule.From {
+		if from.NamespaceSelector != nil && isEmptySelector(from.NamespaceSelector) &&
+			(from.PodSelector == nil || isEmptySelector(from.PodSelector)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AllowsDNS checks if an egress rule lets traffic out to the cluster DNS.
+func allowsDNS(rule nv1.NetworkPolicyEgressRule, dns *v1.Namespace) bool {
+	if !allowsDNSPort(rule.Ports) {
+		return false
+	}
+	if len(rule.To) == 0 {
+		return true
+	}
+	for _, to := range rule.To {
+		switch {
+		case to.IPBlock != nil:
+			return true
+		case to.NamespaceSelector != nil && matchSelector(to.NamespaceSelector, dns.Labels):
+			return true
+		}
+	}
+
+	return false
+}
+
+func allowsDNSPort(pp []nv1.NetworkPolicyPort) bool {
+	if len(pp) == 0 {
+		return true
+	}
+	for _, p := range pp {
+		if p.Port == nil {
+			return true
+		}
+		if p.Port.Type == intstr.Int && p.Port.IntVal == dnsPort {
+			return true
+		}
+		if p.Port.Type == intstr.String && (p.Port.StrVal == "dns" || p.Port.StrVal == "dns-tcp") {
+			return true
+		}
+		if p.EndPort != nil && p.Port.Type == intstr.Int && p.Port.IntVal <= dnsPort && *p.EndPort >= dnsPort {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DNSNamespaceFrom returns the cluster DNS namespace, defaulting its name label if not cached.
+func dnsNamespaceFrom(nss map[string]*v1.Namespace) *v1.Namespace {
+	if ns, ok := nss[dnsNamespace]; ok && ns.Labels[namespaceNameLabel] != "" {
+		return ns
+	}
+	ns := v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: dnsNamespace, Labels: map[string]string{}}}
+	if cached, ok := nss[dnsNamespace]; ok {
+		for k, v := range cached.Labels {
+			ns.Labels[k] = v
+		}
+	}
+	ns.Labels[namespaceNameLabel] = dnsNamespace
+
+	return &ns
+}
+
+func isEmptySelector(sel *metav1.LabelSelector) bool {
+	return len(sel.MatchLabels) == 0 && len(sel.MatchExpressions) == 0
+}
+
+func matchSelector(sel *metav1.LabelSelector, ll map[string]string) bool {
+	s, err := metav1.LabelSelectorAsSelector(sel)
+	if err != nil {
+		return false
+	}
+
+	return s.Matches(labels.Set(ll))
+}
@@ -0,0 +1,191 @@
+package sanitize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	nv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestComputeNetworkIsolation(t *testing.T) {
+	nss := map[string]*v1.Namespace{
+		"ns1": makeNS("ns1", true),
+		"ns2": makeNS("ns2", true),
+		"ns3": makeNS("ns3", true),
+	}
+	pods := map[string]*v1.Pod{
+		"ns1/p1": makeLabeledPod("ns1", "p1", map[string]string{"app": "fred"}),
+		"ns1/p2": makeLabeledPod("ns1", "p2", map[string]string{"app": "blee"}),
+		"ns2/p1": makeLabeledPod("ns2", "p1", nil),
+		"ns3/p1": makeLabeledPod("ns3", "p1", nil),
+	}
+	nps := map[string]*nv1.NetworkPolicy{
+		"ns1/fred": makeSelNP("ns1", "fred", map[string]string{"app": "fred"}, nv1.PolicyTypeIngress),
+		"ns2/deny": makeSelNP("ns2", "deny", nil, nv1.PolicyTypeIngress, nv1.PolicyTypeEgress),
+	}
+
+	assert.Equal(t, []NetworkIsolation{
+		{Namespace: "ns1", Policies: 1, Pods: 2, Selected: 1},
+		{Namespace: "ns2", Policies: 1, DenyIngress: true, DenyEgress: true, Pods: 1, Selected: 1},
+		{Namespace: "ns3", Pods: 1},
+	}, ComputeNetworkIsolation(nss, pods, nps))
+}
+
+func TestIsDefaultDeny(t *testing.T) {
+	uu := map[string]struct {
+		np              *nv1.NetworkPolicy
+		ingress, egress bool
+	}{
+		"denyAll": {
+			np:      makeSelNP("default", "np", nil, nv1.PolicyTypeIngress, nv1.PolicyTypeEgress),
+			ingress: true,
+			egress:  true,
+		},
+		"noTypes": {
+			np:      makeSelNP("default", "np", nil),
+			ingress: true,
+		},
+		"selected": {
+			np: makeSelNP("default", "np", map[string]string{"app": "fred"}, nv1.PolicyTypeIngress),
+		},
+		"allowed": {
+			np: func() *nv1.NetworkPolicy {
+				np := makeSelNP("default", "np", nil, nv1.PolicyTypeIngress, nv1.PolicyTypeEgress)
+				np.Spec.Ingress = []nv1.NetworkPolicyIngressRule{{}}
+				return np
+			}(),
+			egress: true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.ingress, isDefaultDeny(u.np, nv1.PolicyTypeIngress))
+			assert.Equal(t, u.egress, isDefaultDeny(u.np, nv1.PolicyTypeEgress))
+		})
+	}
+}
+
+func TestAllowsAllIngress(t *testing.T) {
+	tcp, http := v1.ProtocolTCP, intstr.FromInt(80)
+	uu := map[string]struct {
+		rule nv1.NetworkPolicyIngressRule
+		e    bool
+	}{
+		"empty": {
+			e: true,
+		},
+		"ports": {
+			rule: nv1.NetworkPolicyIngressRule{
+				Ports: []nv1.NetworkPolicyPort{{Protocol: &tcp, Port: &http}},
+			},
+		},
+		"allNamespaces": {
+			rule: nv1.NetworkPolicyIngressRule{
+				From: []nv1.NetworkPolicyPeer{{NamespaceSelector: &metav1.LabelSelector{}}},
+			},
+			e: true,
+		},
+		"someNamespaces": {
+			rule: nv1.NetworkPolicyIngressRule{
+				From: []nv1.NetworkPolicyPeer{{NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"team": "fred"},
+				}}},
+			},
+		},
+		"somePods": {
+			rule: nv1.NetworkPolicyIngressRule{
+				From: []nv1.NetworkPolicyPeer{{
+					NamespaceSelector: &metav1.LabelSelector{},
+					PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "fred"}},
+				}},
+			},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, allowsAllIngress(u.rule))
+		})
+	}
+}
+
+func TestAllowsDNS(t *testing.T) {
+	udp, dns, http, named := v1.ProtocolUDP, intstr.FromInt(53), intstr.FromInt(80), intstr.FromString("dns")
+	kubeSystem := metav1.LabelSelector{MatchLabels: map[string]string{namespaceNameLabel: dnsNamespace}}
+	uu := map[string]struct {
+		rule nv1.NetworkPolicyEgressRule
+		e    bool
+	}{
+		"empty": {
+			e: true,
+		},
+		"dnsPort": {
+			rule: nv1.NetworkPolicyEgressRule{
+				Ports: []nv1.NetworkPolicyPort{{Protocol: &udp, Port: &dns}},
+			},
+			e: true,
+		},
+		"namedPort": {
+			rule: nv1.NetworkPolicyEgressRule{
+				Ports: []nv1.NetworkPolicyPort{{Protocol: &udp, Port: &named}},
+			},
+			e: true,
+		},
+		"otherPort": {
+			rule: nv1.NetworkPolicyEgressRule{
+				Ports: []nv1.NetworkPolicyPort{{Port: &http}},
+			},
+		},
+		"kubeSystem": {
+			rule: nv1.NetworkPolicyEgressRule{
+				To:    []nv1.NetworkPolicyPeer{{NamespaceSelector: &kubeSystem}},
+				Ports: []nv1.NetworkPolicyPort{{Protocol: &udp, Port: &dns}},
+			},
+			e: true,
+		},
+		"otherNamespace": {
+			rule: nv1.NetworkPolicyEgressRule{
+				To: []nv1.NetworkPolicyPeer{{NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{namespaceNameLabel: "fred"},
+				}}},
+			},
+		},
+	}
+
+	dnsNS := dnsNamespaceFrom(map[string]*v1.Namespace{})
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, allowsDNS(u.rule, dnsNS))
+		})
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func makeLabeledPod(ns, n string, ll map[string]string) *v1.Pod {
+	po := makePod(n)
+	po.Namespace, po.Labels = ns, ll
+
+	return po
+}
+
+func makeSelNP(ns, n string, sel map[string]string, tt ...nv1.PolicyType) *nv1.NetworkPolicy {
+	return &nv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      n,
+			Namespace: ns,
+		},
+		Spec: nv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: sel},
+			PolicyTypes: tt,
+		},
+	}
+}
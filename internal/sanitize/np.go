@@ -26,7 +26,8 @@ type (
 	NetworkPolicyLister interface {
 		PodSelectorLister
 		NamespaceSelectorLister
-		ListNetworkPolicies() map[string]*nv1.NetworkPolicy
+		NPLister
+		ListNamespaces() map[string]*v1.Namespace
 	}
 )
 
@@ -40,12 +41,17 @@ func NewNetworkPolicy(co *issues.Collector, lister NetworkPolicyLister) *Network
 
 // Sanitize cleanse the resource.
 func (n *NetworkPolicy) Sanitize(ctx context.Context) error {
+	blocked := n.dnsBlockedNamespaces()
 	for fqn, np := range n.ListNetworkPolicies() {
 		n.InitOutcome(fqn)
 		ctx = internal.WithFQN(ctx, fqn)
 
-		n.checkDeprecation(ctx, np)
+		checkDeprecation(ctx, n.Collector, "NetworkPolicy", np)
 		n.checkRefs(ctx, np)
+		n.checkOpenIngress(ctx, np)
+		if _, ok := blocked[np.Namespace]; ok && hasPolicyType(np, nv1.PolicyTypeEgress) {
+			n.AddCode(ctx, 1203)
+		}
 
 		if n.NoConcerns(fqn) && n.Config.ExcludeFQN(internal.MustExtractSectionGVR(ctx), fqn) {
 			n.ClearOutcome(fqn)
@@ -115,16 +121,41 @@ func (n *NetworkPolicy) checkRefs(ctx context.Context, np *nv1.NetworkPolicy) {
 	}
 }
 
-func (n *NetworkPolicy) checkDeprecation(ctx context.Context, np *nv1.NetworkPolicy) {
-	const current = "networking.k8s.io/v1"
-
-	rev, err := resourceRev(internal.MustExtractFQN(ctx), "NetworkPolicy", np.Annotations)
-	if err != nil {
-		if rev = revFromLink(np.SelfLink); rev == "" {
+// CheckOpenIngress checks for ingress rules letting in traffic from all namespaces.
+func (n *NetworkPolicy) checkOpenIngress(ctx context.Context, np *nv1.NetworkPolicy) {
+	if !hasPolicyType(np, nv1.PolicyTypeIngress) {
+		return
+	}
+	for _, ing := range np.Spec.Ingress {
+		if allowsAllIngress(ing) {
+			n.AddCode(ctx, 1202)
 			return
 		}
 	}
-	if rev != current {
-		n.AddCode(ctx, 403, "NetworkPolicy", rev, current)
+}
+
+// DNSBlockedNamespaces returns namespaces where egress policies do not let pods reach the cluster DNS.
+func (n *NetworkPolicy) dnsBlockedNamespaces() map[string]struct{} {
+	dns := dnsNamespaceFrom(n.ListNamespaces())
+	egress, allowed := make(map[string]struct{}), make(map[string]struct{})
+	for _, np := range n.ListNetworkPolicies() {
+		if !hasPolicyType(np, nv1.PolicyTypeEgress) {
+			continue
+		}
+		egress[np.Namespace] = struct{}{}
+		for _, eg := range np.Spec.Egress {
+			if allowsDNS(eg, dns) {
+				allowed[np.Namespace] = struct{}{}
+				break
+			}
+		}
 	}
+	blocked := make(map[string]struct{}, len(egress))
+	for ns := range egress {
+		if _, ok := allowed[ns]; !ok {
+			blocked[ns] = struct{}{}
+		}
+	}
+
+	return blocked
 }
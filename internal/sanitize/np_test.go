@@ -9,6 +9,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	nv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func TestNPSanitize(t *testing.T) {
@@ -24,14 +25,14 @@ func TestNPSanitize(t *testing.T) {
 		},
 		"deprecated": {
 			lister: makeNPLister(npOpts{
-				rev: "policy/v1beta1",
+				rev: "extensions/v1beta1",
 			}),
 			issues: issues.Issues{
 				issues.Issue{
 					GVR:     "networking.k8s.io/v1/networkpolicies",
 					Group:   "__root__",
 					Level:   2,
-					Message: `[POP-403] Deprecated NetworkPolicy API group "policy/v1beta1". Use "networking.k8s.io/v1" instead`},
+					Message: `[POP-403] Deprecated NetworkPolicy API group "extensions/v1beta1" removed in v1.16. Use "networking.k8s.io/v1/networkpolicies" instead`},
 			},
 		},
 		"noPodRef": {
@@ -54,6 +55,34 @@ func TestNPSanitize(t *testing.T) {
 				},
 			},
 		},
+		"openIngress": {
+			lister: makeNPLister(npOpts{
+				rev:  "networking.k8s.io/v1",
+				open: true,
+			}),
+			issues: issues.Issues{
+				issues.Issue{
+					GVR:     "networking.k8s.io/v1/networkpolicies",
+					Group:   "__root__",
+					Level:   2,
+					Message: "[POP-1202] Ingress rule allows all traffic from all namespaces",
+				},
+			},
+		},
+		"noDNS": {
+			lister: makeNPLister(npOpts{
+				rev:   "networking.k8s.io/v1",
+				noDNS: true,
+			}),
+			issues: issues.Issues{
+				issues.Issue{
+					GVR:     "networking.k8s.io/v1/networkpolicies",
+					Group:   "__root__",
+					Level:   2,
+					Message: "[POP-1203] Egress policy blocks DNS to kube-system",
+				},
+			},
+		},
 		"noNSRef": {
 			lister: makeNPLister(npOpts{
 				rev: "networking.k8s.io/v1",
@@ -92,8 +121,8 @@ func TestNPSanitize(t *testing.T) {
 
 type (
 	npOpts struct {
-		rev     string
-		pod, ns bool
+		rev                  string
+		pod, ns, open, noDNS bool
 	}
 
 	np struct {
@@ -115,6 +144,13 @@ func (n *np) ListNetworkPolicies() map[string]*nv1.NetworkPolicy {
 	}
 }
 
+func (n *np) ListNamespaces() map[string]*v1.Namespace {
+	return map[string]*v1.Namespace{
+		"ns1":         makeNS("ns1", true),
+		"kube-system": makeNS("kube-system", true),
+	}
+}
+
 func (n *np) ListNamespacesBySelector(sel *metav1.LabelSelector) map[string]*v1.Namespace {
 	if n.opts.ns {
 		return map[string]*v1.Namespace{}
@@ -146,7 +182,7 @@ func (n *np) GetPod(string, map[string]string) *v1.Pod {
 }
 
 func makeNP(n string, o npOpts) *nv1.NetworkPolicy {
-	return &nv1.NetworkPolicy{
+	np := nv1.NetworkPolicy{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      n,
 			Namespace: "default",
@@ -191,4 +227,17 @@ func makeNP(n string, o npOpts) *nv1.NetworkPolicy {
 			},
 		},
 	}
+	if o.open {
+		np.Spec.Ingress = append(np.Spec.Ingress, nv1.NetworkPolicyIngressRule{
+			From: []nv1.NetworkPolicyPeer{{NamespaceSelector: &metav1.LabelSelector{}}},
+		})
+	}
+	if !o.noDNS {
+		udp, dns := v1.ProtocolUDP, intstr.FromInt(53)
+		np.Spec.Egress = append(np.Spec.Egress, nv1.NetworkPolicyEgressRule{
+			Ports: []nv1.NetworkPolicyPort{{Protocol: &udp, Port: &dns}},
+		})
+	}
+
+	return &np
 }
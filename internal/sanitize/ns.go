@@ -2,19 +2,29 @@ package sanitize
 
 import (
 	"context"
+	"strings"
 
 	"github.com/derailed/popeye/internal"
 	"github.com/derailed/popeye/internal/issues"
 	v1 "k8s.io/api/core/v1"
+	nv1 "k8s.io/api/networking/v1"
 )
 
 type (
 	// NamespaceLister lists all namespaces.
 	NamespaceLister interface {
 		NamespaceRefs
+		NamespaceGuards
 		ListNamespaces() map[string]*v1.Namespace
 	}
 
+	// NamespaceGuards lists resources constraining namespaces.
+	NamespaceGuards interface {
+		LimitRangeLister
+		ResourceQuotaLister
+		NPLister
+	}
+
 	// NamespaceRefs tracks namespace references in the cluster.
 	NamespaceRefs interface {
 		ReferencedNamespaces(map[string]struct{})
@@ -40,12 +50,17 @@ func (n *Namespace) Sanitize(ctx context.Context) error {
 	available := n.ListNamespaces()
 	used := make(map[string]struct{}, len(available))
 	n.ReferencedNamespaces(used)
+	limited, quotas := n.guardedNamespaces()
 	for fqn, ns := range available {
 		n.InitOutcome(fqn)
 		ctx = internal.WithFQN(ctx, fqn)
 		if n.checkActive(ctx, ns.Status.Phase) {
 			if _, ok := used[fqn]; !ok {
 				n.AddCode(ctx, 400)
+			} else {
+				n.checkGuards(ctx, fqn, limited, quotas)
+				n.checkPodSecurity(ctx, ns)
+				n.checkNetworkPolicies(ctx, fqn)
 			}
 		}
 		if n.NoConcerns(fqn) && n.Config.ExcludeFQN(internal.MustExtractSectionGVR(ctx), fqn) {
@@ -65,6 +80,57 @@ func (n *Namespace) checkActive(ctx context.Context, p v1.NamespacePhase) bool {
 	return true
 }
 
+// CheckGuards checks if a namespace running workloads is constrained.
+func (n *Namespace) checkGuards(ctx context.Context, ns string, limited, quotas map[string]struct{}) {
+	if _, ok := limited[ns]; !ok {
+		n.AddCode(ctx, 801)
+	}
+	if _, ok := quotas[ns]; !ok {
+		n.AddCode(ctx, 802)
+	}
+}
+
+// CheckPodSecurity checks if a namespace running workloads sets a Pod Security Standard.
+func (n *Namespace) checkPodSecurity(ctx context.Context, ns *v1.Namespace) {
+	if !hasPSSLabels(ns) {
+		n.AddCode(ctx, 803)
+	}
+}
+
+// CheckNetworkPolicies checks if a namespace running workloads is isolated by default.
+func (n *Namespace) checkNetworkPolicies(ctx context.Context, ns string) {
+	nps := namespacePolicies(n.ListNetworkPolicies(), ns)
+	if len(nps) == 0 {
+		n.AddCode(ctx, 804)
+		return
+	}
+	for _, t := range []nv1.PolicyType{nv1.PolicyTypeIngress, nv1.PolicyTypeEgress} {
+		var deny bool
+		for _, np := range nps {
+			if isDefaultDeny(np, t) {
+				deny = true
+				break
+			}
+		}
+		if !deny {
+			n.AddCode(ctx, 805, strings.ToLower(string(t)))
+		}
+	}
+}
+
+// GuardedNamespaces returns the namespaces with a LimitRange or a ResourceQuota.
+func (n *Namespace) guardedNamespaces() (map[string]struct{}, map[string]struct{}) {
+	limited, quotas := make(map[string]struct{}), make(map[string]struct{})
+	for _, lr := range n.ListLimitRanges() {
+		limited[lr.Namespace] = struct{}{}
+	}
+	for _, q := range n.ListResourceQuotas() {
+		quotas[q.Namespace] = struct{}{}
+	}
+
+	return limited, quotas
+}
+
 // ----------------------------------------------------------------------------
 // Helpers...
 
@@ -3,9 +3,11 @@ package sanitize
 import (
 	"testing"
 
+	"github.com/derailed/popeye/internal/authz"
 	"github.com/derailed/popeye/internal/issues"
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
+	nv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -46,6 +48,54 @@ func TestNSSanitizer(t *testing.T) {
 			}),
 			map[string]int{"ns1": 0, "ns2": 0, "ns3": 1},
 		},
+		"unguarded": {
+			makeNsLister(nsOpts{
+				active: true,
+				used: []string{
+					"ns1",
+					"ns2",
+					"ns3",
+				},
+				unlimited: []string{"ns1", "ns2"},
+				unquoted:  []string{"ns2"},
+			}),
+			map[string]int{"ns1": 1, "ns2": 2, "ns3": 0},
+		},
+		"unguardedUnused": {
+			makeNsLister(nsOpts{
+				active: true,
+				used: []string{
+					"ns1",
+					"ns2",
+				},
+				unlimited: []string{"ns3"},
+				unquoted:  []string{"ns3"},
+			}),
+			map[string]int{"ns1": 0, "ns2": 0, "ns3": 1},
+		},
+		"unlabeled": {
+			makeNsLister(nsOpts{
+				active: true,
+				used: []string{
+					"ns1",
+					"ns2",
+				},
+				unlabeled: []string{"ns1", "ns3"},
+			}),
+			map[string]int{"ns1": 1, "ns2": 0, "ns3": 1},
+		},
+		"unisolated": {
+			makeNsLister(nsOpts{
+				active: true,
+				used: []string{
+					"ns1",
+					"ns2",
+				},
+				unisolated: []string{"ns1", "ns3"},
+				undenied:   []string{"ns2"},
+			}),
+			map[string]int{"ns1": 1, "ns2": 2, "ns3": 1},
+		},
 	}
 
 	ctx := makeContext("v1/namespaces", "ns")
@@ -67,8 +117,12 @@ func TestNSSanitizer(t *testing.T) {
 
 type (
 	nsOpts struct {
-		active bool
-		used   []string
+		active              bool
+		used                []string
+		unlimited, unquoted []string
+		unlabeled           []string
+		unisolated          []string
+		undenied            []string
 	}
 
 	ns struct {
@@ -89,11 +143,65 @@ func (n *ns) ReferencedNamespaces(nn map[string]struct{}) {
 }
 
 func (n *ns) ListNamespaces() map[string]*v1.Namespace {
-	return map[string]*v1.Namespace{
+	nss := map[string]*v1.Namespace{
 		"ns1": makeNS("ns1", true),
 		"ns2": makeNS("ns2", n.opts.active),
 		"ns3": makeNS("ns3", true),
 	}
+	for k, ns := range nss {
+		if !authz.In(n.opts.unlabeled, k) {
+			ns.Labels = map[string]string{pssLabelPrefix + "enforce": "baseline"}
+		}
+	}
+
+	return nss
+}
+
+func (n *ns) ListLimitRanges() map[string]*v1.LimitRange {
+	mm := make(map[string]*v1.LimitRange)
+	for _, ns := range n.guarded(n.opts.unlimited) {
+		mm[ns+"/lr"] = &v1.LimitRange{ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "lr"}}
+	}
+
+	return mm
+}
+
+func (n *ns) ListResourceQuotas() map[string]*v1.ResourceQuota {
+	mm := make(map[string]*v1.ResourceQuota)
+	for _, ns := range n.guarded(n.opts.unquoted) {
+		mm[ns+"/quota"] = &v1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "quota"}}
+	}
+
+	return mm
+}
+
+func (n *ns) ListNetworkPolicies() map[string]*nv1.NetworkPolicy {
+	mm := make(map[string]*nv1.NetworkPolicy)
+	for _, ns := range n.guarded(n.opts.unisolated) {
+		np := nv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "deny-all"},
+			Spec: nv1.NetworkPolicySpec{
+				PolicyTypes: []nv1.PolicyType{nv1.PolicyTypeIngress, nv1.PolicyTypeEgress},
+			},
+		}
+		if authz.In(n.opts.undenied, ns) {
+			np.Spec.PodSelector = metav1.LabelSelector{MatchLabels: map[string]string{"app": "fred"}}
+		}
+		mm[ns+"/deny-all"] = &np
+	}
+
+	return mm
+}
+
+func (n *ns) guarded(skip []string) []string {
+	var nn []string
+	for ns := range n.ListNamespaces() {
+		if !authz.In(skip, ns) {
+			nn = append(nn, ns)
+		}
+	}
+
+	return nn
 }
 
 func makeNS(n string, active bool) *v1.Namespace {
@@ -2,12 +2,14 @@ package sanitize
 
 import (
 	"context"
+	"strings"
 
 	"github.com/derailed/popeye/internal"
 	"github.com/derailed/popeye/internal/issues"
 	"github.com/rs/zerolog/log"
 	polv1beta1 "k8s.io/api/policy/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 type (
@@ -39,7 +41,8 @@ func (p *PodDisruptionBudget) Sanitize(ctx context.Context) error {
 		ctx = internal.WithFQN(ctx, fqn)
 
 		p.checkInUse(ctx, pdb)
-		p.checkDeprecation(ctx, pdb)
+		p.checkEvictions(ctx, pdb)
+		checkDeprecation(ctx, p.Collector, "PodDisruptionBudget", pdb)
 
 		if p.NoConcerns(fqn) && p.Config.ExcludeFQN(internal.MustExtractSectionGVR(ctx), fqn) {
 			p.ClearOutcome(fqn)
@@ -49,22 +52,6 @@ func (p *PodDisruptionBudget) Sanitize(ctx context.Context) error {
 	return nil
 }
 
-func (p *PodDisruptionBudget) checkDeprecation(ctx context.Context, pdb *polv1beta1.PodDisruptionBudget) {
-	const current = "policy/v1"
-
-	fqn := internal.MustExtractFQN(ctx)
-	rev, err := resourceRev(fqn, "PodDisruptionBudget", pdb.Annotations)
-	if err != nil {
-		rev = revFromLink(pdb.SelfLink)
-		if rev == "" {
-			return
-		}
-	}
-	if rev != current {
-		p.AddCode(ctx, 403, "PodDisruptionBudget", rev, current)
-	}
-}
-
 func (p *PodDisruptionBudget) checkInUse(ctx context.Context, pdb *polv1beta1.PodDisruptionBudget) {
 	m, err := metav1.LabelSelectorAsMap(pdb.Spec.Selector)
 	if err != nil {
@@ -76,3 +63,36 @@ func (p *PodDisruptionBudget) checkInUse(ctx context.Context, pdb *polv1beta1.Po
 		return
 	}
 }
+
+// CheckEvictions checks if a pdb makes voluntary pod evictions impossible.
+func (p *PodDisruptionBudget) checkEvictions(ctx context.Context, pdb *polv1beta1.PodDisruptionBudget) {
+	expected := int(pdb.Status.ExpectedPods)
+	if max := pdb.Spec.MaxUnavailable; max != nil && isZeroIntOrPercent(*max) {
+		p.AddCode(ctx, 902)
+		return
+	}
+	if min := pdb.Spec.MinAvailable; min != nil {
+		if min.Type == intstr.String && min.StrVal == "100%" {
+			p.AddCode(ctx, 903, min.String(), expected)
+			return
+		}
+		if v, err := intstr.GetScaledValueFromIntOrPercent(min, expected, true); err == nil && expected > 0 && v >= expected {
+			p.AddCode(ctx, 903, min.String(), expected)
+			return
+		}
+	}
+	if expected > 0 && pdb.Status.DisruptionsAllowed == 0 && pdb.Status.CurrentHealthy < pdb.Status.ExpectedPods {
+		p.AddCode(ctx, 904, pdb.Status.CurrentHealthy, pdb.Status.ExpectedPods)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func isZeroIntOrPercent(v intstr.IntOrString) bool {
+	if v.Type == intstr.Int {
+		return v.IntVal == 0
+	}
+
+	return strings.TrimSuffix(v.StrVal, "%") == "0"
+}
@@ -31,6 +31,50 @@ func TestPDBSanitize(t *testing.T) {
 					Message: "[POP-900] Used? No pods match selector"},
 			},
 		},
+		"zeroMaxUnavailable": {
+			lister: makePDBLister(pdbOpts{max: intPtrStr("0%")}),
+			issues: issues.Issues{
+				issues.Issue{
+					GVR:     "policy/v1beta1/poddisruptionbudgets",
+					Group:   "__root__",
+					Level:   3,
+					Message: "[POP-902] MaxUnavailable is 0. Voluntary pod evictions are always blocked"},
+			},
+		},
+		"allMinAvailable": {
+			lister: makePDBLister(pdbOpts{min: intPtrStr("3"), expected: 3, healthy: 3}),
+			issues: issues.Issues{
+				issues.Issue{
+					GVR:     "policy/v1beta1/poddisruptionbudgets",
+					Group:   "__root__",
+					Level:   3,
+					Message: "[POP-903] MinAvailable (3) covers all 3 expected pods. Voluntary pod evictions are always blocked"},
+			},
+		},
+		"allMinAvailablePerc": {
+			lister: makePDBLister(pdbOpts{min: intPtrStr("100%")}),
+			issues: issues.Issues{
+				issues.Issue{
+					GVR:     "policy/v1beta1/poddisruptionbudgets",
+					Group:   "__root__",
+					Level:   3,
+					Message: "[POP-903] MinAvailable (100%) covers all 0 expected pods. Voluntary pod evictions are always blocked"},
+			},
+		},
+		"unhealthy": {
+			lister: makePDBLister(pdbOpts{min: intPtrStr("2"), expected: 3, healthy: 2}),
+			issues: issues.Issues{
+				issues.Issue{
+					GVR:     "policy/v1beta1/poddisruptionbudgets",
+					Group:   "__root__",
+					Level:   2,
+					Message: "[POP-904] No disruptions allowed with 2/3 healthy pods. Voluntary pod evictions are blocked"},
+			},
+		},
+		"disruptable": {
+			lister: makePDBLister(pdbOpts{min: intPtrStr("2"), expected: 3, healthy: 3, allowed: 1}),
+			issues: issues.Issues{},
+		},
 	}
 
 	ctx := makeContext("policy/v1beta1/poddisruptionbudgets", "pdb")
@@ -49,7 +93,9 @@ func TestPDBSanitize(t *testing.T) {
 
 type (
 	pdbOpts struct {
-		pod bool
+		pod                        bool
+		min, max                   *intstr.IntOrString
+		expected, healthy, allowed int32
 	}
 
 	pdb struct {
@@ -67,7 +113,7 @@ func makePDBLister(opts pdbOpts) *pdb {
 
 func (r *pdb) ListPodDisruptionBudgets() map[string]*polv1beta1.PodDisruptionBudget {
 	return map[string]*polv1beta1.PodDisruptionBudget{
-		cache.FQN("default", r.name): makePDB(r.name),
+		cache.FQN("default", r.name): makePDB(r.name, r.opts),
 	}
 }
 
@@ -84,9 +130,9 @@ func (r *pdb) GetPod(ns string, sel map[string]string) *v1.Pod {
 	return makePod("p1")
 }
 
-func makePDB(n string) *polv1beta1.PodDisruptionBudget {
+func makePDB(n string, o pdbOpts) *polv1beta1.PodDisruptionBudget {
 	min, max := intstr.FromInt(1), intstr.FromInt(1)
-	return &polv1beta1.PodDisruptionBudget{
+	pdb := polv1beta1.PodDisruptionBudget{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      n,
 			Namespace: "default",
@@ -97,4 +143,22 @@ func makePDB(n string) *polv1beta1.PodDisruptionBudget {
 			MaxUnavailable: &max,
 		},
 	}
+	if o.min != nil {
+		pdb.Spec.MinAvailable, pdb.Spec.MaxUnavailable = o.min, nil
+	}
+	if o.max != nil {
+		pdb.Spec.MinAvailable, pdb.Spec.MaxUnavailable = nil, o.max
+	}
+	pdb.Status = polv1beta1.PodDisruptionBudgetStatus{
+		ExpectedPods:       o.expected,
+		CurrentHealthy:     o.healthy,
+		DisruptionsAllowed: o.allowed,
+	}
+
+	return &pdb
+}
+
+func intPtrStr(s string) *intstr.IntOrString {
+	v := intstr.Parse(s)
+	return &v
 }
@@ -0,0 +1,108 @@
+package sanitize
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ZoneLabel tracks the node zone label.
+	zoneLabel = "topology.kubernetes.io/zone"
+
+	// LegacyZoneLabel tracks the deprecated node zone label.
+	legacyZoneLabel = "failure-domain.beta.kubernetes.io/zone"
+)
+
+// PlacementLister lists workload pods and the nodes they run on.
+type PlacementLister interface {
+	PodSelectorLister
+	ListNodes() map[string]*v1.Node
+}
+
+// CheckPlacement checks if a multi-replica workload ready pods would survive a node or zone failure.
+func checkPlacement(ctx context.Context, c Collector, l PlacementLister, ns string, sel *metav1.LabelSelector, replicas *int32, spec v1.PodSpec) {
+	if replicas == nil || *replicas < 2 {
+		return
+	}
+	if !isSpread(spec) {
+		c.AddCode(ctx, 510, *replicas)
+	}
+
+	var ready []*v1.Pod
+	for _, po := range l.ListPodsBySelector(ns, sel) {
+		if po.Spec.NodeName != "" && isPodReady(po) {
+			ready = append(ready, po)
+		}
+	}
+	if len(ready) < 2 {
+		return
+	}
+
+	nodes, zones := make(map[string]struct{}), make(map[string]struct{})
+	nn := l.ListNodes()
+	for _, po := range ready {
+		nodes[po.Spec.NodeName] = struct{}{}
+		if zone := nodeZone(nn[po.Spec.NodeName]); zone != "" {
+			zones[zone] = struct{}{}
+		}
+	}
+	if len(nodes) == 1 {
+		c.AddCode(ctx, 508, ready[0].Spec.NodeName, len(ready), *replicas)
+		return
+	}
+	if len(zones) == 1 && zonedPods(nn, ready) {
+		for zone := range zones {
+			c.AddCode(ctx, 509, zone, len(ready), *replicas)
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+// IsSpread checks if a pod template spreads its replicas across topology domains.
+func isSpread(spec v1.PodSpec) bool {
+	if len(spec.TopologySpreadConstraints) > 0 {
+		return true
+	}
+	if spec.Affinity == nil || spec.Affinity.PodAntiAffinity == nil {
+		return false
+	}
+	aa := spec.Affinity.PodAntiAffinity
+
+	return len(aa.RequiredDuringSchedulingIgnoredDuringExecution) > 0 || len(aa.PreferredDuringSchedulingIgnoredDuringExecution) > 0
+}
+
+func isPodReady(po *v1.Pod) bool {
+	for _, c := range po.Status.Conditions {
+		if c.Type == v1.PodReady {
+			return c.Status == v1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+func nodeZone(no *v1.Node) string {
+	if no == nil {
+		return ""
+	}
+	if zone, ok := no.Labels[zoneLabel]; ok {
+		return zone
+	}
+
+	return no.Labels[legacyZoneLabel]
+}
+
+// ZonedPods checks if all pods run on nodes with a known zone.
+func zonedPods(nn map[string]*v1.Node, pp []*v1.Pod) bool {
+	for _, po := range pp {
+		if nodeZone(nn[po.Spec.NodeName]) == "" {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,139 @@
+package sanitize
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/derailed/popeye/internal"
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/pkg/config"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCheckPlacement(t *testing.T) {
+	spread := v1.PodSpec{
+		Affinity: &v1.Affinity{
+			PodAntiAffinity: &v1.PodAntiAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []v1.WeightedPodAffinityTerm{
+					{Weight: 100, PodAffinityTerm: v1.PodAffinityTerm{TopologyKey: "kubernetes.io/hostname"}},
+				},
+			},
+		},
+	}
+	uu := map[string]struct {
+		replicas int32
+		spec     v1.PodSpec
+		pods     []placedPod
+		issues   issues.Issues
+	}{
+		"single": {
+			replicas: 1,
+			pods:     []placedPod{{node: "n1", ready: true}},
+			issues:   issues.Issues{},
+		},
+		"spread": {
+			replicas: 3,
+			spec:     spread,
+			pods:     []placedPod{{node: "n1", ready: true}, {node: "n2", ready: true}, {node: "n3", ready: true}},
+			issues:   issues.Issues{},
+		},
+		"noSpread": {
+			replicas: 2,
+			pods:     []placedPod{{node: "n1", ready: true}, {node: "n3", ready: true}},
+			issues: issues.Issues{
+				issues.New(client.NewGVR("apps/v1/deployments"), issues.Root, config.InfoLevel, "[POP-510] No pod anti-affinity or topology spread constraints defined for 2 replicas"),
+			},
+		},
+		"sameNode": {
+			replicas: 3,
+			spec:     spread,
+			pods:     []placedPod{{node: "n1", ready: true}, {node: "n1", ready: true}, {node: "n1", ready: true}},
+			issues: issues.Issues{
+				issues.New(client.NewGVR("apps/v1/deployments"), issues.Root, config.WarnLevel, "[POP-508] Ready replicas all run on node n1. A node failure would take out 3/3 replicas"),
+			},
+		},
+		"sameZone": {
+			replicas: 3,
+			spec:     spread,
+			pods:     []placedPod{{node: "n1", ready: true}, {node: "n2", ready: true}, {node: "n3"}},
+			issues: issues.Issues{
+				issues.New(client.NewGVR("apps/v1/deployments"), issues.Root, config.WarnLevel, "[POP-509] Ready replicas all run in zone z1. A zone failure would take out 2/3 replicas"),
+			},
+		},
+		"notReady": {
+			replicas: 3,
+			spec:     spread,
+			pods:     []placedPod{{node: "n1", ready: true}, {node: "n1"}, {node: "n1"}},
+			issues:   issues.Issues{},
+		},
+		"unzoned": {
+			replicas: 2,
+			spec:     spread,
+			pods:     []placedPod{{node: "n1", ready: true}, {node: "n4", ready: true}},
+			issues:   issues.Issues{},
+		},
+	}
+
+	ctx := makeContext("apps/v1/deployments", "deploy")
+	ctx = internal.WithFQN(ctx, "default/d1")
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			co := issues.NewCollector(loadCodes(t), makeConfig(t))
+			co.InitOutcome("default/d1")
+
+			checkPlacement(ctx, co, makePlacementLister(u.pods), "default", nil, &u.replicas, u.spec)
+			assert.Equal(t, u.issues, co.Outcome()["default/d1"])
+		})
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+type (
+	placedPod struct {
+		node  string
+		ready bool
+	}
+
+	placement struct {
+		pods []placedPod
+	}
+)
+
+func makePlacementLister(pp []placedPod) *placement {
+	return &placement{pods: pp}
+}
+
+func (p *placement) ListPodsBySelector(string, *metav1.LabelSelector) map[string]*v1.Pod {
+	mm := make(map[string]*v1.Pod, len(p.pods))
+	for i, pp := range p.pods {
+		po := makePod(fmt.Sprintf("p%d", i+1))
+		po.Spec.NodeName = pp.node
+		status := v1.ConditionFalse
+		if pp.ready {
+			status = v1.ConditionTrue
+		}
+		po.Status.Conditions = []v1.PodCondition{{Type: v1.PodReady, Status: status}}
+		mm[client.FQN(po.Namespace, po.Name)] = po
+	}
+
+	return mm
+}
+
+func (p *placement) ListNodes() map[string]*v1.Node {
+	return map[string]*v1.Node{
+		"n1": makeZonedNode("n1", map[string]string{zoneLabel: "z1"}),
+		"n2": makeZonedNode("n2", map[string]string{legacyZoneLabel: "z1"}),
+		"n3": makeZonedNode("n3", map[string]string{zoneLabel: "z2"}),
+		"n4": makeZonedNode("n4", nil),
+	}
+}
+
+func makeZonedNode(n string, ll map[string]string) *v1.Node {
+	return &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: n, Labels: ll}}
+}
@@ -2,6 +2,7 @@ package sanitize
 
 import (
 	"context"
+	"time"
 
 	"github.com/derailed/popeye/internal"
 	"github.com/derailed/popeye/internal/cache"
@@ -10,6 +11,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	polv1beta1 "k8s.io/api/policy/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
 	mv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 )
 
@@ -20,6 +22,9 @@ const (
 	SecNonRootUnset = 0
 	// SecNonRootSet denotes non root user
 	SecNonRootSet = 1
+
+	// EvictedReason tracks the status reason of an evicted pod.
+	evictedReason = "Evicted"
 )
 
 // NonRootUser identifies if a security context for nonRootUser is set/unset or undefined.
@@ -51,6 +56,8 @@ type (
 		PodLister
 		PdbLister
 		ConfigLister
+		PodSecurityLister
+		NPLister
 		ListServiceAccounts() map[string]*v1.ServiceAccount
 	}
 
@@ -72,14 +79,16 @@ func NewPod(co *issues.Collector, lister PodMXLister) *Pod {
 
 // Sanitize cleanse the resource..
 func (p *Pod) Sanitize(ctx context.Context) error {
-	mx := p.ListPodsMetrics()
+	mx, now := p.ListPodsMetrics(), time.Now()
 	for fqn, po := range p.ListPods() {
 		p.InitOutcome(fqn)
 		ctx = internal.WithFQN(ctx, fqn)
 
 		p.checkStatus(ctx, po)
+		p.checkStale(ctx, now, po)
 		p.checkContainerStatus(ctx, po)
 		p.checkContainers(ctx, fqn, po)
+		p.checkVulnerabilities(ctx, fqn, po)
 
 		p.checkOwnedByAnything(ctx, po.OwnerReferences)
 
@@ -87,6 +96,10 @@ func (p *Pod) Sanitize(ctx context.Context) error {
 			p.checkPdb(ctx, po.ObjectMeta.Labels)
 		}
 		p.checkSecure(ctx, fqn, po.Spec)
+		tpl := podTemplate(po)
+		checkHardening(ctx, p.Collector, tpl)
+		checkPodSecurity(ctx, p.Collector, p, po.Namespace, tpl)
+		p.checkNetworkPolicy(ctx, po)
 		pmx, cmx := mx[fqn], client.ContainerMetrics{}
 		containerMetrics(pmx, cmx)
 		p.checkUtilization(ctx, po, cmx)
@@ -98,6 +111,22 @@ func (p *Pod) Sanitize(ctx context.Context) error {
 	return nil
 }
 
+// SanitizeSpec cleanse a pod prior to admission. Only checks that do not
+// depend on the pod state are evaluated.
+func (p *Pod) SanitizeSpec(ctx context.Context, fqn string, po *v1.Pod) {
+	p.InitOutcome(fqn)
+	ctx = internal.WithFQN(ctx, fqn)
+
+	p.checkContainers(ctx, fqn, po)
+	p.checkSecure(ctx, fqn, po.Spec)
+	checkHardening(ctx, p.Collector, podTemplate(po))
+}
+
+// PodTemplate returns the pod template a pod was created from.
+func podTemplate(po *v1.Pod) v1.PodTemplateSpec {
+	return v1.PodTemplateSpec{ObjectMeta: po.ObjectMeta, Spec: po.Spec}
+}
+
 func ownedByDaemonSet(po *v1.Pod) bool {
 	for _, o := range po.OwnerReferences {
 		if o.Kind == "DaemonSet" {
@@ -126,6 +155,17 @@ func (p *Pod) checkOwnedByAnything(ctx context.Context, ownerRefs []metav1.Owner
 	}
 }
 
+// CheckNetworkPolicy checks if a pod is selected by a NetworkPolicy when its namespace defines some.
+func (p *Pod) checkNetworkPolicy(ctx context.Context, po *v1.Pod) {
+	nps := p.ListNetworkPolicies()
+	if len(namespacePolicies(nps, po.Namespace)) == 0 {
+		return
+	}
+	if !isSelectedByPolicy(nps, po) {
+		p.AddCode(ctx, 209)
+	}
+}
+
 func (p *Pod) checkPdb(ctx context.Context, labels map[string]string) {
 	if p.ForLabels(labels) == nil {
 		p.AddCode(ctx, 206)
@@ -259,9 +299,43 @@ func (p *Pod) checkStatus(ctx context.Context, po *v1.Pod) {
 	}
 }
 
+// CheckStale checks if a completed or evicted pod lingers past the configured number of days.
+func (p *Pod) checkStale(ctx context.Context, now time.Time, po *v1.Pod) {
+	var kind string
+	// nolint:exhaustive
+	switch po.Status.Phase {
+	case v1.PodSucceeded:
+		kind = "Completed"
+	case v1.PodFailed:
+		if po.Status.Reason != evictedReason {
+			return
+		}
+		kind = evictedReason
+	default:
+		return
+	}
+	stale := time.Duration(p.Config.StalePodDays()) * 24 * time.Hour
+	if age := now.Sub(terminatedAt(po)); age > stale {
+		p.AddCode(ctx, 210, kind, duration.HumanDuration(age))
+	}
+}
+
 // ----------------------------------------------------------------------------
 // Helpers...
 
+// TerminatedAt returns the time a pod last container terminated, defaulting to
+// the pod creation time.
+func terminatedAt(po *v1.Pod) time.Time {
+	last := po.CreationTimestamp.Time
+	for _, cs := range po.Status.ContainerStatuses {
+		if t := cs.State.Terminated; t != nil && t.FinishedAt.Time.After(last) {
+			last = t.FinishedAt.Time
+		}
+	}
+
+	return last
+}
+
 func containerMetrics(pmx *mv1beta1.PodMetrics, mx client.ContainerMetrics) {
 	// No metrics -> Bail!
 	if pmx == nil {
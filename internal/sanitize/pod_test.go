@@ -2,12 +2,15 @@ package sanitize
 
 import (
 	"testing"
+	"time"
 
 	"github.com/derailed/popeye/internal"
+	"github.com/derailed/popeye/internal/client"
 	"github.com/derailed/popeye/internal/issues"
 	"github.com/derailed/popeye/pkg/config"
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
+	nv1 "k8s.io/api/networking/v1"
 	polv1beta1 "k8s.io/api/policy/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
@@ -102,6 +105,60 @@ func TestPodSanitize(t *testing.T) {
 			}),
 			0,
 		},
+		"selected": {
+			makePodLister(podOpts{
+				pods: map[string]*v1.Pod{
+					"default/p1": makeFullPod(podOpts{
+						serviceAcct: "fred",
+						certs:       false,
+						coOpts: coOpts{
+							rcpu: "100m",
+							rmem: "20Mi",
+							lcpu: "100m",
+							lmem: "200Mi",
+						},
+						csOpts: csOpts{
+							ready:    true,
+							restarts: 0,
+							state:    running,
+						},
+						phase:      v1.PodRunning,
+						controlled: true,
+					}),
+				},
+				nps: map[string]*nv1.NetworkPolicy{
+					"default/np": makePodNP(nil),
+				},
+			}),
+			0,
+		},
+		"unselected": {
+			makePodLister(podOpts{
+				pods: map[string]*v1.Pod{
+					"default/p1": makeFullPod(podOpts{
+						serviceAcct: "fred",
+						certs:       false,
+						coOpts: coOpts{
+							rcpu: "100m",
+							rmem: "20Mi",
+							lcpu: "100m",
+							lmem: "200Mi",
+						},
+						csOpts: csOpts{
+							ready:    true,
+							restarts: 0,
+							state:    running,
+						},
+						phase:      v1.PodRunning,
+						controlled: true,
+					}),
+				},
+				nps: map[string]*nv1.NetworkPolicy{
+					"default/np": makePodNP(map[string]string{"app": "fred"}),
+				},
+			}),
+			1,
+		},
 		"unhappy": {
 			makePodLister(podOpts{
 				pods: map[string]*v1.Pod{
@@ -162,6 +219,71 @@ func TestPodSanitize(t *testing.T) {
 	}
 }
 
+func TestPodCheckStale(t *testing.T) {
+	now := time.Now()
+	uu := map[string]struct {
+		phase    v1.PodPhase
+		reason   string
+		created  time.Time
+		finished time.Time
+		issues   issues.Issues
+	}{
+		"running": {
+			phase:   v1.PodRunning,
+			created: now.Add(-30 * 24 * time.Hour),
+			issues:  issues.Issues{},
+		},
+		"completedRecently": {
+			phase:    v1.PodSucceeded,
+			created:  now.Add(-30 * 24 * time.Hour),
+			finished: now.Add(-24 * time.Hour),
+			issues:   issues.Issues{},
+		},
+		"completed": {
+			phase:    v1.PodSucceeded,
+			created:  now.Add(-30 * 24 * time.Hour),
+			finished: now.Add(-10 * 24 * time.Hour),
+			issues: issues.Issues{
+				issues.New(client.NewGVR("v1/pods"), issues.Root, config.InfoLevel, "[POP-210] Used? Completed pod lingering for 10d"),
+			},
+		},
+		"evicted": {
+			phase:   v1.PodFailed,
+			reason:  "Evicted",
+			created: now.Add(-8 * 24 * time.Hour),
+			issues: issues.Issues{
+				issues.New(client.NewGVR("v1/pods"), issues.Root, config.InfoLevel, "[POP-210] Used? Evicted pod lingering for 8d"),
+			},
+		},
+		"failed": {
+			phase:   v1.PodFailed,
+			created: now.Add(-30 * 24 * time.Hour),
+			issues:  issues.Issues{},
+		},
+	}
+
+	ctx := makeContext("v1/pods", "po")
+	ctx = internal.WithFQN(ctx, "default/p1")
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			p := NewPod(issues.NewCollector(loadCodes(t), makeConfig(t)), nil)
+			p.InitOutcome("default/p1")
+
+			po := makePod("p1")
+			po.CreationTimestamp = metav1.NewTime(u.created)
+			po.Status.Phase, po.Status.Reason = u.phase, u.reason
+			if !u.finished.IsZero() {
+				po.Status.ContainerStatuses = []v1.ContainerStatus{
+					{Name: "c1", State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{FinishedAt: metav1.NewTime(u.finished)}}},
+				}
+			}
+			p.checkStale(ctx, now, po)
+			assert.Equal(t, u.issues, p.Outcome()["default/p1"])
+		})
+	}
+}
+
 // ----------------------------------------------------------------------------
 // Helpers...
 
@@ -171,6 +293,7 @@ type (
 		csOpts
 		phase       v1.PodPhase
 		pods        map[string]*v1.Pod
+		nps         map[string]*nv1.NetworkPolicy
 		serviceAcct string
 		certs       bool
 		controlled  bool
@@ -191,10 +314,18 @@ func (p *pod) ListPods() map[string]*v1.Pod {
 	return p.opts.pods
 }
 
+func (p *pod) ListNamespaces() map[string]*v1.Namespace {
+	return map[string]*v1.Namespace{}
+}
+
 func (p *pod) ListServiceAccounts() map[string]*v1.ServiceAccount {
 	return make(map[string]*v1.ServiceAccount)
 }
 
+func (p *pod) ListNetworkPolicies() map[string]*nv1.NetworkPolicy {
+	return p.opts.nps
+}
+
 func (p *pod) GetPod(string, map[string]string) *v1.Pod {
 	return nil
 }
@@ -252,6 +383,18 @@ func makePod(n string) *v1.Pod {
 	return po
 }
 
+func makePodNP(sel map[string]string) *nv1.NetworkPolicy {
+	return &nv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "np",
+			Namespace: "default",
+		},
+		Spec: nv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: sel},
+		},
+	}
+}
+
 func makeMxPod(cpu, mem string) *v1beta1.PodMetrics {
 	return &v1beta1.PodMetrics{
 		ObjectMeta: metav1.ObjectMeta{
@@ -293,6 +436,7 @@ func makeFullPod(opts podOpts) *v1.Pod {
 		po.Spec.ServiceAccountName = opts.serviceAcct
 	}
 	po.Spec.AutomountServiceAccountToken = &opts.certs
+	hardenPod(po)
 
 	if opts.controlled {
 		truthful := true
@@ -316,6 +460,32 @@ func makeFullPod(opts podOpts) *v1.Pod {
 	return po
 }
 
+// HardenPod locks down the pod security contexts.
+func hardenPod(po *v1.Pod) {
+	tpl := podTemplate(po)
+	hardenTemplate(&tpl)
+	po.ObjectMeta, po.Spec = tpl.ObjectMeta, tpl.Spec
+}
+
+func hardenTemplate(po *v1.PodTemplateSpec) {
+	nonRoot, escalate, readOnly := true, false, true
+	po.Spec.SecurityContext = &v1.PodSecurityContext{
+		RunAsNonRoot:   &nonRoot,
+		SeccompProfile: &v1.SeccompProfile{Type: v1.SeccompProfileTypeRuntimeDefault},
+	}
+	po.Annotations = make(map[string]string)
+	for _, cc := range [][]v1.Container{po.Spec.InitContainers, po.Spec.Containers} {
+		for i := range cc {
+			cc[i].SecurityContext = &v1.SecurityContext{
+				AllowPrivilegeEscalation: &escalate,
+				ReadOnlyRootFilesystem:   &readOnly,
+				Capabilities:             &v1.Capabilities{Drop: []v1.Capability{"ALL"}},
+			}
+			po.Annotations[appArmorAnnotationPrefix+cc[i].Name] = "runtime/default"
+		}
+	}
+}
+
 const (
 	running int = iota
 	waiting
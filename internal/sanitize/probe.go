@@ -0,0 +1,109 @@
+package sanitize
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// MinStartupBudget tracks the minimum seconds a liveness probe should allow for startup.
+	minStartupBudget = 30
+
+	// Probe defaults as set by the api server.
+	defaultProbePeriod    = 10
+	defaultProbeThreshold = 3
+	defaultProbeTimeout   = 1
+)
+
+// CheckProbesQuality checks probes for common misconfigurations.
+func (c *Container) checkProbesQuality(ctx context.Context, co v1.Container) {
+	live, ready := co.LivenessProbe, co.ReadinessProbe
+	if live != nil && ready != nil && hasHandler(live) && equality.Semantic.DeepEqual(live, ready) {
+		c.AddSubCode(ctx, 124)
+	}
+	if live != nil && co.StartupProbe == nil {
+		if budget := startupBudget(live); budget < minStartupBudget {
+			c.AddSubCode(ctx, 125, budget)
+		}
+	}
+
+	c.checkProbe(ctx, co, live, "Liveness")
+	c.checkProbe(ctx, co, ready, "Readiness")
+	c.checkProbe(ctx, co, co.StartupProbe, "Startup")
+}
+
+func (c *Container) checkProbe(ctx context.Context, co v1.Container, p *v1.Probe, kind string) {
+	if p == nil {
+		return
+	}
+	if p.Exec != nil && probeTimeout(p) <= defaultProbeTimeout {
+		c.AddSubCode(ctx, 126, kind, probeTimeout(p))
+	}
+	if p.TCPSocket != nil && p.TCPSocket.Port.Type == intstr.Int {
+		c.AddSubCode(ctx, 128, kind)
+	}
+	for _, port := range probePorts(p) {
+		if !declaresPort(co, port) {
+			c.AddSubCode(ctx, 127, kind, port.String())
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func hasHandler(p *v1.Probe) bool {
+	return p.Exec != nil || p.HTTPGet != nil || p.TCPSocket != nil || p.GRPC != nil
+}
+
+// StartupBudget returns the seconds a liveness probe allows before restarting a starting container.
+func startupBudget(p *v1.Probe) int32 {
+	period, threshold := p.PeriodSeconds, p.FailureThreshold
+	if period == 0 {
+		period = defaultProbePeriod
+	}
+	if threshold == 0 {
+		threshold = defaultProbeThreshold
+	}
+
+	return p.InitialDelaySeconds + period*threshold
+}
+
+func probeTimeout(p *v1.Probe) int32 {
+	if p.TimeoutSeconds == 0 {
+		return defaultProbeTimeout
+	}
+
+	return p.TimeoutSeconds
+}
+
+func probePorts(p *v1.Probe) []intstr.IntOrString {
+	var pp []intstr.IntOrString
+	if p.HTTPGet != nil {
+		pp = append(pp, p.HTTPGet.Port)
+	}
+	if p.TCPSocket != nil {
+		pp = append(pp, p.TCPSocket.Port)
+	}
+	if p.GRPC != nil {
+		pp = append(pp, intstr.FromInt(int(p.GRPC.Port)))
+	}
+
+	return pp
+}
+
+func declaresPort(co v1.Container, port intstr.IntOrString) bool {
+	for _, p := range co.Ports {
+		if port.Type == intstr.String && p.Name == port.StrVal {
+			return true
+		}
+		if port.Type == intstr.Int && p.ContainerPort == port.IntVal {
+			return true
+		}
+	}
+
+	return false
+}
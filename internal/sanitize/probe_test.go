@@ -0,0 +1,103 @@
+package sanitize
+
+import (
+	"testing"
+
+	"github.com/derailed/popeye/internal"
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/pkg/config"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestContainerCheckProbesQuality(t *testing.T) {
+	http := func(port string) *v1.Probe {
+		return &v1.Probe{ProbeHandler: v1.ProbeHandler{HTTPGet: &v1.HTTPGetAction{Path: "/", Port: intstr.Parse(port)}}}
+	}
+	gvr := client.NewGVR("containers")
+	uu := map[string]struct {
+		live, ready, startup *v1.Probe
+		issues               issues.Issues
+	}{
+		"cool": {
+			live:  &v1.Probe{ProbeHandler: v1.ProbeHandler{HTTPGet: &v1.HTTPGetAction{Path: "/healthz", Port: intstr.Parse("http")}}},
+			ready: http("http"),
+		},
+		"identical": {
+			live:  http("http"),
+			ready: http("http"),
+			issues: issues.Issues{
+				issues.New(gvr, "c1", config.WarnLevel, "[POP-124] Liveness and Readiness probes are identical. A slow container gets restarted instead of taken out of rotation"),
+			},
+		},
+		"shortStartup": {
+			live: &v1.Probe{
+				ProbeHandler:     v1.ProbeHandler{HTTPGet: &v1.HTTPGetAction{Port: intstr.Parse("http")}},
+				PeriodSeconds:    5,
+				FailureThreshold: 2,
+			},
+			issues: issues.Issues{
+				issues.New(gvr, "c1", config.WarnLevel, "[POP-125] Liveness probe only allows 10s for startup and no startup probe is defined"),
+			},
+		},
+		"startupProbe": {
+			live: &v1.Probe{
+				ProbeHandler:     v1.ProbeHandler{HTTPGet: &v1.HTTPGetAction{Port: intstr.Parse("http")}},
+				PeriodSeconds:    5,
+				FailureThreshold: 2,
+			},
+			startup: http("http"),
+		},
+		"execTimeout": {
+			ready: &v1.Probe{ProbeHandler: v1.ProbeHandler{Exec: &v1.ExecAction{Command: []string{"true"}}}},
+			issues: issues.Issues{
+				issues.New(gvr, "c1", config.WarnLevel, "[POP-126] Readiness exec probe timeout is 1s. Exec probes may need more time to run"),
+			},
+		},
+		"execCool": {
+			ready: &v1.Probe{ProbeHandler: v1.ProbeHandler{Exec: &v1.ExecAction{Command: []string{"true"}}}, TimeoutSeconds: 5},
+		},
+		"undeclared": {
+			ready: http("fred"),
+			issues: issues.Issues{
+				issues.New(gvr, "c1", config.WarnLevel, "[POP-127] Readiness probe targets port fred which is not declared by the container"),
+			},
+		},
+		"tcpNumeric": {
+			ready: &v1.Probe{ProbeHandler: v1.ProbeHandler{TCPSocket: &v1.TCPSocketAction{Port: intstr.FromInt(8080)}}},
+			issues: issues.Issues{
+				issues.New(gvr, "c1", config.InfoLevel, "[POP-128] Readiness TCP probe uses a port#, prefer a named port"),
+			},
+		},
+		"grpcUndeclared": {
+			startup: &v1.Probe{ProbeHandler: v1.ProbeHandler{GRPC: &v1.GRPCAction{Port: 9000}}},
+			issues: issues.Issues{
+				issues.New(gvr, "c1", config.WarnLevel, "[POP-127] Startup probe targets port 9000 which is not declared by the container"),
+			},
+		},
+	}
+
+	ctx := makeContext("containers", "container")
+	ctx = internal.WithFQN(ctx, "default/p1")
+	ctx = internal.WithGroup(ctx, gvr, "c1")
+	for k := range uu {
+		u := uu[k]
+		co := makeContainer("c1", coOpts{})
+		co.Ports = []v1.ContainerPort{{Name: "http", ContainerPort: 8080}}
+		co.LivenessProbe, co.ReadinessProbe, co.StartupProbe = u.live, u.ready, u.startup
+
+		l := newRangeCollector(t)
+		l.InitOutcome("default/p1")
+		c := NewContainer("default/p1", l)
+		t.Run(k, func(t *testing.T) {
+			c.checkProbesQuality(ctx, co)
+
+			if u.issues == nil {
+				u.issues = issues.Issues{}
+			}
+			assert.Equal(t, u.issues, c.Outcome()["default/p1"])
+		})
+	}
+}
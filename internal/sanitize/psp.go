@@ -35,7 +35,7 @@ func (p *PodSecurityPolicy) Sanitize(ctx context.Context) error {
 		p.InitOutcome(fqn)
 		ctx = internal.WithFQN(ctx, fqn)
 
-		p.checkDeprecation(ctx, psp)
+		checkDeprecation(ctx, p.Collector, "PodSecurityPolicy", psp)
 
 		if p.NoConcerns(fqn) && p.Config.ExcludeFQN(internal.MustExtractSectionGVR(ctx), fqn) {
 			p.ClearOutcome(fqn)
@@ -44,17 +44,3 @@ func (p *PodSecurityPolicy) Sanitize(ctx context.Context) error {
 
 	return nil
 }
-
-func (p *PodSecurityPolicy) checkDeprecation(ctx context.Context, psp *polv1beta1.PodSecurityPolicy) {
-	const current = "policy/v1beta1"
-
-	rev, err := resourceRev(internal.MustExtractFQN(ctx), "PodSecurityPolicy", psp.Annotations)
-	if err != nil {
-		if rev = revFromLink(psp.SelfLink); rev == "" {
-			return
-		}
-	}
-	if rev != current {
-		p.AddCode(ctx, 403, "PodSecurityPolicy", rev, current)
-	}
-}
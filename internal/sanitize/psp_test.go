@@ -15,11 +15,17 @@ func TestPSPSanitize(t *testing.T) {
 		lister PodSecurityPolicyLister
 		issues issues.Issues
 	}{
-		"good": {
+		"removed": {
 			lister: makePSPLister("psp", pspOpts{
 				rev: "policy/v1beta1",
 			}),
-			issues: issues.Issues{},
+			issues: issues.Issues{
+				issues.Issue{
+					GVR:     "policy/v1beta1/podsecuritypolicies",
+					Group:   "__root__",
+					Level:   2,
+					Message: `[POP-403] Deprecated PodSecurityPolicy API group "policy/v1beta1" removed in v1.25. Use "Pod Security Admission" instead`},
+			},
 		},
 		"deprecated": {
 			lister: makePSPLister("psp", pspOpts{
@@ -30,7 +36,7 @@ func TestPSPSanitize(t *testing.T) {
 					GVR:     "policy/v1beta1/podsecuritypolicies",
 					Group:   "__root__",
 					Level:   2,
-					Message: `[POP-403] Deprecated PodSecurityPolicy API group "extensions/v1beta1". Use "policy/v1beta1" instead`},
+					Message: `[POP-403] Deprecated PodSecurityPolicy API group "extensions/v1beta1" removed in v1.16. Use "policy/v1beta1/podsecuritypolicies" instead`},
 			},
 		},
 	}
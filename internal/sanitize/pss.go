@@ -0,0 +1,365 @@
+package sanitize
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/derailed/popeye/internal"
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/pkg/config"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// PSSLabelPrefix tracks the Pod Security Admission namespace labels prefix.
+	pssLabelPrefix = "pod-security.kubernetes.io/"
+
+	// AppArmorAnnotationPrefix tracks the container AppArmor profile annotations prefix.
+	appArmorAnnotationPrefix = "container.apparmor.security.beta.kubernetes.io/"
+)
+
+const (
+	pssPrivileged pssLevel = iota
+	pssBaseline
+	pssRestricted
+)
+
+// PSSModes tracks the Pod Security Admission modes in order of precedence.
+var pssModes = []string{"enforce", "warn", "audit"}
+
+var (
+	// BaselineCaps tracks capabilities allowed by the baseline profile.
+	baselineCaps = map[v1.Capability]struct{}{
+		"AUDIT_WRITE":      {},
+		"CHOWN":            {},
+		"DAC_OVERRIDE":     {},
+		"FOWNER":           {},
+		"FSETID":           {},
+		"KILL":             {},
+		"MKNOD":            {},
+		"NET_BIND_SERVICE": {},
+		"SETFCAP":          {},
+		"SETGID":           {},
+		"SETPCAP":          {},
+		"SETUID":           {},
+		"SYS_CHROOT":       {},
+	}
+
+	// SafeSysctls tracks sysctls allowed by the baseline profile.
+	safeSysctls = map[string]struct{}{
+		"kernel.shm_rmid_forced":              {},
+		"net.ipv4.ip_local_port_range":        {},
+		"net.ipv4.ip_unprivileged_port_start": {},
+		"net.ipv4.tcp_syncookies":             {},
+		"net.ipv4.ping_group_range":           {},
+		"net.ipv4.ip_local_reserved_ports":    {},
+		"net.ipv4.tcp_keepalive_time":         {},
+		"net.ipv4.tcp_fin_timeout":            {},
+		"net.ipv4.tcp_keepalive_intvl":        {},
+		"net.ipv4.tcp_keepalive_probes":       {},
+	}
+
+	// SELinuxTypes tracks SELinux types allowed by the baseline profile.
+	seLinuxTypes = map[string]struct{}{
+		"":                 {},
+		"container_t":      {},
+		"container_init_t": {},
+		"container_kvm_t":  {},
+	}
+)
+
+type (
+	// PSSLevel represents a Pod Security Standards profile.
+	pssLevel int
+
+	// PodSecurityLister lists namespaces and their Pod Security Admission labels.
+	PodSecurityLister interface {
+		ListNamespaces() map[string]*v1.Namespace
+	}
+
+	// PSSCheck returns the controls violated for a given profile.
+	pssCheck func(pssLevel) []string
+
+	// IssueFn records an issue.
+	issueFn func(ctx context.Context, code config.ID, args ...interface{})
+)
+
+// String returns the profile name.
+func (l pssLevel) String() string {
+	switch l {
+	case pssBaseline:
+		return "baseline"
+	case pssRestricted:
+		return "restricted"
+	default:
+		return "privileged"
+	}
+}
+
+// CheckPodSecurity evaluates a pod spec against the Pod Security Standards profiles
+// set on its namespace. Each pod or container reports its most severe finding only.
+func checkPodSecurity(ctx context.Context, c *issues.Collector, l PodSecurityLister, ns string, tpl v1.PodTemplateSpec) {
+	if l == nil {
+		return
+	}
+	levels, spec := pssLevels(l.ListNamespaces()[ns]), tpl.Spec
+	fqn, gvr := internal.MustExtractFQN(ctx), internal.MustExtractSectionGVR(ctx)
+
+	reportPSS(ctx, levels, c.AddCode, func(level pssLevel) []string {
+		return podViolations(spec, level)
+	})
+	cos := make([]v1.Container, 0, len(spec.InitContainers)+len(spec.Containers))
+	cos = append(cos, spec.InitContainers...)
+	cos = append(cos, spec.Containers...)
+	for _, co := range cos {
+		if c.Config.ExcludeContainer(gvr, fqn, co.Name) {
+			continue
+		}
+		co := co
+		reportPSS(internal.WithGroup(ctx, client.NewGVR("containers"), co.Name), levels, c.AddSubCode, func(level pssLevel) []string {
+			return containerViolations(tpl.Annotations, spec, co, level)
+		})
+	}
+}
+
+// ReportPSS records violations against the enforced profile, then the warn or audit
+// profiles, then the profile following the enforced one.
+func reportPSS(ctx context.Context, levels map[string]pssLevel, add issueFn, check pssCheck) {
+	enforce := levels["enforce"]
+	if vv := check(enforce); len(vv) > 0 {
+		add(ctx, 1800, enforce, strings.Join(vv, ", "))
+		return
+	}
+	for _, m := range pssModes[1:] {
+		level, ok := levels[m]
+		if !ok || level <= enforce {
+			continue
+		}
+		if vv := check(level); len(vv) > 0 {
+			add(ctx, 1801, level, m, strings.Join(vv, ", "))
+			return
+		}
+	}
+	if enforce == pssRestricted {
+		return
+	}
+	if vv := check(enforce + 1); len(vv) > 0 {
+		add(ctx, 1802, enforce+1, strings.Join(vv, ", "))
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func hasPSSLabels(ns *v1.Namespace) bool {
+	for _, m := range pssModes {
+		if _, ok := ns.Labels[pssLabelPrefix+m]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func pssLevels(ns *v1.Namespace) map[string]pssLevel {
+	levels := make(map[string]pssLevel, len(pssModes))
+	if ns == nil {
+		return levels
+	}
+	for _, m := range pssModes {
+		if l, ok := toPSSLevel(ns.Labels[pssLabelPrefix+m]); ok {
+			levels[m] = l
+		}
+	}
+
+	return levels
+}
+
+func toPSSLevel(s string) (pssLevel, bool) {
+	switch s {
+	case "privileged":
+		return pssPrivileged, true
+	case "baseline":
+		return pssBaseline, true
+	case "restricted":
+		return pssRestricted, true
+	default:
+		return pssPrivileged, false
+	}
+}
+
+func podViolations(spec v1.PodSpec, level pssLevel) []string {
+	if level == pssPrivileged {
+		return nil
+	}
+	var vv []string
+	if spec.HostNetwork || spec.HostPID || spec.HostIPC {
+		vv = append(vv, "host namespaces")
+	}
+	if sec := spec.SecurityContext; sec != nil {
+		if !allowedSELinux(sec.SELinuxOptions) {
+			vv = append(vv, "seLinuxOptions")
+		}
+		if sec.SeccompProfile != nil && sec.SeccompProfile.Type == v1.SeccompProfileTypeUnconfined {
+			vv = append(vv, "seccompProfile")
+		}
+		if sec.WindowsOptions != nil && sec.WindowsOptions.HostProcess != nil && *sec.WindowsOptions.HostProcess {
+			vv = append(vv, "hostProcess")
+		}
+		for _, s := range sec.Sysctls {
+			if _, ok := safeSysctls[s.Name]; !ok {
+				vv = append(vv, "sysctls")
+				break
+			}
+		}
+	}
+	for _, vol := range spec.Volumes {
+		if vol.HostPath != nil {
+			vv = append(vv, "hostPath volumes")
+			break
+		}
+	}
+	if level == pssBaseline {
+		return dedupControls(vv)
+	}
+
+	for _, vol := range spec.Volumes {
+		if vol.HostPath == nil && !restrictedVolume(vol.VolumeSource) {
+			vv = append(vv, "volume types")
+			break
+		}
+	}
+
+	return dedupControls(vv)
+}
+
+func containerViolations(aa map[string]string, spec v1.PodSpec, co v1.Container, level pssLevel) []string {
+	if level == pssPrivileged {
+		return nil
+	}
+	var vv []string
+	sec := co.SecurityContext
+	if sec == nil {
+		sec = &v1.SecurityContext{}
+	}
+	if sec.Privileged != nil && *sec.Privileged {
+		vv = append(vv, "privileged")
+	}
+	if sec.Capabilities != nil {
+		for _, c := range sec.Capabilities.Add {
+			if _, ok := baselineCaps[c]; !ok {
+				vv = append(vv, "capabilities")
+				break
+			}
+		}
+	}
+	for _, p := range co.Ports {
+		if p.HostPort != 0 {
+			vv = append(vv, "hostPorts")
+			break
+		}
+	}
+	if a, ok := aa[appArmorAnnotationPrefix+co.Name]; ok && a != "runtime/default" && !strings.HasPrefix(a, "localhost/") {
+		vv = append(vv, "appArmor")
+	}
+	if !allowedSELinux(sec.SELinuxOptions) {
+		vv = append(vv, "seLinuxOptions")
+	}
+	if sec.ProcMount != nil && *sec.ProcMount != v1.DefaultProcMount {
+		vv = append(vv, "procMount")
+	}
+	if sec.SeccompProfile != nil && sec.SeccompProfile.Type == v1.SeccompProfileTypeUnconfined {
+		vv = append(vv, "seccompProfile")
+	}
+	if sec.WindowsOptions != nil && sec.WindowsOptions.HostProcess != nil && *sec.WindowsOptions.HostProcess {
+		vv = append(vv, "hostProcess")
+	}
+	if level == pssBaseline {
+		return dedupControls(vv)
+	}
+
+	if sec.AllowPrivilegeEscalation == nil || *sec.AllowPrivilegeEscalation {
+		vv = append(vv, "allowPrivilegeEscalation")
+	}
+	if !restrictedNonRoot(spec.SecurityContext, sec) {
+		vv = append(vv, "runAsNonRoot")
+	}
+	if (sec.RunAsUser != nil && *sec.RunAsUser == 0) ||
+		(sec.RunAsUser == nil && spec.SecurityContext != nil && spec.SecurityContext.RunAsUser != nil && *spec.SecurityContext.RunAsUser == 0) {
+		vv = append(vv, "runAsUser")
+	}
+	if !restrictedSeccomp(spec.SecurityContext, sec) {
+		vv = append(vv, "seccompProfile")
+	}
+	if !restrictedCaps(sec.Capabilities) {
+		vv = append(vv, "capabilities")
+	}
+
+	return dedupControls(vv)
+}
+
+func allowedSELinux(o *v1.SELinuxOptions) bool {
+	if o == nil {
+		return true
+	}
+	_, ok := seLinuxTypes[o.Type]
+
+	return ok && o.User == "" && o.Role == ""
+}
+
+func restrictedVolume(s v1.VolumeSource) bool {
+	return s.ConfigMap != nil || s.CSI != nil || s.DownwardAPI != nil || s.EmptyDir != nil ||
+		s.Ephemeral != nil || s.PersistentVolumeClaim != nil || s.Projected != nil || s.Secret != nil
+}
+
+func restrictedNonRoot(psec *v1.PodSecurityContext, sec *v1.SecurityContext) bool {
+	if sec.RunAsNonRoot != nil {
+		return *sec.RunAsNonRoot
+	}
+
+	return psec != nil && psec.RunAsNonRoot != nil && *psec.RunAsNonRoot
+}
+
+func restrictedSeccomp(psec *v1.PodSecurityContext, sec *v1.SecurityContext) bool {
+	p := sec.SeccompProfile
+	if p == nil && psec != nil {
+		p = psec.SeccompProfile
+	}
+
+	return p != nil && (p.Type == v1.SeccompProfileTypeRuntimeDefault || p.Type == v1.SeccompProfileTypeLocalhost)
+}
+
+func restrictedCaps(caps *v1.Capabilities) bool {
+	if caps == nil {
+		return false
+	}
+	for _, c := range caps.Add {
+		if c != "NET_BIND_SERVICE" {
+			return false
+		}
+	}
+	for _, c := range caps.Drop {
+		if c == "ALL" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func dedupControls(vv []string) []string {
+	set := make(map[string]struct{}, len(vv))
+	cc := make([]string, 0, len(vv))
+	for _, v := range vv {
+		if _, ok := set[v]; ok {
+			continue
+		}
+		set[v] = struct{}{}
+		cc = append(cc, v)
+	}
+	sort.Strings(cc)
+
+	return cc
+}
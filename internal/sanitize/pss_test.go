@@ -0,0 +1,154 @@
+package sanitize
+
+import (
+	"testing"
+
+	"github.com/derailed/popeye/internal"
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/pkg/config"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCheckPodSecurity(t *testing.T) {
+	uu := map[string]struct {
+		labels map[string]string
+		spec   v1.PodSpec
+		issues issues.Issues
+	}{
+		"unlabeled": {
+			spec:   makePSSSpec(nil),
+			issues: issues.Issues{},
+		},
+		"unlabeledHostNetwork": {
+			spec: func() v1.PodSpec {
+				spec := makePSSSpec(nil)
+				spec.HostNetwork = true
+				return spec
+			}(),
+			issues: issues.Issues{
+				issues.New(client.NewGVR("apps/v1/deployments"), issues.Root, config.InfoLevel, `[POP-1802] Would be rejected if namespace enforced Pod Security Standard "baseline" -- host namespaces`),
+			},
+		},
+		"enforced": {
+			labels: map[string]string{pssLabelPrefix + "enforce": "baseline"},
+			spec: makePSSSpec(&v1.SecurityContext{
+				Privileged:   boolPtr(true),
+				Capabilities: &v1.Capabilities{Add: []v1.Capability{"SYS_ADMIN"}},
+			}),
+			issues: issues.Issues{
+				issues.New(client.NewGVR("containers"), "c1", config.ErrorLevel, `[POP-1800] Violates Pod Security Standard "baseline" enforced on namespace -- capabilities, privileged`),
+			},
+		},
+		"warned": {
+			labels: map[string]string{
+				pssLabelPrefix + "enforce": "baseline",
+				pssLabelPrefix + "warn":    "restricted",
+			},
+			spec: makePSSSpec(nil),
+			issues: issues.Issues{
+				issues.New(client.NewGVR("containers"), "c1", config.WarnLevel, `[POP-1801] Violates Pod Security Standard "restricted" set in warn mode -- allowPrivilegeEscalation, capabilities, runAsNonRoot, seccompProfile`),
+			},
+		},
+		"audited": {
+			labels: map[string]string{pssLabelPrefix + "audit": "baseline"},
+			spec: makePSSSpec(&v1.SecurityContext{
+				ProcMount: procMountPtr(v1.UnmaskedProcMount),
+			}),
+			issues: issues.Issues{
+				issues.New(client.NewGVR("containers"), "c1", config.WarnLevel, `[POP-1801] Violates Pod Security Standard "baseline" set in audit mode -- procMount`),
+			},
+		},
+		"nextLevel": {
+			labels: map[string]string{pssLabelPrefix + "enforce": "baseline"},
+			spec: makePSSSpec(&v1.SecurityContext{
+				AllowPrivilegeEscalation: boolPtr(false),
+				RunAsNonRoot:             boolPtr(true),
+			}),
+			issues: issues.Issues{
+				issues.New(client.NewGVR("containers"), "c1", config.InfoLevel, `[POP-1802] Would be rejected if namespace enforced Pod Security Standard "restricted" -- capabilities, seccompProfile`),
+			},
+		},
+		"restricted": {
+			labels: map[string]string{pssLabelPrefix + "enforce": "restricted"},
+			spec: makePSSSpec(&v1.SecurityContext{
+				AllowPrivilegeEscalation: boolPtr(false),
+				RunAsNonRoot:             boolPtr(true),
+				SeccompProfile:           &v1.SeccompProfile{Type: v1.SeccompProfileTypeRuntimeDefault},
+				Capabilities:             &v1.Capabilities{Drop: []v1.Capability{"ALL"}},
+			}),
+			issues: issues.Issues{},
+		},
+		"restrictedVolumes": {
+			labels: map[string]string{pssLabelPrefix + "enforce": "restricted"},
+			spec: func() v1.PodSpec {
+				spec := makePSSSpec(&v1.SecurityContext{
+					AllowPrivilegeEscalation: boolPtr(false),
+					RunAsNonRoot:             boolPtr(true),
+					SeccompProfile:           &v1.SeccompProfile{Type: v1.SeccompProfileTypeRuntimeDefault},
+					Capabilities:             &v1.Capabilities{Drop: []v1.Capability{"ALL"}},
+				})
+				spec.Volumes = []v1.Volume{
+					{Name: "v1", VolumeSource: v1.VolumeSource{NFS: &v1.NFSVolumeSource{Server: "s1"}}},
+				}
+				return spec
+			}(),
+			issues: issues.Issues{
+				issues.New(client.NewGVR("apps/v1/deployments"), issues.Root, config.ErrorLevel, `[POP-1800] Violates Pod Security Standard "restricted" enforced on namespace -- volume types`),
+			},
+		},
+	}
+
+	ctx := makeContext("apps/v1/deployments", "deployment")
+	ctx = internal.WithFQN(ctx, "default/dp1")
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			c := issues.NewCollector(loadCodes(t), makeConfig(t))
+			c.InitOutcome("default/dp1")
+			checkPodSecurity(ctx, c, makePSSLister(u.labels), "default", v1.PodTemplateSpec{Spec: u.spec})
+
+			assert.Equal(t, u.issues, c.Outcome()["default/dp1"])
+		})
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+type pssLister struct {
+	labels map[string]string
+}
+
+func makePSSLister(labels map[string]string) pssLister {
+	return pssLister{labels: labels}
+}
+
+func (l pssLister) ListNamespaces() map[string]*v1.Namespace {
+	return map[string]*v1.Namespace{
+		"default": {
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "default",
+				Labels: l.labels,
+			},
+		},
+	}
+}
+
+func makePSSSpec(sec *v1.SecurityContext) v1.PodSpec {
+	return v1.PodSpec{
+		Containers: []v1.Container{
+			{Name: "c1", Image: "fred:1.0", SecurityContext: sec},
+		},
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func procMountPtr(t v1.ProcMountType) *v1.ProcMountType {
+	return &t
+}
@@ -54,5 +54,7 @@ func (p *PersistentVolume) checkBound(ctx context.Context, phase v1.PersistentVo
 		p.AddCode(ctx, 1001)
 	case v1.VolumeFailed:
 		p.AddCode(ctx, 1002)
+	case v1.VolumeReleased:
+		p.AddCode(ctx, 1005)
 	}
 }
@@ -18,6 +18,7 @@ func TestPVSanitize(t *testing.T) {
 		"available": {makePVLister(pvOpts{phase: v1.VolumeAvailable}), 1},
 		"pending":   {makePVLister(pvOpts{phase: v1.VolumePending}), 1},
 		"failed":    {makePVLister(pvOpts{phase: v1.VolumeFailed}), 1},
+		"released":  {makePVLister(pvOpts{phase: v1.VolumeReleased}), 1},
 	}
 
 	ctx := makeContext("v1/persistentvolumes", "pv")
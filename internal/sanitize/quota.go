@@ -0,0 +1,74 @@
+package sanitize
+
+import (
+	"context"
+	"sort"
+
+	"github.com/derailed/popeye/internal"
+	"github.com/derailed/popeye/internal/issues"
+	v1 "k8s.io/api/core/v1"
+)
+
+// quotaThreshold tracks the quota usage percentage past which a quota is nearly exhausted.
+const quotaThreshold = 80
+
+type (
+	// ResourceQuotaLister list available ResourceQuotas on a cluster.
+	ResourceQuotaLister interface {
+		ListResourceQuotas() map[string]*v1.ResourceQuota
+	}
+
+	// ResourceQuota tracks ResourceQuota sanitization.
+	ResourceQuota struct {
+		*issues.Collector
+		ResourceQuotaLister
+	}
+)
+
+// NewResourceQuota returns a new sanitizer.
+func NewResourceQuota(co *issues.Collector, lister ResourceQuotaLister) *ResourceQuota {
+	return &ResourceQuota{
+		Collector:           co,
+		ResourceQuotaLister: lister,
+	}
+}
+
+// Sanitize cleanse the resource.
+func (r *ResourceQuota) Sanitize(ctx context.Context) error {
+	for fqn, q := range r.ListResourceQuotas() {
+		r.InitOutcome(fqn)
+		ctx = internal.WithFQN(ctx, fqn)
+
+		r.checkUsage(ctx, q)
+
+		if r.NoConcerns(fqn) && r.Config.ExcludeFQN(internal.MustExtractSectionGVR(ctx), fqn) {
+			r.ClearOutcome(fqn)
+		}
+	}
+
+	return nil
+}
+
+// CheckUsage checks if quotas are exhausted or close to it.
+func (r *ResourceQuota) checkUsage(ctx context.Context, q *v1.ResourceQuota) {
+	names := make([]string, 0, len(q.Status.Hard))
+	for n := range q.Status.Hard {
+		names = append(names, string(n))
+	}
+	sort.Strings(names)
+
+	for _, n := range names {
+		res := v1.ResourceName(n)
+		hard, used := q.Status.Hard[res], q.Status.Used[res]
+		if hard.IsZero() {
+			continue
+		}
+		perc := int(used.AsApproximateFloat64() / hard.AsApproximateFloat64() * 100)
+		switch {
+		case perc >= 100:
+			r.AddCode(ctx, 1501, res, used.String(), hard.String())
+		case perc >= quotaThreshold:
+			r.AddCode(ctx, 1500, res, perc, used.String(), hard.String())
+		}
+	}
+}
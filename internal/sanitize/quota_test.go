@@ -0,0 +1,80 @@
+package sanitize
+
+import (
+	"testing"
+
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/pkg/config"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResourceQuotaSanitize(t *testing.T) {
+	uu := map[string]struct {
+		hard, used v1.ResourceList
+		issues     issues.Issues
+	}{
+		"good": {
+			hard:   v1.ResourceList{v1.ResourcePods: *makeQty("10"), v1.ResourceRequestsCPU: *makeQty("2")},
+			used:   v1.ResourceList{v1.ResourcePods: *makeQty("2"), v1.ResourceRequestsCPU: *makeQty("500m")},
+			issues: issues.Issues{},
+		},
+		"unused": {
+			hard:   v1.ResourceList{v1.ResourcePods: *makeQty("10")},
+			issues: issues.Issues{},
+		},
+		"near": {
+			hard: v1.ResourceList{v1.ResourcePods: *makeQty("10"), v1.ResourceRequestsMemory: *makeQty("1Gi")},
+			used: v1.ResourceList{v1.ResourcePods: *makeQty("8"), v1.ResourceRequestsMemory: *makeQty("512Mi")},
+			issues: issues.Issues{
+				issues.New(client.NewGVR("v1/resourcequotas"), issues.Root, config.WarnLevel, "[POP-1500] pods quota at 80% (8/10)"),
+			},
+		},
+		"exhausted": {
+			hard: v1.ResourceList{v1.ResourcePods: *makeQty("10"), v1.ResourceRequestsCPU: *makeQty("2")},
+			used: v1.ResourceList{v1.ResourcePods: *makeQty("10"), v1.ResourceRequestsCPU: *makeQty("1900m")},
+			issues: issues.Issues{
+				issues.New(client.NewGVR("v1/resourcequotas"), issues.Root, config.ErrorLevel, "[POP-1501] pods quota exhausted (10/10)"),
+				issues.New(client.NewGVR("v1/resourcequotas"), issues.Root, config.WarnLevel, "[POP-1500] requests.cpu quota at 95% (1900m/2)"),
+			},
+		},
+	}
+
+	ctx := makeContext("v1/resourcequotas", "resourcequotas")
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			l := quotaLister{quotas: map[string]*v1.ResourceQuota{"default/q1": makeResourceQuota("q1", u.hard, u.used)}}
+			q := NewResourceQuota(issues.NewCollector(loadCodes(t), makeConfig(t)), &l)
+
+			assert.Nil(t, q.Sanitize(ctx))
+			assert.Equal(t, u.issues, q.Outcome()["default/q1"])
+		})
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+type quotaLister struct {
+	quotas map[string]*v1.ResourceQuota
+}
+
+func (l *quotaLister) ListResourceQuotas() map[string]*v1.ResourceQuota {
+	return l.quotas
+}
+
+func makeResourceQuota(n string, hard, used v1.ResourceList) *v1.ResourceQuota {
+	q := v1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      n,
+			Namespace: "default",
+		},
+		Spec: v1.ResourceQuotaSpec{Hard: hard},
+	}
+	q.Status.Hard, q.Status.Used = hard, used
+
+	return &q
+}
@@ -37,6 +37,7 @@ func (r *RoleBinding) Sanitize(ctx context.Context) error {
 		r.InitOutcome(fqn)
 		ctx = internal.WithFQN(ctx, fqn)
 
+		checkDeprecation(ctx, r.Collector, "RoleBinding", rb)
 		switch rb.RoleRef.Kind {
 		case "ClusterRole":
 			if _, ok := r.ListClusterRoles()[rb.RoleRef.Name]; !ok {
@@ -0,0 +1,176 @@
+package sanitize
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/derailed/popeye/internal/authz"
+	"github.com/derailed/popeye/internal/cache"
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/pkg/config"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// BootstrapLabel tracks the label set on Kubernetes default rbac resources.
+	bootstrapLabel = "kubernetes.io/bootstrapping"
+
+	// RBACGroup tracks the rbac api group.
+	rbacGroup = "rbac.authorization.k8s.io"
+
+	// AdmissionGroup tracks the admission registration api group.
+	admissionGroup = "admissionregistration.k8s.io"
+)
+
+var (
+	// PublicGroups tracks groups every user or anonymous caller belongs to.
+	publicGroups = map[string]struct{}{
+		"system:authenticated":   {},
+		"system:unauthenticated": {},
+	}
+
+	// EscalationVerbs tracks verbs allowing to bypass rbac and the resources they apply to.
+	escalationVerbs = []struct {
+		verb, group string
+		resources   []string
+	}{
+		{verb: "escalate", group: rbacGroup, resources: []string{"roles", "clusterroles"}},
+		{verb: "bind", group: rbacGroup, resources: []string{"roles", "clusterroles"}},
+		{verb: "impersonate", group: "", resources: []string{"users", "groups", "serviceaccounts"}},
+	}
+)
+
+// RBACRisk represents a risky permission granted by a set of rules.
+type rbacRisk struct {
+	code config.ID
+	args []interface{}
+}
+
+// CheckRules reports risky permissions granted by a role. Bootstrap roles are expected
+// to grant such permissions and are only reported when exposed to public subjects.
+func checkRules(ctx context.Context, c *issues.Collector, meta metav1.ObjectMeta, rules []rbacv1.PolicyRule) bool {
+	risks := ruleRisks(rules)
+	if meta.Labels[bootstrapLabel] != "rbac-defaults" {
+		for _, r := range risks {
+			c.AddCode(ctx, r.code, r.args...)
+		}
+	}
+
+	return len(risks) > 0
+}
+
+// CheckExposure reports bindings granting a risky role to everyone or default service accounts.
+func checkExposure(ctx context.Context, c *issues.Collector, kind, binding string, ss []rbacv1.Subject) {
+	for _, s := range ss {
+		if subject, ok := publicSubject(s); ok {
+			c.AddCode(ctx, 1307, subject, kind, binding)
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func ruleRisks(rules []rbacv1.PolicyRule) []rbacRisk {
+	var risks []rbacRisk
+	for _, kind := range wildcards(rules) {
+		risks = append(risks, rbacRisk{code: 1301, args: []interface{}{kind}})
+	}
+	if grants(rules, []string{"get", "list", "watch"}, "", "secrets") {
+		risks = append(risks, rbacRisk{code: 1302})
+	}
+	for _, e := range escalationVerbs {
+		if grants(rules, []string{e.verb}, e.group, e.resources...) {
+			risks = append(risks, rbacRisk{code: 1303, args: []interface{}{e.verb}})
+		}
+	}
+	if grants(rules, []string{"create"}, "", "pods/exec") {
+		risks = append(risks, rbacRisk{code: 1304})
+	}
+	if grants(rules, []string{"get", "create"}, "", "nodes/proxy") {
+		risks = append(risks, rbacRisk{code: 1305})
+	}
+	if grants(rules, []string{"create", "update", "patch", "delete", "deletecollection"}, admissionGroup, "mutatingwebhookconfigurations", "validatingwebhookconfigurations") {
+		risks = append(risks, rbacRisk{code: 1306})
+	}
+
+	return risks
+}
+
+func wildcards(rules []rbacv1.PolicyRule) []string {
+	set := make(map[string]struct{})
+	for _, r := range rules {
+		if authz.In(r.Verbs, rbacv1.VerbAll) {
+			set["verbs"] = struct{}{}
+		}
+		if authz.In(r.Resources, rbacv1.ResourceAll) {
+			set["resources"] = struct{}{}
+		}
+		if authz.In(r.APIGroups, rbacv1.APIGroupAll) {
+			set["apiGroups"] = struct{}{}
+		}
+	}
+	kk := make([]string, 0, len(set))
+	for k := range set {
+		kk = append(kk, k)
+	}
+	sort.Strings(kk)
+
+	return kk
+}
+
+// Grants returns true if any rule allows one of the verbs on one of the resources.
+func grants(rules []rbacv1.PolicyRule, verbs []string, group string, resources ...string) bool {
+	for _, r := range rules {
+		if len(r.ResourceNames) > 0 {
+			continue
+		}
+		if !authz.In(r.APIGroups, group) && !authz.In(r.APIGroups, rbacv1.APIGroupAll) {
+			continue
+		}
+		if !matchVerbs(r.Verbs, verbs) {
+			continue
+		}
+		for _, res := range resources {
+			if authz.MatchResource(r.Resources, res) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func matchVerbs(rr, verbs []string) bool {
+	if authz.In(rr, rbacv1.VerbAll) {
+		return true
+	}
+	for _, v := range verbs {
+		if authz.In(rr, v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func publicSubject(s rbacv1.Subject) (string, bool) {
+	switch s.Kind {
+	case rbacv1.GroupKind:
+		if _, ok := publicGroups[s.Name]; ok {
+			return fmt.Sprintf("group %q", s.Name), true
+		}
+	case rbacv1.UserKind:
+		if s.Name == "system:anonymous" {
+			return fmt.Sprintf("user %q", s.Name), true
+		}
+	case rbacv1.ServiceAccountKind:
+		if s.Name == "default" {
+			return fmt.Sprintf("serviceaccount %q", cache.FQN(s.Namespace, s.Name)), true
+		}
+	}
+
+	return "", false
+}
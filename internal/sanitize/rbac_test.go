@@ -0,0 +1,217 @@
+package sanitize
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/derailed/popeye/internal"
+	"github.com/derailed/popeye/internal/cache"
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/pkg/config"
+	"github.com/stretchr/testify/assert"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRuleRisks(t *testing.T) {
+	uu := map[string]struct {
+		rules []rbacv1.PolicyRule
+		e     []config.ID
+	}{
+		"none": {
+			rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"pods", "configmaps"}, Verbs: []string{"get", "list"}},
+			},
+		},
+		"wildcards": {
+			rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{"apps"}, Resources: []string{"*"}, Verbs: []string{"*"}},
+			},
+			e: []config.ID{1301, 1301},
+		},
+		"admin": {
+			rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}},
+			},
+			e: []config.ID{1301, 1301, 1301, 1302, 1303, 1303, 1303, 1304, 1305, 1306},
+		},
+		"secrets": {
+			rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"watch"}},
+			},
+			e: []config.ID{1302},
+		},
+		"namedSecrets": {
+			rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"secrets"}, ResourceNames: []string{"s1"}, Verbs: []string{"get"}},
+			},
+		},
+		"escalation": {
+			rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{rbacGroup}, Resources: []string{"clusterroles"}, Verbs: []string{"bind", "escalate"}},
+				{APIGroups: []string{""}, Resources: []string{"users"}, Verbs: []string{"impersonate"}},
+			},
+			e: []config.ID{1303, 1303, 1303},
+		},
+		"exec": {
+			rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"pods/*"}, Verbs: []string{"create"}},
+			},
+			e: []config.ID{1304},
+		},
+		"nodeProxy": {
+			rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"*/proxy"}, Verbs: []string{"get"}},
+			},
+			e: []config.ID{1305},
+		},
+		"webhooks": {
+			rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{admissionGroup}, Resources: []string{"validatingwebhookconfigurations"}, Verbs: []string{"patch"}},
+			},
+			e: []config.ID{1306},
+		},
+		"webhooksRead": {
+			rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{admissionGroup}, Resources: []string{"validatingwebhookconfigurations"}, Verbs: []string{"get"}},
+			},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			var ids []config.ID
+			for _, r := range ruleRisks(u.rules) {
+				ids = append(ids, r.code)
+			}
+			assert.Equal(t, u.e, ids)
+		})
+	}
+}
+
+func TestClusterRoleExposure(t *testing.T) {
+	uu := map[string]struct {
+		cr     *rbacv1.ClusterRole
+		issues issues.Issues
+	}{
+		"safe": {
+			cr:     makeRuleCR("cr1", rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"}}),
+			issues: issues.Issues{},
+		},
+		"exposed": {
+			cr: makeRuleCR("cr1", rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"list"}}),
+			issues: issues.Issues{
+				issues.New(client.NewGVR("rbac.authorization.k8s.io/v1/clusterroles"), issues.Root, config.WarnLevel, "[POP-1302] Grants read access to secrets"),
+				issues.New(client.NewGVR("rbac.authorization.k8s.io/v1/clusterroles"), issues.Root, config.ErrorLevel, `[POP-1307] Grants risky permissions to group "system:authenticated" via ClusterRoleBinding "crb1"`),
+				issues.New(client.NewGVR("rbac.authorization.k8s.io/v1/clusterroles"), issues.Root, config.ErrorLevel, `[POP-1307] Grants risky permissions to serviceaccount "fred/default" via RoleBinding "fred/rb1"`),
+			},
+		},
+		"bootstrap": {
+			cr: func() *rbacv1.ClusterRole {
+				cr := makeRuleCR("cr1", rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods/exec"}, Verbs: []string{"create"}})
+				cr.Labels = map[string]string{bootstrapLabel: "rbac-defaults"}
+				return cr
+			}(),
+			issues: issues.Issues{
+				issues.New(client.NewGVR("rbac.authorization.k8s.io/v1/clusterroles"), issues.Root, config.ErrorLevel, `[POP-1307] Grants risky permissions to group "system:authenticated" via ClusterRoleBinding "crb1"`),
+				issues.New(client.NewGVR("rbac.authorization.k8s.io/v1/clusterroles"), issues.Root, config.ErrorLevel, `[POP-1307] Grants risky permissions to serviceaccount "fred/default" via RoleBinding "fred/rb1"`),
+			},
+		},
+	}
+
+	ctx := makeContext("rbac.authorization.k8s.io/v1/clusterroles", "cr")
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			c := NewClusterRole(issues.NewCollector(loadCodes(t), makeConfig(t)), makeRBACLister(u.cr, nil))
+
+			assert.Nil(t, c.Sanitize(ctx))
+			assert.Equal(t, u.issues, c.Outcome()["cr1"])
+		})
+	}
+}
+
+func TestRoleExposure(t *testing.T) {
+	ro := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "ro1", Namespace: "fred"},
+		Rules: []rbacv1.PolicyRule{
+			{APIGroups: []string{""}, Resources: []string{"pods/exec"}, Verbs: []string{"create"}},
+		},
+	}
+	r := NewRole(issues.NewCollector(loadCodes(t), makeConfig(t)), makeRBACLister(nil, ro))
+
+	assert.Nil(t, r.Sanitize(makeContext("rbac.authorization.k8s.io/v1/roles", "ro")))
+	assert.Equal(t, issues.Issues{
+		issues.New(client.NewGVR("rbac.authorization.k8s.io/v1/roles"), issues.Root, config.WarnLevel, "[POP-1304] Grants exec access into pods"),
+		issues.New(client.NewGVR("rbac.authorization.k8s.io/v1/roles"), issues.Root, config.ErrorLevel, `[POP-1307] Grants risky permissions to user "system:anonymous" via RoleBinding "fred/rb2"`),
+	}, r.Outcome()["fred/ro1"])
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+type rbacLister struct {
+	cr *rbacv1.ClusterRole
+	ro *rbacv1.Role
+}
+
+func makeRBACLister(cr *rbacv1.ClusterRole, ro *rbacv1.Role) *rbacLister {
+	return &rbacLister{cr: cr, ro: ro}
+}
+
+func (l *rbacLister) ListClusterRoles() map[string]*rbacv1.ClusterRole {
+	if l.cr == nil {
+		return map[string]*rbacv1.ClusterRole{}
+	}
+	return map[string]*rbacv1.ClusterRole{l.cr.Name: l.cr}
+}
+
+func (l *rbacLister) ListRoles() map[string]*rbacv1.Role {
+	if l.ro == nil {
+		return map[string]*rbacv1.Role{}
+	}
+	return map[string]*rbacv1.Role{cache.FQN(l.ro.Namespace, l.ro.Name): l.ro}
+}
+
+func (l *rbacLister) ListClusterRoleBindings() map[string]*rbacv1.ClusterRoleBinding {
+	crb := makeCRB("crb1", "ClusterRole", "cr1")
+	crb.Namespace = ""
+	crb.Subjects = []rbacv1.Subject{
+		{Kind: rbacv1.UserKind, Name: "fred"},
+		{Kind: rbacv1.GroupKind, Name: "system:authenticated"},
+	}
+
+	return map[string]*rbacv1.ClusterRoleBinding{"crb1": crb}
+}
+
+func (l *rbacLister) ListRoleBindings() map[string]*rbacv1.RoleBinding {
+	rb1 := makeRB("rb1", "ClusterRole", "cr1")
+	rb1.Namespace = "fred"
+	rb1.Subjects = []rbacv1.Subject{
+		{Kind: rbacv1.ServiceAccountKind, Namespace: "fred", Name: "default"},
+	}
+	rb2 := makeRB("rb2", "Role", "ro1")
+	rb2.Namespace = "fred"
+	rb2.Subjects = []rbacv1.Subject{
+		{Kind: rbacv1.UserKind, Name: "system:anonymous"},
+	}
+
+	return map[string]*rbacv1.RoleBinding{"fred/rb1": rb1, "fred/rb2": rb2}
+}
+
+func (l *rbacLister) ClusterRoleRefs(refs *sync.Map) {
+	refs.Store(cache.ResFqn(cache.ClusterRoleKey, "cr1"), internal.AllKeys)
+}
+
+func (l *rbacLister) RoleRefs(refs *sync.Map) {
+	refs.Store(cache.ResFqn(cache.RoleKey, "fred/ro1"), internal.AllKeys)
+}
+
+func makeRuleCR(n string, rules ...rbacv1.PolicyRule) *rbacv1.ClusterRole {
+	return &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: n},
+		Rules:      rules,
+	}
+}
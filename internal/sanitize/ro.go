@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/derailed/popeye/internal"
+	"github.com/derailed/popeye/internal/authz"
 	"github.com/derailed/popeye/internal/cache"
 	"github.com/derailed/popeye/internal/issues"
 )
@@ -43,10 +44,15 @@ func (r *Role) Sanitize(ctx context.Context) error {
 }
 
 func (r *Role) checkInUse(ctx context.Context, refs *sync.Map) {
-	for fqn := range r.ListRoles() {
+	for fqn, ro := range r.ListRoles() {
 		r.InitOutcome(fqn)
 		ctx = internal.WithFQN(ctx, fqn)
 
+		checkDeprecation(ctx, r.Collector, "Role", ro)
+		if checkRules(ctx, r.Collector, ro.ObjectMeta, ro.Rules) {
+			r.checkExposure(ctx, ro.Namespace, ro.Name)
+		}
+
 		_, ok := refs.Load(cache.ResFqn(cache.RoleKey, fqn))
 		if !ok {
 			r.AddCode(ctx, 400)
@@ -57,3 +63,13 @@ func (r *Role) checkInUse(ctx context.Context, refs *sync.Map) {
 		}
 	}
 }
+
+// CheckExposure checks if the role is bound to public subjects.
+func (r *Role) checkExposure(ctx context.Context, ns, name string) {
+	rbs := r.ListRoleBindings()
+	for _, fqn := range authz.RoleBindingKeys(rbs) {
+		if rb := rbs[fqn]; rb.Namespace == ns && rb.RoleRef.Kind == "Role" && rb.RoleRef.Name == name {
+			checkExposure(ctx, r.Collector, "RoleBinding", fqn, rb.Subjects)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package sanitize
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// MaxPausedRollout tracks how long a rollout may remain paused.
+	maxPausedRollout = 24 * time.Hour
+
+	// MaxPendingUpdate tracks how long an OnDelete update may remain pending.
+	maxPendingUpdate = 24 * time.Hour
+
+	// ProgressDeadlineExceeded tracks a stalled rollout condition reason.
+	progressDeadlineExceeded = "ProgressDeadlineExceeded"
+
+	// DeploymentPaused tracks a paused rollout condition reason.
+	deploymentPaused = "DeploymentPaused"
+
+	// TierLabel tracks the pod label identifying a workload tier.
+	tierLabel = "tier"
+
+	// CriticalTier tracks the critical workload tier.
+	criticalTier = "critical"
+)
+
+// CriticalPriorityClasses tracks priority classes of critical workloads.
+var criticalPriorityClasses = map[string]struct{}{
+	"system-cluster-critical": {},
+	"system-node-critical":    {},
+}
+
+// CheckDeploymentRollout checks a deployment rollout health and strategy.
+func checkDeploymentRollout(ctx context.Context, c Collector, now time.Time, dp *appsv1.Deployment) {
+	for _, cond := range dp.Status.Conditions {
+		if cond.Type != appsv1.DeploymentProgressing {
+			continue
+		}
+		if cond.Status == v1.ConditionFalse && cond.Reason == progressDeadlineExceeded {
+			c.AddCode(ctx, 511, cond.Message)
+		}
+		if !dp.Spec.Paused || cond.Reason != deploymentPaused || cond.LastUpdateTime.IsZero() {
+			continue
+		}
+		if age := now.Sub(cond.LastUpdateTime.Time); age > maxPausedRollout {
+			c.AddCode(ctx, 516, duration.HumanDuration(age))
+		}
+	}
+	if ru := dp.Spec.Strategy.RollingUpdate; ru != nil && dp.Spec.Strategy.Type != appsv1.RecreateDeploymentStrategyType {
+		checkRollingUpdate(ctx, c, dp.Spec.Replicas, ru)
+	}
+	checkRevisionHistory(ctx, c, dp.Spec.RevisionHistoryLimit)
+	checkMinReadySeconds(ctx, c, dp.Spec.MinReadySeconds, dp.Spec.Template)
+}
+
+// CheckStatefulSetRollout checks a statefulset rollout health and strategy.
+func checkStatefulSetRollout(ctx context.Context, c Collector, now time.Time, sts *appsv1.StatefulSet) {
+	if sts.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		st := sts.Status
+		if st.UpdateRevision != "" && st.UpdateRevision != st.CurrentRevision && st.UpdatedReplicas < st.Replicas {
+			last := lastSpecUpdate(sts.ObjectMeta)
+			if age := now.Sub(last); !last.IsZero() && age > maxPendingUpdate {
+				c.AddCode(ctx, 517, st.UpdateRevision, st.Replicas-st.UpdatedReplicas, st.Replicas, duration.HumanDuration(age))
+			}
+		}
+	}
+	checkRevisionHistory(ctx, c, sts.Spec.RevisionHistoryLimit)
+	checkMinReadySeconds(ctx, c, sts.Spec.MinReadySeconds, sts.Spec.Template)
+}
+
+func checkRollingUpdate(ctx context.Context, c Collector, replicas *int32, ru *appsv1.RollingUpdateDeployment) {
+	if ru.MaxSurge != nil && ru.MaxUnavailable != nil && isZeroIntOrPercent(*ru.MaxSurge) && isZeroIntOrPercent(*ru.MaxUnavailable) {
+		c.AddCode(ctx, 513)
+		return
+	}
+	if replicas == nil || *replicas == 0 || ru.MaxUnavailable == nil {
+		return
+	}
+	if v, err := intstr.GetScaledValueFromIntOrPercent(ru.MaxUnavailable, int(*replicas), false); err == nil && v >= int(*replicas) {
+		c.AddCode(ctx, 512, ru.MaxUnavailable.String(), *replicas)
+	}
+}
+
+func checkRevisionHistory(ctx context.Context, c Collector, limit *int32) {
+	if limit != nil && *limit == 0 {
+		c.AddCode(ctx, 514)
+	}
+}
+
+func checkMinReadySeconds(ctx context.Context, c Collector, minReady int32, tpl v1.PodTemplateSpec) {
+	if minReady > 0 {
+		return
+	}
+	if tier, ok := criticalTierOf(tpl); ok {
+		c.AddCode(ctx, 515, tier)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+// CriticalTierOf returns the reason a pod template is deemed critical if any.
+func criticalTierOf(tpl v1.PodTemplateSpec) (string, bool) {
+	if _, ok := criticalPriorityClasses[tpl.Spec.PriorityClassName]; ok {
+		return tpl.Spec.PriorityClassName, true
+	}
+	if strings.EqualFold(tpl.Labels[tierLabel], criticalTier) {
+		return tierLabel + "=" + tpl.Labels[tierLabel], true
+	}
+
+	return "", false
+}
+
+// LastSpecUpdate returns the last time a resource spec was updated as tracked by
+// its managed fields, defaulting to its creation time.
+func lastSpecUpdate(m metav1.ObjectMeta) time.Time {
+	last := m.CreationTimestamp.Time
+	for _, mf := range m.ManagedFields {
+		if mf.Time == nil || mf.FieldsV1 == nil || !strings.Contains(string(mf.FieldsV1.Raw), `"f:spec"`) {
+			continue
+		}
+		if mf.Time.Time.After(last) {
+			last = mf.Time.Time
+		}
+	}
+
+	return last
+}
@@ -0,0 +1,211 @@
+package sanitize
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derailed/popeye/internal"
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/pkg/config"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestCheckDeploymentRollout(t *testing.T) {
+	now := time.Now()
+	gvr := client.NewGVR("apps/v1/deployments")
+	uu := map[string]struct {
+		dp     func(*appsv1.Deployment)
+		issues issues.Issues
+	}{
+		"cool": {
+			dp:     func(*appsv1.Deployment) {},
+			issues: issues.Issues{},
+		},
+		"deadline": {
+			dp: func(dp *appsv1.Deployment) {
+				dp.Status.Conditions = []appsv1.DeploymentCondition{
+					{Type: appsv1.DeploymentProgressing, Status: v1.ConditionFalse, Reason: progressDeadlineExceeded, Message: `ReplicaSet "d1-123" has timed out progressing.`},
+				}
+			},
+			issues: issues.Issues{
+				issues.New(gvr, issues.Root, config.ErrorLevel, `[POP-511] Rollout exceeded its progress deadline (ReplicaSet "d1-123" has timed out progressing.)`),
+			},
+		},
+		"allUnavailable": {
+			dp: func(dp *appsv1.Deployment) {
+				mu := intstr.Parse("100%")
+				dp.Spec.Strategy.RollingUpdate = &appsv1.RollingUpdateDeployment{MaxUnavailable: &mu}
+			},
+			issues: issues.Issues{
+				issues.New(gvr, issues.Root, config.WarnLevel, "[POP-512] RollingUpdate maxUnavailable (100%) covers all 3 replicas. A rollout may take down the workload"),
+			},
+		},
+		"stuck": {
+			dp: func(dp *appsv1.Deployment) {
+				ms, mu := intstr.FromInt(0), intstr.Parse("0%")
+				dp.Spec.Strategy.RollingUpdate = &appsv1.RollingUpdateDeployment{MaxSurge: &ms, MaxUnavailable: &mu}
+			},
+			issues: issues.Issues{
+				issues.New(gvr, issues.Root, config.ErrorLevel, "[POP-513] RollingUpdate maxSurge and maxUnavailable are both 0. The rollout cannot progress"),
+			},
+		},
+		"recreate": {
+			dp: func(dp *appsv1.Deployment) {
+				mu := intstr.Parse("100%")
+				dp.Spec.Strategy = appsv1.DeploymentStrategy{
+					Type:          appsv1.RecreateDeploymentStrategyType,
+					RollingUpdate: &appsv1.RollingUpdateDeployment{MaxUnavailable: &mu},
+				}
+			},
+			issues: issues.Issues{},
+		},
+		"noHistory": {
+			dp: func(dp *appsv1.Deployment) {
+				var l int32
+				dp.Spec.RevisionHistoryLimit = &l
+			},
+			issues: issues.Issues{
+				issues.New(gvr, issues.Root, config.WarnLevel, "[POP-514] RevisionHistoryLimit is 0. Rollbacks are not possible"),
+			},
+		},
+		"criticalClass": {
+			dp: func(dp *appsv1.Deployment) {
+				dp.Spec.MinReadySeconds = 0
+				dp.Spec.Template.Spec.PriorityClassName = "system-cluster-critical"
+			},
+			issues: issues.Issues{
+				issues.New(gvr, issues.Root, config.InfoLevel, "[POP-515] MinReadySeconds is 0 on a critical workload (system-cluster-critical). Pods are available as soon as they report ready"),
+			},
+		},
+		"criticalTier": {
+			dp: func(dp *appsv1.Deployment) {
+				dp.Spec.MinReadySeconds = 0
+				dp.Spec.Template.Labels = map[string]string{tierLabel: "Critical"}
+			},
+			issues: issues.Issues{
+				issues.New(gvr, issues.Root, config.InfoLevel, "[POP-515] MinReadySeconds is 0 on a critical workload (tier=Critical). Pods are available as soon as they report ready"),
+			},
+		},
+		"paused": {
+			dp: func(dp *appsv1.Deployment) {
+				dp.Spec.Paused = true
+				dp.Status.Conditions = []appsv1.DeploymentCondition{
+					{Type: appsv1.DeploymentProgressing, Status: v1.ConditionUnknown, Reason: deploymentPaused, LastUpdateTime: metav1.NewTime(now.Add(-72 * time.Hour))},
+				}
+			},
+			issues: issues.Issues{
+				issues.New(gvr, issues.Root, config.WarnLevel, "[POP-516] Rollout has been paused for 3d"),
+			},
+		},
+		"recentlyPaused": {
+			dp: func(dp *appsv1.Deployment) {
+				dp.Spec.Paused = true
+				dp.Status.Conditions = []appsv1.DeploymentCondition{
+					{Type: appsv1.DeploymentProgressing, Status: v1.ConditionUnknown, Reason: deploymentPaused, LastUpdateTime: metav1.NewTime(now.Add(-time.Hour))},
+				}
+			},
+			issues: issues.Issues{},
+		},
+	}
+
+	ctx := makeContext("apps/v1/deployments", "deploy")
+	ctx = internal.WithFQN(ctx, "default/d1")
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			co := issues.NewCollector(loadCodes(t), makeConfig(t))
+			co.InitOutcome("default/d1")
+			dp := makeDP("d1", dpOpts{reps: 3})
+			dp.Spec.MinReadySeconds = 10
+			u.dp(dp)
+
+			checkDeploymentRollout(ctx, co, now, dp)
+			assert.Equal(t, u.issues, co.Outcome()["default/d1"])
+		})
+	}
+}
+
+func TestCheckStatefulSetRollout(t *testing.T) {
+	now := time.Now()
+	gvr := client.NewGVR("apps/v1/statefulsets")
+	uu := map[string]struct {
+		strategy        appsv1.StatefulSetUpdateStrategyType
+		current, update string
+		updated         int32
+		lastUpdate      time.Duration
+		issues          issues.Issues
+	}{
+		"cool": {
+			strategy: appsv1.OnDeleteStatefulSetStrategyType,
+			current:  "r1",
+			update:   "r1",
+			updated:  3,
+			issues:   issues.Issues{},
+		},
+		"pending": {
+			strategy:   appsv1.OnDeleteStatefulSetStrategyType,
+			current:    "r1",
+			update:     "r2",
+			updated:    1,
+			lastUpdate: 48 * time.Hour,
+			issues: issues.Issues{
+				issues.New(gvr, issues.Root, config.WarnLevel, "[POP-517] OnDelete update to revision r2 pending on 2/3 pods for 2d"),
+			},
+		},
+		"recent": {
+			strategy:   appsv1.OnDeleteStatefulSetStrategyType,
+			current:    "r1",
+			update:     "r2",
+			updated:    1,
+			lastUpdate: time.Hour,
+			issues:     issues.Issues{},
+		},
+		"rolling": {
+			strategy:   appsv1.RollingUpdateStatefulSetStrategyType,
+			current:    "r1",
+			update:     "r2",
+			updated:    1,
+			lastUpdate: 48 * time.Hour,
+			issues:     issues.Issues{},
+		},
+	}
+
+	ctx := makeContext("apps/v1/statefulsets", "sts")
+	ctx = internal.WithFQN(ctx, "default/sts1")
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			co := issues.NewCollector(loadCodes(t), makeConfig(t))
+			co.InitOutcome("default/sts1")
+			sts := makeSTS("sts1", stsOpts{replicas: 3})
+			sts.CreationTimestamp = metav1.NewTime(now.Add(-30 * 24 * time.Hour))
+			sts.ManagedFields = []metav1.ManagedFieldsEntry{
+				{Manager: "kubectl", Time: timePtr(now.Add(-u.lastUpdate)), FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec": {"f:template": {}}}`)}},
+				{Manager: "kube-controller-manager", Time: timePtr(now), FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:status": {}}`)}},
+			}
+			sts.Spec.UpdateStrategy.Type = u.strategy
+			sts.Status = appsv1.StatefulSetStatus{
+				Replicas:        3,
+				UpdatedReplicas: u.updated,
+				CurrentRevision: u.current,
+				UpdateRevision:  u.update,
+			}
+
+			checkStatefulSetRollout(ctx, co, now, sts)
+			assert.Equal(t, u.issues, co.Outcome()["default/sts1"])
+		})
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func timePtr(t time.Time) *metav1.Time {
+	mt := metav1.NewTime(t)
+	return &mt
+}
@@ -2,10 +2,22 @@ package sanitize
 
 import (
 	"context"
+	"sort"
+	"strconv"
 
 	"github.com/derailed/popeye/internal"
+	"github.com/derailed/popeye/internal/cache"
 	"github.com/derailed/popeye/internal/issues"
 	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// RevisionAnnotation tracks a deployment revision on its replicasets.
+	revisionAnnotation = "deployment.kubernetes.io/revision"
+
+	// DefaultRevisionHistoryLimit tracks the api server revision history limit default.
+	defaultRevisionHistoryLimit = 10
 )
 
 type (
@@ -23,6 +35,13 @@ type (
 	// ReplicaSetLister list available ReplicaSets on a cluster.
 	ReplicaSetLister interface {
 		ReplicaLister
+		DeploymentLister
+	}
+
+	// StaleRevision tracks a scaled down revision exceeding its deployment revision history limit.
+	staleRevision struct {
+		deployment string
+		limit      int32
 	}
 )
 
@@ -36,12 +55,16 @@ func NewReplicaSet(co *issues.Collector, lister ReplicaSetLister) *ReplicaSet {
 
 // Sanitize cleanse the resource.
 func (r *ReplicaSet) Sanitize(ctx context.Context) error {
+	stales := staleRevisions(r.ListReplicaSets(), r.ListDeployments())
 	for fqn, rs := range r.ListReplicaSets() {
 		r.InitOutcome(fqn)
 		ctx = internal.WithFQN(ctx, fqn)
 
 		r.checkHealth(ctx, rs)
-		r.checkDeprecation(ctx, rs)
+		if st, ok := stales[fqn]; ok {
+			r.AddCode(ctx, 1121, st.deployment, st.limit)
+		}
+		checkDeprecation(ctx, r.Collector, "ReplicaSet", rs)
 
 		if r.NoConcerns(fqn) && r.Config.ExcludeFQN(internal.MustExtractSectionGVR(ctx), fqn) {
 			r.ClearOutcome(fqn)
@@ -57,16 +80,55 @@ func (r *ReplicaSet) checkHealth(ctx context.Context, rs *appsv1.ReplicaSet) {
 	}
 }
 
-func (r *ReplicaSet) checkDeprecation(ctx context.Context, rs *appsv1.ReplicaSet) {
-	const current = "apps/v1"
+// ----------------------------------------------------------------------------
+// Helpers...
 
-	rev, err := resourceRev(internal.MustExtractFQN(ctx), "ReplicaSet", rs.Annotations)
-	if err != nil {
-		if rev = revFromLink(rs.SelfLink); rev == "" {
-			return
+// StaleRevisions returns the scaled down replicasets a deployment no longer retains.
+// Like the deployment controller, all old revisions count towards the history limit
+// while only the oldest scaled down ones are deemed stale.
+func staleRevisions(rss map[string]*appsv1.ReplicaSet, dps map[string]*appsv1.Deployment) map[string]staleRevision {
+	owned := make(map[string][]*appsv1.ReplicaSet)
+	for _, rs := range rss {
+		ref := metav1.GetControllerOf(rs)
+		if ref == nil || ref.Kind != "Deployment" {
+			continue
+		}
+		dfqn := cache.FQN(rs.Namespace, ref.Name)
+		owned[dfqn] = append(owned[dfqn], rs)
+	}
+
+	stales := make(map[string]staleRevision)
+	for dfqn, ll := range owned {
+		limit := int32(defaultRevisionHistoryLimit)
+		if dp, ok := dps[dfqn]; ok && dp.Spec.RevisionHistoryLimit != nil {
+			limit = *dp.Spec.RevisionHistoryLimit
+		}
+		sort.Slice(ll, func(i, j int) bool {
+			return revisionOf(ll[i]) < revisionOf(ll[j])
+		})
+		// The latest revision is the current one.
+		old := ll[:len(ll)-1]
+		for i := 0; i < len(old)-int(limit); i++ {
+			if !scaledDown(old[i]) {
+				continue
+			}
+			_, n := namespaced(dfqn)
+			stales[cache.MetaFQN(old[i].ObjectMeta)] = staleRevision{deployment: n, limit: limit}
 		}
 	}
-	if rev != current {
-		r.AddCode(ctx, 403, "ReplicaSet", rev, current)
+
+	return stales
+}
+
+func revisionOf(rs *appsv1.ReplicaSet) int64 {
+	rev, err := strconv.ParseInt(rs.Annotations[revisionAnnotation], 10, 64)
+	if err != nil {
+		return 0
 	}
+
+	return rev
+}
+
+func scaledDown(rs *appsv1.ReplicaSet) bool {
+	return rs.Spec.Replicas != nil && *rs.Spec.Replicas == 0 && rs.Status.Replicas == 0
 }
@@ -1,6 +1,8 @@
 package sanitize
 
 import (
+	"fmt"
+	"strconv"
 	"testing"
 
 	"github.com/derailed/popeye/internal/cache"
@@ -30,7 +32,7 @@ func TestRSSanitize(t *testing.T) {
 					GVR:     "apps/v1/replicasets",
 					Group:   "__root__",
 					Level:   2,
-					Message: `[POP-403] Deprecated ReplicaSet API group "extensions/v1beta1". Use "apps/v1" instead`},
+					Message: `[POP-403] Deprecated ReplicaSet API group "extensions/v1beta1" removed in v1.16. Use "apps/v1/replicasets" instead`},
 			},
 		},
 	}
@@ -47,6 +49,59 @@ func TestRSSanitize(t *testing.T) {
 	}
 }
 
+func TestStaleRevisions(t *testing.T) {
+	uu := map[string]struct {
+		limit    *int32
+		replicas []int32
+		e        map[string]staleRevision
+	}{
+		"withinLimit": {
+			limit:    int32Ptr(2),
+			replicas: []int32{0, 0, 1},
+			e:        map[string]staleRevision{},
+		},
+		"beyondLimit": {
+			limit:    int32Ptr(1),
+			replicas: []int32{0, 0, 0, 1},
+			e: map[string]staleRevision{
+				"default/rs1": {deployment: "d1", limit: 1},
+				"default/rs2": {deployment: "d1", limit: 1},
+			},
+		},
+		"scaledUp": {
+			limit:    int32Ptr(0),
+			replicas: []int32{1, 0, 1},
+			e: map[string]staleRevision{
+				"default/rs2": {deployment: "d1", limit: 0},
+			},
+		},
+		"defaultLimit": {
+			replicas: []int32{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+			e: map[string]staleRevision{
+				"default/rs1": {deployment: "d1", limit: 10},
+			},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			dp := makeDP("d1", dpOpts{})
+			dp.Spec.RevisionHistoryLimit = u.limit
+			rss := make(map[string]*appsv1.ReplicaSet, len(u.replicas))
+			for i, r := range u.replicas {
+				rs := makeRevision(dp, i+1, r)
+				rss[cache.MetaFQN(rs.ObjectMeta)] = rs
+			}
+
+			assert.Equal(t, u.e, staleRevisions(rss, map[string]*appsv1.Deployment{"default/d1": dp}))
+		})
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
 type (
 	rsOpts struct {
 		rev string
@@ -71,6 +126,19 @@ func (r *rs) ListReplicaSets() map[string]*appsv1.ReplicaSet {
 	}
 }
 
+func (r *rs) ListDeployments() map[string]*appsv1.Deployment {
+	return map[string]*appsv1.Deployment{}
+}
+
+func makeRevision(dp *appsv1.Deployment, rev int, replicas int32) *appsv1.ReplicaSet {
+	rs := makeRS(fmt.Sprintf("rs%d", rev), rsOpts{rev: "apps/v1"})
+	rs.Annotations = map[string]string{revisionAnnotation: strconv.Itoa(rev)}
+	rs.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(dp, appsv1.SchemeGroupVersion.WithKind("Deployment"))}
+	rs.Spec.Replicas, rs.Status.Replicas = &replicas, replicas
+
+	return rs
+}
+
 func makeRS(n string, o rsOpts) *appsv1.ReplicaSet {
 	return &appsv1.ReplicaSet{
 		ObjectMeta: metav1.ObjectMeta{
@@ -81,3 +149,7 @@ func makeRS(n string, o rsOpts) *appsv1.ReplicaSet {
 		Spec: appsv1.ReplicaSetSpec{},
 	}
 }
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
@@ -0,0 +1,264 @@
+package sanitize
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxScheduleYears bounds the search for a schedule next activation.
+const maxScheduleYears = 5
+
+var (
+	scheduleMacros = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+	monthNames = map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}
+	dowNames = map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}
+)
+
+// scheduleField represents a cron field bounds.
+type scheduleField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var scheduleFields = []scheduleField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: monthNames},
+	{name: "day of week", min: 0, max: 6, names: dowNames},
+}
+
+// schedule represents a standard cron schedule as accepted by the CronJob controller.
+type schedule struct {
+	minute, hour, dom, month, dow uint64
+	domStar, dowStar              bool
+	every                         time.Duration
+	loc                           *time.Location
+}
+
+// parseSchedule parses a standard 5 fields cron expression, descriptors and an optional timezone prefix.
+func parseSchedule(spec string) (*schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, errors.New("empty schedule")
+	}
+
+	s := schedule{loc: time.Local}
+	if strings.HasPrefix(spec, "TZ=") || strings.HasPrefix(spec, "CRON_TZ=") {
+		i := strings.Index(spec, " ")
+		if i == -1 {
+			return nil, errors.New("missing schedule after timezone")
+		}
+		tz := spec[strings.Index(spec, "=")+1 : i]
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timezone %q", tz)
+		}
+		s.loc, spec = loc, strings.TrimSpace(spec[i:])
+	}
+
+	if strings.HasPrefix(spec, "@every ") {
+		d, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(spec, "@every ")))
+		if err != nil {
+			return nil, err
+		}
+		if d < time.Second {
+			return nil, fmt.Errorf("interval %s is too short", d)
+		}
+		s.every = d
+		return &s, nil
+	}
+	if m, ok := scheduleMacros[spec]; ok {
+		spec = m
+	}
+
+	ff := strings.Fields(spec)
+	if len(ff) != len(scheduleFields) {
+		return nil, fmt.Errorf("expected %d fields but found %d", len(scheduleFields), len(ff))
+	}
+	bb := make([]uint64, len(ff))
+	for i, f := range ff {
+		bits, star, err := parseScheduleField(f, scheduleFields[i])
+		if err != nil {
+			return nil, err
+		}
+		bb[i] = bits
+		switch i {
+		case 2:
+			s.domStar = star
+		case 4:
+			s.dowStar = star
+		}
+	}
+	s.minute, s.hour, s.dom, s.month, s.dow = bb[0], bb[1], bb[2], bb[3], bb[4]
+
+	return &s, nil
+}
+
+// next returns the first activation after the given time or a zero time if none.
+func (s *schedule) next(t time.Time) time.Time {
+	if s.every > 0 {
+		return t.Add(s.every - time.Duration(t.Nanosecond())*time.Nanosecond)
+	}
+
+	t = t.In(s.loc).Truncate(time.Minute).Add(time.Minute)
+	limit := t.Year() + maxScheduleYears
+
+wrap:
+	if t.Year() > limit {
+		return time.Time{}
+	}
+	for 1<<uint(t.Month())&s.month == 0 {
+		t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.loc)
+		if t.Month() == time.January {
+			goto wrap
+		}
+	}
+	for !s.dayMatches(t) {
+		t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.loc)
+		if t.Day() == 1 {
+			goto wrap
+		}
+	}
+	for 1<<uint(t.Hour())&s.hour == 0 {
+		t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, s.loc)
+		if t.Hour() == 0 {
+			goto wrap
+		}
+	}
+	for 1<<uint(t.Minute())&s.minute == 0 {
+		t = t.Add(time.Minute)
+		if t.Minute() == 0 {
+			goto wrap
+		}
+	}
+
+	return t
+}
+
+// interval returns the duration between the two activations following the given time.
+func (s *schedule) interval(t time.Time) time.Duration {
+	n1 := s.next(t)
+	if n1.IsZero() {
+		return 0
+	}
+	n2 := s.next(n1)
+	if n2.IsZero() {
+		return 0
+	}
+
+	return n2.Sub(n1)
+}
+
+// dayMatches checks the day of month and day of week fields. When both are
+// restricted, matching either one is enough.
+func (s *schedule) dayMatches(t time.Time) bool {
+	dom := 1<<uint(t.Day())&s.dom > 0
+	dow := 1<<uint(t.Weekday())&s.dow > 0
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+
+	return dom || dow
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func parseScheduleField(expr string, f scheduleField) (uint64, bool, error) {
+	var (
+		bits uint64
+		star bool
+	)
+	for _, r := range strings.Split(expr, ",") {
+		b, s, err := parseScheduleRange(r, f)
+		if err != nil {
+			return 0, false, err
+		}
+		bits, star = bits|b, star || s
+	}
+
+	return bits, star, nil
+}
+
+func parseScheduleRange(expr string, f scheduleField) (uint64, bool, error) {
+	var (
+		start, end, step = 0, 0, 1
+		star             bool
+		err              error
+	)
+	tokens := strings.Split(expr, "/")
+	if len(tokens) > 2 {
+		return 0, false, fmt.Errorf("invalid %s %q", f.name, expr)
+	}
+	bounds := strings.Split(tokens[0], "-")
+	switch {
+	case len(bounds) == 1 && (bounds[0] == "*" || bounds[0] == "?"):
+		start, end, star = f.min, f.max, true
+	case len(bounds) == 1:
+		if start, err = parseScheduleValue(bounds[0], f); err != nil {
+			return 0, false, err
+		}
+		end = start
+	case len(bounds) == 2:
+		if start, err = parseScheduleValue(bounds[0], f); err != nil {
+			return 0, false, err
+		}
+		if end, err = parseScheduleValue(bounds[1], f); err != nil {
+			return 0, false, err
+		}
+	default:
+		return 0, false, fmt.Errorf("invalid %s %q", f.name, expr)
+	}
+	if len(tokens) == 2 {
+		if step, err = strconv.Atoi(tokens[1]); err != nil || step <= 0 {
+			return 0, false, fmt.Errorf("invalid %s step %q", f.name, tokens[1])
+		}
+		// A single value with a step runs till the end of the range.
+		if len(bounds) == 1 && !star {
+			end = f.max
+		}
+		if step > 1 {
+			star = false
+		}
+	}
+	if start < f.min || end > f.max || start > end {
+		return 0, false, fmt.Errorf("%s %q out of range [%d-%d]", f.name, expr, f.min, f.max)
+	}
+
+	var bits uint64
+	for i := start; i <= end; i += step {
+		bits |= 1 << uint(i)
+	}
+
+	return bits, star, nil
+}
+
+func parseScheduleValue(s string, f scheduleField) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q", f.name, s)
+	}
+
+	return v, nil
+}
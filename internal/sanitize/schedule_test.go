@@ -0,0 +1,79 @@
+package sanitize
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSchedule(t *testing.T) {
+	uu := map[string]struct {
+		spec string
+		err  string
+	}{
+		"standard":  {spec: "*/5 * * * *"},
+		"ranges":    {spec: "0 9-17 * * mon-fri"},
+		"lists":     {spec: "0,30 1,13 1,15 jan,jul *"},
+		"macro":     {spec: "@daily"},
+		"every":     {spec: "@every 1h30m"},
+		"tz":        {spec: "CRON_TZ=UTC 0 0 * * *"},
+		"empty":     {spec: "", err: "empty schedule"},
+		"fields":    {spec: "* * * *", err: "expected 5 fields but found 4"},
+		"range":     {spec: "60 * * * *", err: `minute "60" out of range [0-59]`},
+		"name":      {spec: "0 0 * * fred", err: `invalid day of week "fred"`},
+		"step":      {spec: "*/0 * * * *", err: `invalid minute step "0"`},
+		"backwards": {spec: "0 17-9 * * *", err: `hour "17-9" out of range [0-23]`},
+		"badTZ":     {spec: "TZ=Fred/Blee 0 0 * * *", err: `invalid timezone "Fred/Blee"`},
+		"badEvery":  {spec: "@every fred", err: `time: invalid duration "fred"`},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			_, err := parseSchedule(u.spec)
+			if u.err == "" {
+				assert.Nil(t, err)
+				return
+			}
+			assert.EqualError(t, err, u.err)
+		})
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	from := time.Date(2022, time.January, 31, 10, 7, 30, 0, time.UTC)
+	uu := map[string]struct {
+		spec string
+		e    time.Time
+	}{
+		"minutes": {spec: "*/5 * * * *", e: time.Date(2022, time.January, 31, 10, 10, 0, 0, time.UTC)},
+		"hourly":  {spec: "@hourly", e: time.Date(2022, time.January, 31, 11, 0, 0, 0, time.UTC)},
+		"daily":   {spec: "30 2 * * *", e: time.Date(2022, time.February, 1, 2, 30, 0, 0, time.UTC)},
+		"weekday": {spec: "0 9 * * sat", e: time.Date(2022, time.February, 5, 9, 0, 0, 0, time.UTC)},
+		"domOr":   {spec: "0 0 15 * sat", e: time.Date(2022, time.February, 5, 0, 0, 0, 0, time.UTC)},
+		"yearly":  {spec: "@yearly", e: time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		"leap":    {spec: "0 0 29 2 *", e: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		"never":   {spec: "0 0 31 2 *"},
+		"every":   {spec: "@every 90m", e: time.Date(2022, time.January, 31, 11, 37, 30, 0, time.UTC)},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			s, err := parseSchedule("CRON_TZ=UTC " + u.spec)
+			assert.Nil(t, err)
+			assert.True(t, u.e.Equal(s.next(from)), s.next(from).String())
+		})
+	}
+}
+
+func TestScheduleInterval(t *testing.T) {
+	s, err := parseSchedule("CRON_TZ=UTC */15 * * * *")
+	assert.Nil(t, err)
+	assert.Equal(t, 15*time.Minute, s.interval(time.Now()))
+
+	s, err = parseSchedule("CRON_TZ=UTC 0 0 31 2 *")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Duration(0), s.interval(time.Now()))
+}
@@ -2,6 +2,7 @@ package sanitize
 
 import (
 	"context"
+	"time"
 
 	"github.com/derailed/popeye/internal"
 	"github.com/derailed/popeye/internal/client"
@@ -26,9 +27,11 @@ type (
 		ConfigLister
 		PodSelectorLister
 		PodsMetricsLister
+		PodSecurityLister
 
 		ListStatefulSets() map[string]*appsv1.StatefulSet
 		ListServiceAccounts() map[string]*v1.ServiceAccount
+		ListNodes() map[string]*v1.Node
 	}
 
 	// StatefulSet represents a StatefulSet sanitizer.
@@ -51,14 +54,18 @@ func (s *StatefulSet) Sanitize(ctx context.Context) error {
 	pmx := client.PodsMetrics{}
 	podsMetrics(s, pmx)
 
-	over := pullOverAllocs(ctx)
+	over, now := pullOverAllocs(ctx), time.Now()
 	for fqn, st := range s.ListStatefulSets() {
 		s.InitOutcome(fqn)
 		ctx = internal.WithFQN(ctx, fqn)
 
-		s.checkDeprecation(ctx, st)
+		checkDeprecation(ctx, s.Collector, "StatefulSet", st)
 		s.checkStatefulSet(ctx, st)
+		checkStatefulSetRollout(ctx, s.Collector, now, st)
+		checkPlacement(ctx, s.Collector, s, st.Namespace, st.Spec.Selector, st.Spec.Replicas, st.Spec.Template.Spec)
 		s.checkContainers(ctx, st)
+		checkHardening(ctx, s.Collector, st.Spec.Template)
+		checkPodSecurity(ctx, s.Collector, s, st.Namespace, st.Spec.Template)
 		s.checkUtilization(ctx, over, st, pmx)
 
 		if s.NoConcerns(fqn) && s.Config.ExcludeFQN(internal.MustExtractSectionGVR(ctx), fqn) {
@@ -69,21 +76,6 @@ func (s *StatefulSet) Sanitize(ctx context.Context) error {
 	return nil
 }
 
-func (s *StatefulSet) checkDeprecation(ctx context.Context, st *appsv1.StatefulSet) {
-	const current = "apps/v1"
-
-	rev, err := resourceRev(internal.MustExtractFQN(ctx), "StatefulSet", st.Annotations)
-	if err != nil {
-		if rev = revFromLink(st.SelfLink); rev == "" {
-			return
-		}
-	}
-
-	if rev != current {
-		s.AddCode(ctx, 403, "StatefulSet", rev, current)
-	}
-}
-
 func (s *StatefulSet) checkStatefulSet(ctx context.Context, sts *appsv1.StatefulSet) {
 	if sts.Spec.Replicas == nil || (sts.Spec.Replicas != nil && *sts.Spec.Replicas == 0) {
 		s.AddCode(ctx, 500)
@@ -36,11 +36,11 @@ func TestSTSSanitizer(t *testing.T) {
 				coOpts:      coOpts{rcpu: "100m", rmem: "10Mi"},
 				replicas:    1,
 				currentReps: 1,
-				rev:         "extensions/v1",
+				rev:         "apps/v1beta2",
 				ccpu:        "100m", cmem: "10Mi",
 			}),
 			issues: issues.Issues{
-				issues.New(client.NewGVR("apps/v1/statefulsets"), issues.Root, config.WarnLevel, `[POP-403] Deprecated StatefulSet API group "extensions/v1". Use "apps/v1" instead`),
+				issues.New(client.NewGVR("apps/v1/statefulsets"), issues.Root, config.WarnLevel, `[POP-403] Deprecated StatefulSet API group "apps/v1beta2" removed in v1.16. Use "apps/v1/statefulsets" instead`),
 			},
 		},
 		"unhealthy": {
@@ -296,10 +296,18 @@ func (s *sts) ListPodsBySelector(ns string, sel *metav1.LabelSelector) map[strin
 	}
 }
 
+func (s *sts) ListNamespaces() map[string]*v1.Namespace {
+	return map[string]*v1.Namespace{}
+}
+
 func (s *sts) ListServiceAccounts() map[string]*v1.ServiceAccount {
 	return nil
 }
 
+func (s *sts) ListNodes() map[string]*v1.Node {
+	return map[string]*v1.Node{}
+}
+
 func (s *sts) ListPodsMetrics() map[string]*mv1beta1.PodMetrics {
 	return map[string]*mv1beta1.PodMetrics{
 		"default/p1": makeMxPod(s.opts.ccpu, s.opts.cmem),
@@ -307,7 +315,7 @@ func (s *sts) ListPodsMetrics() map[string]*mv1beta1.PodMetrics {
 }
 
 func makeSTS(n string, opts stsOpts) *appsv1.StatefulSet {
-	return &appsv1.StatefulSet{
+	st := appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      n,
 			Namespace: "default",
@@ -363,4 +371,7 @@ func makeSTS(n string, opts stsOpts) *appsv1.StatefulSet {
 			CollisionCount:  &opts.collisions,
 		},
 	}
+	hardenTemplate(&st.Spec.Template)
+
+	return &st
 }
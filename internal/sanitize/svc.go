@@ -112,15 +112,18 @@ func (s *Service) checkExternalTrafficPolicy(ctx context.Context, kind v1.Servic
 
 // CheckEndpoints runs a sanity check on this service endpoints.
 func (s *Service) checkEndpoints(ctx context.Context, sel map[string]string, kind v1.ServiceType) {
-	// Service may not have selectors.
-	if len(sel) == 0 {
-		return
-	}
 	// External service bail -> no EPs.
 	if kind == v1.ServiceTypeExternalName {
 		return
 	}
 	ep := s.GetEndpoints(internal.MustExtractFQN(ctx))
+	// Service may not have selectors as long as its endpoints are managed manually.
+	if len(sel) == 0 {
+		if ep == nil || len(ep.Subsets) == 0 {
+			s.AddCode(ctx, 1110)
+		}
+		return
+	}
 	if ep == nil || len(ep.Subsets) == 0 {
 		s.AddCode(ctx, 1105)
 		return
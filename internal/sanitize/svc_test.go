@@ -79,6 +79,14 @@ func TestSVCSanitize(t *testing.T) {
 			),
 			0,
 		},
+		"noSelectorNoEp": {
+			makeSvcLister(
+				svcOpts{
+					kind: v1.ServiceTypeClusterIP,
+				},
+			),
+			1,
+		},
 		"externalSvc": {
 			makeSvcLister(
 				svcOpts{
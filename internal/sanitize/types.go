@@ -36,6 +36,8 @@ type PodLimiter interface {
 
 type ContainerRestrictor interface {
 	AllowedRegistries() []string
+	ImagePolicy() config.Images
+	AllowedEnv(name string) bool
 }
 
 // PodSelectorLister list a collection of pod matching a selector.
@@ -0,0 +1,73 @@
+package sanitize
+
+import (
+	"context"
+	"strings"
+
+	"github.com/derailed/popeye/internal"
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/vuln"
+	v1 "k8s.io/api/core/v1"
+)
+
+// MaxVulnIDs tracks the number of vulnerability ids listed in an issue.
+const maxVulnIDs = 5
+
+// CheckVulnerabilities checks pod container images against scanned images vulnerabilities.
+func (p *Pod) checkVulnerabilities(ctx context.Context, fqn string, po *v1.Pod) {
+	imageIDs := make(map[string]string, len(po.Status.InitContainerStatuses)+len(po.Status.ContainerStatuses))
+	for _, s := range po.Status.InitContainerStatuses {
+		imageIDs[s.Name] = s.ImageID
+	}
+	for _, s := range po.Status.ContainerStatuses {
+		imageIDs[s.Name] = s.ImageID
+	}
+
+	gvr := internal.MustExtractSectionGVR(ctx)
+	for _, cc := range [][]v1.Container{po.Spec.InitContainers, po.Spec.Containers} {
+		for _, co := range cc {
+			if p.Config.ExcludeContainer(gvr, fqn, co.Name) {
+				continue
+			}
+			vv := p.Config.ImageVulnerabilities(co.Image, imageIDs[co.Name])
+			if len(vv) == 0 {
+				continue
+			}
+			p.reportVulnerabilities(internal.WithGroup(ctx, client.NewGVR("containers"), co.Name), co.Image, vv)
+		}
+	}
+}
+
+// ReportVulnerabilities reports a container image vulnerabilities ids by severity.
+func (p *Pod) reportVulnerabilities(ctx context.Context, image string, vv []vuln.Vulnerability) {
+	ids, seen := make(map[string][]string), make(map[string]struct{}, len(vv))
+	for _, v := range vv {
+		if _, ok := seen[v.ID]; ok {
+			continue
+		}
+		seen[v.ID] = struct{}{}
+		ids[v.Severity] = append(ids[v.Severity], v.ID)
+	}
+	for _, sev := range []string{vuln.Critical, vuln.High, vuln.Medium, vuln.Low, vuln.Unknown} {
+		ii := ids[sev]
+		if len(ii) == 0 {
+			continue
+		}
+		if sev == vuln.Critical {
+			p.AddSubCode(ctx, 122, image, len(ii), vulnIDs(ii))
+			continue
+		}
+		p.AddSubCode(ctx, 123, image, len(ii), strings.ToLower(sev), vulnIDs(ii))
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func vulnIDs(ids []string) string {
+	if len(ids) <= maxVulnIDs {
+		return strings.Join(ids, ", ")
+	}
+
+	return strings.Join(ids[:maxVulnIDs], ", ") + ", ..."
+}
@@ -0,0 +1,92 @@
+package sanitize
+
+import (
+	"testing"
+
+	"github.com/derailed/popeye/internal"
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/internal/vuln"
+	"github.com/derailed/popeye/pkg/config"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestPodCheckVulnerabilities(t *testing.T) {
+	idx := vuln.NewIndex(
+		vuln.Report{
+			Images: []string{"fred:1.0"},
+			Vulnerabilities: []vuln.Vulnerability{
+				{ID: "CVE-1", Package: "p1", Fixed: "1.1", Severity: vuln.Critical},
+				{ID: "CVE-1", Package: "p2", Fixed: "1.1", Severity: vuln.Critical},
+				{ID: "CVE-2", Package: "p1", Fixed: "1.2", Severity: vuln.High},
+				{ID: "CVE-3", Package: "p1", Severity: vuln.Critical},
+				{ID: "CVE-4", Package: "p1", Fixed: "1.2", Severity: vuln.Medium},
+			},
+		},
+		vuln.Report{
+			Digests: []string{"sha256:blee"},
+			Vulnerabilities: []vuln.Vulnerability{
+				{ID: "CVE-5", Package: "p1", Fixed: "1.1", Severity: vuln.High},
+				{ID: "CVE-6", Package: "p1", Fixed: "1.1", Severity: vuln.High},
+				{ID: "CVE-7", Package: "p1", Fixed: "1.1", Severity: vuln.High},
+				{ID: "CVE-8", Package: "p1", Fixed: "1.1", Severity: vuln.High},
+				{ID: "CVE-9", Package: "p1", Fixed: "1.1", Severity: vuln.High},
+				{ID: "CVE-10", Package: "p1", Fixed: "1.1", Severity: vuln.High},
+			},
+		},
+	)
+
+	uu := map[string]struct {
+		image, imageID string
+		policy         config.Vulnerabilities
+		issues         issues.Issues
+	}{
+		"unscanned": {
+			image:  "blee:1.0",
+			issues: issues.Issues{},
+		},
+		"tag": {
+			image: "fred:1.0",
+			issues: issues.Issues{
+				issues.New(client.NewGVR("containers"), "c1", config.ErrorLevel, "[POP-122] Image fred:1.0 has 1 critical vulnerabilities with a fix available (CVE-1)"),
+				issues.New(client.NewGVR("containers"), "c1", config.WarnLevel, "[POP-123] Image fred:1.0 has 1 high vulnerabilities with a fix available (CVE-2)"),
+			},
+		},
+		"severity": {
+			image:  "fred:1.0",
+			policy: config.Vulnerabilities{Severity: "medium", Ignore: config.Patterns{"CVE-1"}},
+			issues: issues.Issues{
+				issues.New(client.NewGVR("containers"), "c1", config.WarnLevel, "[POP-123] Image fred:1.0 has 1 high vulnerabilities with a fix available (CVE-2)"),
+				issues.New(client.NewGVR("containers"), "c1", config.WarnLevel, "[POP-123] Image fred:1.0 has 1 medium vulnerabilities with a fix available (CVE-4)"),
+			},
+		},
+		"imageID": {
+			image:   "fred:1.0",
+			imageID: "docker-pullable://fred@sha256:blee",
+			issues: issues.Issues{
+				issues.New(client.NewGVR("containers"), "c1", config.WarnLevel, "[POP-123] Image fred:1.0 has 6 high vulnerabilities with a fix available (CVE-10, CVE-5, CVE-6, CVE-7, CVE-8, ...)"),
+			},
+		},
+	}
+
+	ctx := makeContext("v1/pods", "po")
+	ctx = internal.WithFQN(ctx, "default/p1")
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			cfg := makeConfig(t)
+			cfg.SetVulnIndex(idx)
+			cfg.Vulnerabilities = u.policy
+			co := issues.NewCollector(loadCodes(t), cfg)
+			co.InitOutcome("default/p1")
+			p := NewPod(co, nil)
+
+			po := makePod("p1")
+			po.Spec.Containers = []v1.Container{{Name: "c1", Image: u.image}}
+			po.Status.ContainerStatuses = []v1.ContainerStatus{{Name: "c1", ImageID: u.imageID}}
+			p.checkVulnerabilities(ctx, "default/p1", po)
+			assert.Equal(t, u.issues, p.Outcome()["default/p1"])
+		})
+	}
+}
@@ -22,6 +22,7 @@ type Cache struct {
 	*dial
 	*core
 	*apps
+	*batch
 	*rbac
 	*policy
 	*ext
@@ -35,6 +36,7 @@ func NewCache(f types.Factory, cfg *config.Config) *Cache {
 		dial:   d,
 		core:   newCore(d),
 		apps:   newApps(d),
+		batch:  newBatch(d),
 		rbac:   newRBAC(d),
 		policy: newPolicy(d),
 		ext:    newExt(d),
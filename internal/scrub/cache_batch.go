@@ -0,0 +1,71 @@
+package scrub
+
+import (
+	"context"
+	"sync"
+
+	"github.com/derailed/popeye/internal"
+	"github.com/derailed/popeye/internal/cache"
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/dag"
+)
+
+type batch struct {
+	*dial
+
+	mx  sync.Mutex
+	job *cache.Job
+	cj  *cache.CronJob
+}
+
+func newBatch(d *dial) *batch {
+	return &batch{dial: d}
+}
+
+func (b *batch) jobs() (*cache.Job, error) {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+
+	if b.job != nil {
+		return b.job, nil
+	}
+	ctx, cancel := b.context()
+	defer cancel()
+	jobs, err := dag.ListJobs(ctx)
+	b.job = cache.NewJob(jobs)
+
+	return b.job, err
+}
+
+func (b *batch) cronjobs() (*cache.CronJob, error) {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+
+	if b.cj != nil {
+		return b.cj, nil
+	}
+	ctx, cancel := b.context()
+	defer cancel()
+	cjs, err := dag.ListCronJobs(ctx)
+	b.cj = cache.NewCronJob(cjs)
+
+	return b.cj, err
+}
+
+// Helpers...
+
+func (b *batch) context() (context.Context, context.CancelFunc) {
+	ctx := context.WithValue(context.Background(), internal.KeyFactory, b.factory)
+	ctx = context.WithValue(ctx, internal.KeyConfig, b.config)
+	if b.config.Flags.ActiveNamespace != nil {
+		ctx = context.WithValue(ctx, internal.KeyNamespace, *b.config.Flags.ActiveNamespace)
+	} else {
+		ns, err := b.factory.Client().Config().CurrentNamespaceName()
+		if err != nil {
+			ns = client.AllNamespaces
+		}
+		ctx = context.WithValue(ctx, internal.KeyNamespace, ns)
+	}
+
+	return context.WithCancel(ctx)
+}
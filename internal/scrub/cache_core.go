@@ -8,6 +8,8 @@ import (
 	"github.com/derailed/popeye/internal/cache"
 	"github.com/derailed/popeye/internal/client"
 	"github.com/derailed/popeye/internal/dag"
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/internal/sanitize"
 )
 
 type core struct {
@@ -24,6 +26,8 @@ type core struct {
 	sec       *cache.Secret
 	svc       *cache.Service
 	ep        *cache.Endpoints
+	lr        *cache.LimitRange
+	quota     *cache.ResourceQuota
 }
 
 func newCore(d *dial) *core {
@@ -150,6 +154,21 @@ func (c *core) nodes() (*cache.Node, error) {
 	return c.node, err
 }
 
+// PlacementNodes returns the nodes used to resolve replicas zones. Nodes are cluster
+// scoped hence none are reported when scanning a namespace or when they can not be
+// listed, in which case zone placement checks are skipped.
+func (c *core) placementNodes() *cache.Node {
+	if c.factory.Client().ActiveNamespace() != client.AllNamespaces {
+		return cache.NewNode(nil)
+	}
+	nn, err := c.nodes()
+	if err != nil {
+		return cache.NewNode(nil)
+	}
+
+	return nn
+}
+
 func (c *core) pods() (*cache.Pod, error) {
 	c.mx.Lock()
 	defer c.mx.Unlock()
@@ -180,6 +199,58 @@ func (c *core) serviceaccounts() (*cache.ServiceAccount, error) {
 	return c.sa, err
 }
 
+func (c *core) limitranges() (*cache.LimitRange, error) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	if c.lr != nil {
+		return c.lr, nil
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	lrs, err := dag.ListLimitRanges(ctx)
+	c.lr = cache.NewLimitRange(lrs)
+
+	return c.lr, err
+}
+
+func (c *core) resourcequotas() (*cache.ResourceQuota, error) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	if c.quota != nil {
+		return c.quota, nil
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	quotas, err := dag.ListResourceQuotas(ctx)
+	c.quota = cache.NewResourceQuota(quotas)
+
+	return c.quota, err
+}
+
+// Garbage returns the unused resources flagged in a sanitizer outcome along with
+// the storage they hold.
+func (c *Cache) Garbage(gvr client.GVR, o issues.Outcome) ([]sanitize.Garbage, error) {
+	var sizes map[string]int64
+	switch gvr {
+	case client.NewGVR("v1/persistentvolumes"):
+		pvs, err := c.persistentvolumes()
+		if err != nil {
+			return nil, err
+		}
+		sizes = sanitize.PersistentVolumeSizes(pvs.ListPersistentVolumes())
+	case client.NewGVR("v1/persistentvolumeclaims"):
+		pvcs, err := c.persistentvolumeclaims()
+		if err != nil {
+			return nil, err
+		}
+		sizes = sanitize.PersistentVolumeClaimSizes(pvcs.ListPersistentVolumeClaims())
+	}
+
+	return sanitize.ComputeGarbage(gvr, o, sizes), nil
+}
+
 // Helpers...
 
 func (c *core) context() (context.Context, context.CancelFunc) {
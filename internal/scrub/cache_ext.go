@@ -16,6 +16,8 @@ type ext struct {
 	mx  sync.Mutex
 	pdb *cache.PodDisruptionBudget
 	ing *cache.Ingress
+	ic  *cache.IngressClass
+	crd *cache.CustomResourceDefinition
 	cl  *cache.Cluster
 }
 
@@ -53,6 +55,36 @@ func (e *ext) ingresses() (*cache.Ingress, error) {
 	return e.ing, err
 }
 
+func (e *ext) ingressclasses() (*cache.IngressClass, error) {
+	e.mx.Lock()
+	defer e.mx.Unlock()
+
+	if e.ic != nil {
+		return e.ic, nil
+	}
+	ctx, cancel := e.context()
+	defer cancel()
+	ics, err := dag.ListIngressClasses(ctx)
+	e.ic = cache.NewIngressClass(ics)
+
+	return e.ic, err
+}
+
+func (e *ext) customresourcedefinitions() (*cache.CustomResourceDefinition, error) {
+	e.mx.Lock()
+	defer e.mx.Unlock()
+
+	if e.crd != nil {
+		return e.crd, nil
+	}
+	ctx, cancel := e.context()
+	defer cancel()
+	crds, err := dag.ListCustomResourceDefinitions(ctx)
+	e.crd = cache.NewCustomResourceDefinition(crds)
+
+	return e.crd, err
+}
+
 func (e *ext) podDisruptionBudgets() (*cache.PodDisruptionBudget, error) {
 	e.mx.Lock()
 	defer e.mx.Unlock()
@@ -68,6 +100,41 @@ func (e *ext) podDisruptionBudgets() (*cache.PodDisruptionBudget, error) {
 	return e.pdb, err
 }
 
+// Drain tracks the resources involved in a node drain.
+type Drain struct {
+	*cache.Node
+	*cache.Pod
+	*cache.ReplicaSet
+	*cache.PodDisruptionBudget
+}
+
+// Drain returns the nodes, pods, replicasets and pod disruption budgets.
+func (c *Cache) Drain() (*Drain, error) {
+	nos, err := c.nodes()
+	if err != nil {
+		return nil, err
+	}
+	pos, err := c.pods()
+	if err != nil {
+		return nil, err
+	}
+	rss, err := c.replicasets()
+	if err != nil {
+		return nil, err
+	}
+	pdbs, err := c.podDisruptionBudgets()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Drain{
+		Node:                nos,
+		Pod:                 pos,
+		ReplicaSet:          rss,
+		PodDisruptionBudget: pdbs,
+	}, nil
+}
+
 // Helpers...
 
 func (e *ext) context() (context.Context, context.CancelFunc) {
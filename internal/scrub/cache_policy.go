@@ -8,6 +8,7 @@ import (
 	"github.com/derailed/popeye/internal/cache"
 	"github.com/derailed/popeye/internal/client"
 	"github.com/derailed/popeye/internal/dag"
+	"github.com/derailed/popeye/internal/sanitize"
 )
 
 type policy struct {
@@ -52,6 +53,24 @@ func (p *policy) networkpolicies() (*cache.NetworkPolicy, error) {
 	return p.np, err
 }
 
+// NetworkIsolation returns the network isolation summary of each namespace.
+func (c *Cache) NetworkIsolation() ([]sanitize.NetworkIsolation, error) {
+	nss, err := c.namespaces()
+	if err != nil {
+		return nil, err
+	}
+	pods, err := c.pods()
+	if err != nil {
+		return nil, err
+	}
+	nps, err := c.networkpolicies()
+	if err != nil {
+		return nil, err
+	}
+
+	return sanitize.ComputeNetworkIsolation(nss.ListNamespaces(), pods.ListPods(), nps.ListNetworkPolicies()), nil
+}
+
 // Helpers...
 
 func (p *policy) context() (context.Context, context.CancelFunc) {
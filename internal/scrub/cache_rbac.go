@@ -84,6 +84,41 @@ func (r *rbac) clusterrolebindings() (*cache.ClusterRoleBinding, error) {
 	return r.crb, err
 }
 
+// RBAC tracks the cluster roles and bindings.
+type RBAC struct {
+	*cache.ClusterRole
+	*cache.ClusterRoleBinding
+	*cache.Role
+	*cache.RoleBinding
+}
+
+// RBAC returns the cluster roles and bindings.
+func (c *Cache) RBAC() (*RBAC, error) {
+	crs, err := c.clusterroles()
+	if err != nil {
+		return nil, err
+	}
+	crbs, err := c.clusterrolebindings()
+	if err != nil {
+		return nil, err
+	}
+	ros, err := c.roles()
+	if err != nil {
+		return nil, err
+	}
+	rbs, err := c.rolebindings()
+	if err != nil {
+		return nil, err
+	}
+
+	return &RBAC{
+		ClusterRole:        crs,
+		ClusterRoleBinding: crbs,
+		Role:               ros,
+		RoleBinding:        rbs,
+	}, nil
+}
+
 // Helpers...
 
 func (r *rbac) context() (context.Context, context.CancelFunc) {
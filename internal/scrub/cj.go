@@ -0,0 +1,54 @@
+package scrub
+
+import (
+	"context"
+
+	"github.com/derailed/popeye/internal/cache"
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/internal/sanitize"
+	"github.com/derailed/popeye/pkg/config"
+	"github.com/derailed/popeye/types"
+)
+
+// CronJob represents a CronJob scruber.
+type CronJob struct {
+	*issues.Collector
+	*cache.Namespace
+	*cache.CronJob
+	*cache.Job
+	*config.Config
+
+	client types.Connection
+}
+
+// NewCronJob return a new CronJob scruber.
+func NewCronJob(ctx context.Context, c *Cache, codes *issues.Codes) Sanitizer {
+	cj := CronJob{
+		client:    c.factory.Client(),
+		Config:    c.config,
+		Collector: issues.NewCollector(codes, c.config),
+	}
+
+	var err error
+	cj.CronJob, err = c.cronjobs()
+	if err != nil {
+		cj.AddErr(ctx, err)
+	}
+
+	cj.Job, err = c.jobs()
+	if err != nil {
+		cj.AddErr(ctx, err)
+	}
+
+	cj.Namespace, err = c.namespaces()
+	if err != nil {
+		cj.AddErr(ctx, err)
+	}
+
+	return &cj
+}
+
+// Sanitize all available CronJobs.
+func (c *CronJob) Sanitize(ctx context.Context) error {
+	return sanitize.NewCronJob(c.Collector, c).Sanitize(ctx)
+}
@@ -0,0 +1,33 @@
+package scrub
+
+import (
+	"context"
+
+	"github.com/derailed/popeye/internal/cache"
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/internal/sanitize"
+)
+
+// CustomResourceDefinition represents a CustomResourceDefinition scruber.
+type CustomResourceDefinition struct {
+	*issues.Collector
+	*cache.CustomResourceDefinition
+}
+
+// NewCustomResourceDefinition return a new CustomResourceDefinition scruber.
+func NewCustomResourceDefinition(ctx context.Context, c *Cache, codes *issues.Codes) Sanitizer {
+	crd := CustomResourceDefinition{Collector: issues.NewCollector(codes, c.config)}
+
+	var err error
+	crd.CustomResourceDefinition, err = c.customresourcedefinitions()
+	if err != nil {
+		crd.AddErr(ctx, err)
+	}
+
+	return &crd
+}
+
+// Sanitize all available CustomResourceDefinitions.
+func (c *CustomResourceDefinition) Sanitize(ctx context.Context) error {
+	return sanitize.NewCustomResourceDefinition(c.Collector, c).Sanitize(ctx)
+}
@@ -13,10 +13,12 @@ import (
 // Deployment represents a Deployment scruber.
 type Deployment struct {
 	*issues.Collector
+	*cache.Namespace
 	*cache.Deployment
 	*cache.PodsMetrics
 	*cache.Pod
 	*cache.ServiceAccount
+	*cache.Node
 	*config.Config
 
 	client types.Connection
@@ -38,6 +40,8 @@ func NewDeployment(ctx context.Context, c *Cache, codes *issues.Codes) Sanitizer
 
 	d.PodsMetrics, _ = c.podsMx()
 
+	d.Node = c.placementNodes()
+
 	d.Pod, err = c.pods()
 	if err != nil {
 		d.AddErr(ctx, err)
@@ -48,6 +52,11 @@ func NewDeployment(ctx context.Context, c *Cache, codes *issues.Codes) Sanitizer
 		d.AddErr(ctx, err)
 	}
 
+	d.Namespace, err = c.namespaces()
+	if err != nil {
+		d.AddErr(ctx, err)
+	}
+
 	return &d
 }
 
@@ -13,6 +13,7 @@ import (
 // DaemonSet represents a DaemonSet scruber.
 type DaemonSet struct {
 	*issues.Collector
+	*cache.Namespace
 	*cache.DaemonSet
 	*cache.PodsMetrics
 	*cache.Pod
@@ -47,6 +48,11 @@ func NewDaemonSet(ctx context.Context, c *Cache, codes *issues.Codes) Sanitizer
 		d.AddErr(ctx, err)
 	}
 
+	d.Namespace, err = c.namespaces()
+	if err != nil {
+		d.AddErr(ctx, err)
+	}
+
 	return &d
 }
 
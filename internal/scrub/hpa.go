@@ -16,6 +16,8 @@ import (
 type HorizontalPodAutoscaler struct {
 	*issues.Collector
 	*cache.HorizontalPodAutoscaler
+	*cache.Scale
+	*cache.Namespace
 	*cache.Pod
 	*cache.Node
 	*cache.PodsMetrics
@@ -54,6 +56,11 @@ func NewHorizontalPodAutoscaler(ctx context.Context, c *Cache, codes *issues.Cod
 		h.AddErr(ctx, err)
 	}
 	h.HorizontalPodAutoscaler = cache.NewHorizontalPodAutoscaler(ss)
+	scales, err := dag.ListScales(ctx, ss)
+	if err != nil {
+		h.AddErr(ctx, err)
+	}
+	h.Scale = cache.NewScale(scales)
 
 	h.Deployment, err = c.deployments()
 	if err != nil {
@@ -83,6 +90,11 @@ func NewHorizontalPodAutoscaler(ctx context.Context, c *Cache, codes *issues.Cod
 		h.AddErr(ctx, err)
 	}
 
+	h.Namespace, err = c.namespaces()
+	if err != nil {
+		h.AddErr(ctx, err)
+	}
+
 	return &h
 }
 
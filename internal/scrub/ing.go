@@ -14,6 +14,10 @@ import (
 type Ingress struct {
 	*issues.Collector
 	*cache.Ingress
+	*cache.IngressClass
+	*cache.Service
+	*cache.Endpoints
+	*cache.Secret
 	*config.Config
 
 	client types.Connection
@@ -33,6 +37,26 @@ func NewIngress(ctx context.Context, c *Cache, codes *issues.Codes) Sanitizer {
 		d.AddErr(ctx, err)
 	}
 
+	d.IngressClass, err = c.ingressclasses()
+	if err != nil {
+		d.AddErr(ctx, err)
+	}
+
+	d.Service, err = c.services()
+	if err != nil {
+		d.AddErr(ctx, err)
+	}
+
+	d.Endpoints, err = c.endpoints()
+	if err != nil {
+		d.AddErr(ctx, err)
+	}
+
+	d.Secret, err = c.secrets()
+	if err != nil {
+		d.AddErr(ctx, err)
+	}
+
 	return &d
 }
 
@@ -0,0 +1,48 @@
+package scrub
+
+import (
+	"context"
+
+	"github.com/derailed/popeye/internal/cache"
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/internal/sanitize"
+	"github.com/derailed/popeye/pkg/config"
+	"github.com/derailed/popeye/types"
+)
+
+// Job represents a Job scruber.
+type Job struct {
+	*issues.Collector
+	*cache.Namespace
+	*cache.Job
+	*config.Config
+
+	client types.Connection
+}
+
+// NewJob return a new Job scruber.
+func NewJob(ctx context.Context, c *Cache, codes *issues.Codes) Sanitizer {
+	j := Job{
+		client:    c.factory.Client(),
+		Config:    c.config,
+		Collector: issues.NewCollector(codes, c.config),
+	}
+
+	var err error
+	j.Job, err = c.jobs()
+	if err != nil {
+		j.AddErr(ctx, err)
+	}
+
+	j.Namespace, err = c.namespaces()
+	if err != nil {
+		j.AddErr(ctx, err)
+	}
+
+	return &j
+}
+
+// Sanitize all available Jobs.
+func (j *Job) Sanitize(ctx context.Context) error {
+	return sanitize.NewJob(j.Collector, j).Sanitize(ctx)
+}
@@ -0,0 +1,39 @@
+package scrub
+
+import (
+	"context"
+
+	"github.com/derailed/popeye/internal/cache"
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/internal/sanitize"
+)
+
+// LimitRange represents a LimitRange scruber.
+type LimitRange struct {
+	*issues.Collector
+	*cache.LimitRange
+	*cache.Pod
+}
+
+// NewLimitRange return a new LimitRange scruber.
+func NewLimitRange(ctx context.Context, c *Cache, codes *issues.Codes) Sanitizer {
+	l := LimitRange{Collector: issues.NewCollector(codes, c.config)}
+
+	var err error
+	l.LimitRange, err = c.limitranges()
+	if err != nil {
+		l.AddErr(ctx, err)
+	}
+
+	l.Pod, err = c.pods()
+	if err != nil {
+		l.AddErr(ctx, err)
+	}
+
+	return &l
+}
+
+// Sanitize all available LimitRanges.
+func (l *LimitRange) Sanitize(ctx context.Context) error {
+	return sanitize.NewLimitRange(l.Collector, l).Sanitize(ctx)
+}
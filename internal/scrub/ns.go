@@ -15,6 +15,9 @@ type Namespace struct {
 	*issues.Collector
 	*cache.Namespace
 	*cache.Pod
+	*cache.LimitRange
+	*cache.ResourceQuota
+	*cache.NetworkPolicy
 }
 
 // NewNamespace return a new Namespace scruber.
@@ -32,6 +35,21 @@ func NewNamespace(ctx context.Context, c *Cache, codes *issues.Codes) Sanitizer
 		n.AddErr(ctx, err)
 	}
 
+	n.LimitRange, err = c.limitranges()
+	if err != nil {
+		n.AddErr(ctx, err)
+	}
+
+	n.ResourceQuota, err = c.resourcequotas()
+	if err != nil {
+		n.AddErr(ctx, err)
+	}
+
+	n.NetworkPolicy, err = c.networkpolicies()
+	if err != nil {
+		n.AddErr(ctx, err)
+	}
+
 	return &n
 }
 
@@ -12,11 +12,13 @@ import (
 // Pod represents a Pod scruber.
 type Pod struct {
 	*issues.Collector
+	*cache.Namespace
 	*cache.Pod
 	*cache.PodsMetrics
 	*config.Config
 	*cache.PodDisruptionBudget
 	*cache.ServiceAccount
+	*cache.NetworkPolicy
 }
 
 // NewPod return a new Pod scruber.
@@ -44,6 +46,16 @@ func NewPod(ctx context.Context, c *Cache, codes *issues.Codes) Sanitizer {
 		p.AddErr(ctx, err)
 	}
 
+	p.Namespace, err = c.namespaces()
+	if err != nil {
+		p.AddErr(ctx, err)
+	}
+
+	p.NetworkPolicy, err = c.networkpolicies()
+	if err != nil {
+		p.AddErr(ctx, err)
+	}
+
 	return &p
 }
 
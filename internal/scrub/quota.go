@@ -0,0 +1,33 @@
+package scrub
+
+import (
+	"context"
+
+	"github.com/derailed/popeye/internal/cache"
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/internal/sanitize"
+)
+
+// ResourceQuota represents a ResourceQuota scruber.
+type ResourceQuota struct {
+	*issues.Collector
+	*cache.ResourceQuota
+}
+
+// NewResourceQuota return a new ResourceQuota scruber.
+func NewResourceQuota(ctx context.Context, c *Cache, codes *issues.Codes) Sanitizer {
+	r := ResourceQuota{Collector: issues.NewCollector(codes, c.config)}
+
+	var err error
+	r.ResourceQuota, err = c.resourcequotas()
+	if err != nil {
+		r.AddErr(ctx, err)
+	}
+
+	return &r
+}
+
+// Sanitize all available ResourceQuotas.
+func (r *ResourceQuota) Sanitize(ctx context.Context) error {
+	return sanitize.NewResourceQuota(r.Collector, r).Sanitize(ctx)
+}
@@ -14,6 +14,7 @@ import (
 type ReplicaSet struct {
 	*issues.Collector
 	*cache.ReplicaSet
+	*cache.Deployment
 	*cache.Pod
 	*config.Config
 
@@ -34,6 +35,11 @@ func NewReplicaSet(ctx context.Context, c *Cache, codes *issues.Codes) Sanitizer
 		d.AddErr(ctx, err)
 	}
 
+	d.Deployment, err = c.deployments()
+	if err != nil {
+		d.AddErr(ctx, err)
+	}
+
 	d.Pod, err = c.pods()
 	if err != nil {
 		d.AddErr(ctx, err)
@@ -12,10 +12,12 @@ import (
 // StatefulSet represents a StatefulSet scruber.
 type StatefulSet struct {
 	*issues.Collector
+	*cache.Namespace
 	*cache.Pod
 	*cache.StatefulSet
 	*cache.PodsMetrics
 	*cache.ServiceAccount
+	*cache.Node
 	*config.Config
 }
 
@@ -39,11 +41,18 @@ func NewStatefulSet(ctx context.Context, c *Cache, codes *issues.Codes) Sanitize
 
 	s.PodsMetrics, _ = c.podsMx()
 
+	s.Node = c.placementNodes()
+
 	s.ServiceAccount, err = c.serviceaccounts()
 	if err != nil {
 		s.AddErr(ctx, err)
 	}
 
+	s.Namespace, err = c.namespaces()
+	if err != nil {
+		s.AddErr(ctx, err)
+	}
+
 	return &s
 }
 
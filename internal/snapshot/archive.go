@@ -0,0 +1,167 @@
+package snapshot
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/derailed/popeye/internal/offline"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	mv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+const (
+	metaFile        = "snapshot.json"
+	objectsDir      = "objects/"
+	nodeMetricsFile = "metrics/nodes.json"
+	podMetricsFile  = "metrics/pods.json"
+	jsonExt         = ".json"
+)
+
+// Save writes a snapshot archive to the given path.
+func Save(p string, s *Snapshot) error {
+	f, err := os.Create(p)
+	if err != nil {
+		return err
+	}
+	if err := Write(f, s); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// Load reads a snapshot archive from the given path.
+func Load(p string) (*Snapshot, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Read(f)
+}
+
+// Write dumps a snapshot as a gzipped tarball.
+func Write(w io.Writer, s *Snapshot) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	if err := writeJSON(tw, metaFile, s.Meta, s.Meta.CapturedAt); err != nil {
+		return err
+	}
+	kk := make([]string, 0, len(s.Objects))
+	for k := range s.Objects {
+		kk = append(kk, k)
+	}
+	sort.Strings(kk)
+	for _, k := range kk {
+		l := unstructured.UnstructuredList{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "List",
+		}}
+		for _, o := range s.Objects[k] {
+			l.Items = append(l.Items, *o)
+		}
+		if err := writeJSON(tw, objectsDir+k+jsonExt, &l, s.Meta.CapturedAt); err != nil {
+			return err
+		}
+	}
+	if s.NodeMetrics != nil {
+		if err := writeJSON(tw, nodeMetricsFile, s.NodeMetrics, s.Meta.CapturedAt); err != nil {
+			return err
+		}
+	}
+	if s.PodMetrics != nil {
+		if err := writeJSON(tw, podMetricsFile, s.PodMetrics, s.Meta.CapturedAt); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	return gz.Close()
+}
+
+// Read loads a snapshot from a gzipped tarball.
+func Read(r io.Reader) (*Snapshot, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot archive -- %w", err)
+	}
+	defer gz.Close()
+
+	var (
+		s       = New(Meta{})
+		hasMeta bool
+		tr      = tar.NewReader(gz)
+	)
+	for {
+		h, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if h.Typeflag != tar.TypeReg {
+			continue
+		}
+		switch {
+		case h.Name == metaFile:
+			hasMeta = true
+			err = json.NewDecoder(tr).Decode(&s.Meta)
+		case h.Name == nodeMetricsFile:
+			s.NodeMetrics = new(mv1beta1.NodeMetricsList)
+			err = json.NewDecoder(tr).Decode(s.NodeMetrics)
+		case h.Name == podMetricsFile:
+			s.PodMetrics = new(mv1beta1.PodMetricsList)
+			err = json.NewDecoder(tr).Decode(s.PodMetrics)
+		case strings.HasPrefix(h.Name, objectsDir) && path.Ext(h.Name) == jsonExt:
+			gvr := strings.TrimSuffix(strings.TrimPrefix(h.Name, objectsDir), jsonExt)
+			s.Objects[gvr], err = offline.Decode(tr)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to read snapshot entry %q -- %w", h.Name, err)
+		}
+	}
+	if !hasMeta {
+		return nil, fmt.Errorf("invalid snapshot archive -- missing %s", metaFile)
+	}
+
+	return s, nil
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func writeJSON(tw *tar.Writer, name string, v interface{}, t time.Time) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	h := tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(raw)),
+		ModTime: t,
+	}
+	if err := tw.WriteHeader(&h); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, bytes.NewReader(raw))
+
+	return err
+}
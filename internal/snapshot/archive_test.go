@@ -0,0 +1,94 @@
+package snapshot
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/version"
+	mv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+func TestArchiveRoundTrip(t *testing.T) {
+	s := makeSnapshot()
+	p := filepath.Join(t.TempDir(), "cluster.tgz")
+	assert.Nil(t, Save(p, s))
+
+	o, err := Load(p)
+	assert.Nil(t, err)
+	assert.Equal(t, s.Meta.Cluster, o.Meta.Cluster)
+	assert.Equal(t, s.Meta.Version, o.Meta.Version)
+	assert.True(t, s.Meta.CapturedAt.Equal(o.Meta.CapturedAt))
+	assert.Equal(t, 1, len(o.Meta.Resources))
+	assert.Equal(t, 2, len(o.Objects))
+	assert.Equal(t, "p1", o.Objects["v1/pods"][0].GetName())
+	assert.Equal(t, "dp1", o.Objects["apps/v1/deployments"][0].GetName())
+	assert.Nil(t, o.NodeMetrics)
+	assert.Equal(t, "p1", o.PodMetrics.Items[0].Name)
+}
+
+func TestReadInvalid(t *testing.T) {
+	uu := map[string]struct {
+		raw []byte
+	}{
+		"notGzip": {
+			raw: []byte("blee"),
+		},
+		"noMeta": {
+			raw: emptyArchive(t),
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			_, err := Read(bytes.NewReader(u.raw))
+			assert.Error(t, err)
+		})
+	}
+}
+
+// Helpers...
+
+func makeSnapshot() *Snapshot {
+	s := New(Meta{
+		Cluster:    "fred",
+		CapturedAt: time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC),
+		Version:    &version.Info{Major: "1", Minor: "22", GitVersion: "v1.22.3"},
+		Resources: []*metav1.APIResourceList{
+			{GroupVersion: "v1", APIResources: []metav1.APIResource{{Name: "pods", Kind: "Pod", Namespaced: true}}},
+		},
+	})
+	s.Objects["v1/pods"] = []*unstructured.Unstructured{makeUnstructured("v1", "Pod", "default", "p1")}
+	s.Objects["apps/v1/deployments"] = []*unstructured.Unstructured{makeUnstructured("apps/v1", "Deployment", "default", "dp1")}
+	s.PodMetrics = &mv1beta1.PodMetricsList{
+		Items: []mv1beta1.PodMetrics{{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "p1"}}},
+	}
+
+	return s
+}
+
+func makeUnstructured(apiVersion, kind, ns, n string) *unstructured.Unstructured {
+	var o unstructured.Unstructured
+	o.SetAPIVersion(apiVersion)
+	o.SetKind(kind)
+	o.SetNamespace(ns)
+	o.SetName(n)
+
+	return &o
+}
+
+func emptyArchive(t *testing.T) []byte {
+	var b bytes.Buffer
+	gz := gzip.NewWriter(&b)
+	assert.Nil(t, tar.NewWriter(gz).Close())
+	assert.Nil(t, gz.Close())
+
+	return b.Bytes()
+}
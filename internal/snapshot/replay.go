@@ -0,0 +1,64 @@
+package snapshot
+
+import (
+	"github.com/derailed/popeye/internal/offline"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+var (
+	nodeMetricsGVR = schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "nodes"}
+	podMetricsGVR  = schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "pods"}
+)
+
+// NewFactory returns a factory replaying a snapshot.
+func NewFactory(flags *genericclioptions.ConfigFlags, s *Snapshot) (*offline.Factory, error) {
+	v := offline.DefaultVersion
+	if s.Meta.Version != nil {
+		v = *s.Meta.Version
+	}
+	rr := s.Meta.Resources
+	if len(rr) == 0 {
+		rr = offline.DefaultResources()
+	}
+	d := offline.NewDiscovery(rr, &v)
+	conn := offline.NewConnection(offline.NewConfig(flags, s.Meta.Cluster), d, &v)
+	if s.HasMetrics() {
+		mx, err := newMetricsClient(s)
+		if err != nil {
+			return nil, err
+		}
+		conn.SetMetrics(mx)
+	}
+
+	f := offline.NewFactory(conn, d)
+	for gvr, oo := range s.Objects {
+		f.AddFor(gvr, oo...)
+	}
+
+	return f, nil
+}
+
+// NewMetricsClient serves the captured metrics.
+// Metrics resources are registered explicitly as the tracker can not guess their names from kinds.
+func newMetricsClient(s *Snapshot) (*fake.Clientset, error) {
+	mx := fake.NewSimpleClientset()
+	if s.NodeMetrics != nil {
+		for i := range s.NodeMetrics.Items {
+			if err := mx.Tracker().Create(nodeMetricsGVR, &s.NodeMetrics.Items[i], ""); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if s.PodMetrics != nil {
+		for i := range s.PodMetrics.Items {
+			o := &s.PodMetrics.Items[i]
+			if err := mx.Tracker().Create(podMetricsGVR, o, o.Namespace); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return mx, nil
+}
@@ -0,0 +1,46 @@
+package snapshot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestNewFactory(t *testing.T) {
+	f, err := NewFactory(genericclioptions.NewConfigFlags(false), makeSnapshot())
+	assert.Nil(t, err)
+
+	assert.Equal(t, "fred", f.Client().ActiveCluster())
+	v, err := f.Client().ServerVersion()
+	assert.Nil(t, err)
+	assert.Equal(t, "22", v.Minor)
+
+	oo, err := f.List("apps/v1/deployments", "default", false, labels.Everything())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(oo))
+
+	assert.True(t, f.Client().HasMetrics())
+	mx, err := f.Client().MXDial()
+	assert.Nil(t, err)
+	pmx, err := mx.MetricsV1beta1().PodMetricses("").List(context.Background(), metav1.ListOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(pmx.Items))
+	nmx, err := mx.MetricsV1beta1().NodeMetricses().List(context.Background(), metav1.ListOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(nmx.Items))
+}
+
+func TestNewFactoryNoMetrics(t *testing.T) {
+	s := makeSnapshot()
+	s.PodMetrics = nil
+	f, err := NewFactory(genericclioptions.NewConfigFlags(false), s)
+	assert.Nil(t, err)
+
+	assert.False(t, f.Client().HasMetrics())
+	_, err = f.Client().MXDial()
+	assert.Error(t, err)
+}
@@ -0,0 +1,129 @@
+package snapshot
+
+import (
+	"context"
+	"time"
+
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/types"
+	"github.com/rs/zerolog/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery"
+	mv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+// Meta represents snapshot metadata.
+type Meta struct {
+	Cluster    string                    `json:"cluster"`
+	Namespace  string                    `json:"namespace,omitempty"`
+	CapturedAt time.Time                 `json:"capturedAt"`
+	Version    *version.Info             `json:"version"`
+	Resources  []*metav1.APIResourceList `json:"resources"`
+}
+
+// Snapshot represents a point in time cluster capture.
+type Snapshot struct {
+	Meta        Meta
+	Objects     map[string][]*unstructured.Unstructured
+	NodeMetrics *mv1beta1.NodeMetricsList
+	PodMetrics  *mv1beta1.PodMetricsList
+}
+
+// New returns a new instance.
+func New(m Meta) *Snapshot {
+	return &Snapshot{
+		Meta:    m,
+		Objects: make(map[string][]*unstructured.Unstructured),
+	}
+}
+
+// HasMetrics checks if metrics were captured.
+func (s *Snapshot) HasMetrics() bool {
+	return s.NodeMetrics != nil || s.PodMetrics != nil
+}
+
+// Capture records the given resources, metrics and server info.
+func Capture(ctx context.Context, c types.Connection, gvrs []string) (*Snapshot, error) {
+	info, err := c.ServerVersion()
+	if err != nil {
+		return nil, err
+	}
+	disco, err := c.CachedDiscovery()
+	if err != nil {
+		return nil, err
+	}
+	rr, err := disco.ServerPreferredResources()
+	if err != nil {
+		if !discovery.IsGroupDiscoveryFailedError(err) {
+			return nil, err
+		}
+		log.Warn().Err(err).Msg("Partial discovery")
+	}
+
+	ns := c.ActiveNamespace()
+	s := New(Meta{
+		Cluster:    c.ActiveCluster(),
+		Namespace:  ns,
+		CapturedAt: time.Now().UTC(),
+		Version:    info,
+		Resources:  rr,
+	})
+
+	dial, err := c.DynDial()
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range gvrs {
+		gvr := client.NewGVR(k)
+		var ll *unstructured.UnstructuredList
+		if client.IsNamespaced(ns) && isNamespaced(rr, gvr) {
+			ll, err = dial.Resource(gvr.GVR()).Namespace(ns).List(ctx, metav1.ListOptions{})
+		} else {
+			ll, err = dial.Resource(gvr.GVR()).List(ctx, metav1.ListOptions{})
+		}
+		if err != nil {
+			return nil, err
+		}
+		oo := make([]*unstructured.Unstructured, 0, len(ll.Items))
+		for i := range ll.Items {
+			oo = append(oo, &ll.Items[i])
+		}
+		s.Objects[k] = oo
+	}
+
+	if !c.HasMetrics() {
+		return s, nil
+	}
+	mx, err := c.MXDial()
+	if err != nil {
+		return nil, err
+	}
+	if s.NodeMetrics, err = mx.MetricsV1beta1().NodeMetricses().List(ctx, metav1.ListOptions{}); err != nil {
+		return nil, err
+	}
+	if s.PodMetrics, err = mx.MetricsV1beta1().PodMetricses(ns).List(ctx, metav1.ListOptions{}); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func isNamespaced(rr []*metav1.APIResourceList, gvr client.GVR) bool {
+	for _, r := range rr {
+		if r.GroupVersion != gvr.GV().String() {
+			continue
+		}
+		for _, res := range r.APIResources {
+			if res.Name == gvr.R() {
+				return res.Namespaced
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,98 @@
+package snapshot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/derailed/popeye/internal/offline"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/dynamic"
+	dynfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	mv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	mxfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+func TestCapture(t *testing.T) {
+	uu := map[string]struct {
+		ns      string
+		metrics bool
+		pods    int
+	}{
+		"all": {
+			pods: 2,
+		},
+		"namespaced": {
+			ns:   "fred",
+			pods: 1,
+		},
+		"metrics": {
+			metrics: true,
+			pods:    2,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			c := makeConnection(u.ns, u.metrics)
+			s, err := Capture(context.Background(), c, []string{"v1/pods", "v1/nodes"})
+			assert.Nil(t, err)
+
+			assert.Equal(t, "test", s.Meta.Cluster)
+			assert.Equal(t, "23", s.Meta.Version.Minor)
+			assert.Equal(t, u.pods, len(s.Objects["v1/pods"]))
+			assert.Equal(t, 1, len(s.Objects["v1/nodes"]))
+			assert.Equal(t, u.metrics, s.HasMetrics())
+		})
+	}
+}
+
+// Helpers...
+
+type testConnection struct {
+	*offline.Connection
+
+	dial dynamic.Interface
+}
+
+func (c *testConnection) DynDial() (dynamic.Interface, error) {
+	return c.dial, nil
+}
+
+func makeConnection(ns string, metrics bool) *testConnection {
+	flags := genericclioptions.NewConfigFlags(false)
+	flags.Namespace = &ns
+	v := offline.DefaultVersion
+	conn := offline.NewConnection(offline.NewConfig(flags, "test"), offline.NewDiscovery(offline.DefaultResources(), &v), &v)
+	if metrics {
+		mx := mxfake.NewSimpleClientset()
+		_ = mx.Tracker().Create(nodeMetricsGVR, &mv1beta1.NodeMetrics{ObjectMeta: metav1.ObjectMeta{Name: "n1"}}, "")
+		conn.SetMetrics(mx)
+	}
+
+	return &testConnection{
+		Connection: conn,
+		dial: dynfake.NewSimpleDynamicClientWithCustomListKinds(
+			scheme.Scheme,
+			map[schema.GroupVersionResource]string{
+				{Version: "v1", Resource: "pods"}:  "PodList",
+				{Version: "v1", Resource: "nodes"}: "NodeList",
+			},
+			makeObjects()...,
+		),
+	}
+}
+
+func makeObjects() []runtime.Object {
+	return []runtime.Object{
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "fred", Name: "p1"}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "blee", Name: "p2"}},
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n1"}},
+	}
+}
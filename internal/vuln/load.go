@@ -0,0 +1,162 @@
+package vuln
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type (
+	// TrivyReport represents a Trivy image scan json report.
+	trivyReport struct {
+		ArtifactName string `json:"ArtifactName"`
+		Metadata     struct {
+			ImageID     string   `json:"ImageID"`
+			RepoTags    []string `json:"RepoTags"`
+			RepoDigests []string `json:"RepoDigests"`
+		} `json:"Metadata"`
+		Results []struct {
+			Vulnerabilities []struct {
+				VulnerabilityID  string `json:"VulnerabilityID"`
+				PkgName          string `json:"PkgName"`
+				InstalledVersion string `json:"InstalledVersion"`
+				FixedVersion     string `json:"FixedVersion"`
+				Severity         string `json:"Severity"`
+			} `json:"Vulnerabilities"`
+		} `json:"Results"`
+	}
+
+	// GrypeReport represents a Grype image scan json report.
+	grypeReport struct {
+		Matches []struct {
+			Vulnerability struct {
+				ID       string `json:"id"`
+				Severity string `json:"severity"`
+				Fix      struct {
+					Versions []string `json:"versions"`
+					State    string   `json:"state"`
+				} `json:"fix"`
+			} `json:"vulnerability"`
+			Artifact struct {
+				Name    string `json:"name"`
+				Version string `json:"version"`
+			} `json:"artifact"`
+		} `json:"matches"`
+		Source struct {
+			Type   string          `json:"type"`
+			Target json.RawMessage `json:"target"`
+		} `json:"source"`
+	}
+
+	// GrypeImage represents a Grype image scan target.
+	grypeImage struct {
+		UserInput   string   `json:"userInput"`
+		ImageID     string   `json:"imageID"`
+		Tags        []string `json:"tags"`
+		RepoDigests []string `json:"repoDigests"`
+	}
+)
+
+// Load loads all Trivy or Grype json reports from a directory.
+func Load(dir string) (*Index, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, err
+	}
+	ff, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(ff)
+	rr := make([]Report, 0, len(ff))
+	for _, f := range ff {
+		raw, err := ioutil.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		r, err := Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(f), err)
+		}
+		rr = append(rr, r)
+	}
+
+	return NewIndex(rr...), nil
+}
+
+// Parse parses a Trivy or Grype json report.
+func Parse(raw []byte) (Report, error) {
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &probe); err != nil {
+		return Report{}, err
+	}
+	if _, ok := probe["matches"]; ok {
+		return parseGrype(raw)
+	}
+	if _, ok := probe["ArtifactName"]; ok {
+		return parseTrivy(raw)
+	}
+
+	return Report{}, fmt.Errorf("unsupported vulnerability report format")
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func parseTrivy(raw []byte) (Report, error) {
+	var tr trivyReport
+	if err := json.Unmarshal(raw, &tr); err != nil {
+		return Report{}, err
+	}
+	r := Report{
+		Images:  append([]string{tr.ArtifactName}, tr.Metadata.RepoTags...),
+		Digests: append([]string{tr.Metadata.ImageID}, tr.Metadata.RepoDigests...),
+	}
+	for _, res := range tr.Results {
+		for _, v := range res.Vulnerabilities {
+			r.Vulnerabilities = append(r.Vulnerabilities, Vulnerability{
+				ID:        v.VulnerabilityID,
+				Package:   v.PkgName,
+				Installed: v.InstalledVersion,
+				Fixed:     v.FixedVersion,
+				Severity:  NormalizeSeverity(v.Severity),
+			})
+		}
+	}
+
+	return r, nil
+}
+
+func parseGrype(raw []byte) (Report, error) {
+	var gr grypeReport
+	if err := json.Unmarshal(raw, &gr); err != nil {
+		return Report{}, err
+	}
+	var r Report
+	if gr.Source.Type == "image" {
+		var img grypeImage
+		if err := json.Unmarshal(gr.Source.Target, &img); err != nil {
+			return Report{}, err
+		}
+		r.Images = append([]string{img.UserInput}, img.Tags...)
+		r.Digests = append([]string{img.ImageID}, img.RepoDigests...)
+	}
+	for _, m := range gr.Matches {
+		var fixed string
+		if m.Vulnerability.Fix.State == "fixed" {
+			fixed = strings.Join(m.Vulnerability.Fix.Versions, ", ")
+		}
+		r.Vulnerabilities = append(r.Vulnerabilities, Vulnerability{
+			ID:        m.Vulnerability.ID,
+			Package:   m.Artifact.Name,
+			Installed: m.Artifact.Version,
+			Fixed:     fixed,
+			Severity:  NormalizeSeverity(m.Vulnerability.Severity),
+		})
+	}
+
+	return r, nil
+}
@@ -0,0 +1,74 @@
+package vuln
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	idx, err := Load("testdata/reports")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(idx.Lookup("nginx:1.21", "")))
+	assert.Equal(t, 3, len(idx.Lookup("bitnami/redis:7.0", "")))
+}
+
+func TestLoadToast(t *testing.T) {
+	_, err := Load("testdata/toast")
+
+	assert.NotNil(t, err)
+}
+
+func TestParse(t *testing.T) {
+	uu := map[string]struct {
+		raw string
+		e   Report
+		err string
+	}{
+		"trivy": {
+			raw: `{"ArtifactName": "fred:1.0", "Metadata": {"ImageID": "sha256:abc", "RepoDigests": ["fred@sha256:def"]}, "Results": [{"Vulnerabilities": [{"VulnerabilityID": "CVE-1", "PkgName": "p1", "InstalledVersion": "1.0", "FixedVersion": "1.1", "Severity": "HIGH"}]}]}`,
+			e: Report{
+				Images:  []string{"fred:1.0"},
+				Digests: []string{"sha256:abc", "fred@sha256:def"},
+				Vulnerabilities: []Vulnerability{
+					{ID: "CVE-1", Package: "p1", Installed: "1.0", Fixed: "1.1", Severity: High},
+				},
+			},
+		},
+		"grype": {
+			raw: `{"matches": [{"vulnerability": {"id": "CVE-1", "severity": "Negligible", "fix": {"versions": ["1.1", "1.2"], "state": "fixed"}}, "artifact": {"name": "p1", "version": "1.0"}}], "source": {"type": "image", "target": {"userInput": "fred:1.0", "imageID": "sha256:abc"}}}`,
+			e: Report{
+				Images:  []string{"fred:1.0"},
+				Digests: []string{"sha256:abc"},
+				Vulnerabilities: []Vulnerability{
+					{ID: "CVE-1", Package: "p1", Installed: "1.0", Fixed: "1.1, 1.2", Severity: Unknown},
+				},
+			},
+		},
+		"grypeDir": {
+			raw: `{"matches": [], "source": {"type": "directory", "target": "/fred"}}`,
+			e:   Report{},
+		},
+		"unsupported": {
+			raw: `{"fred": "blee"}`,
+			err: "unsupported vulnerability report format",
+		},
+		"toast": {
+			raw: `{"fred"`,
+			err: "unexpected end of JSON input",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			r, err := Parse([]byte(u.raw))
+			if err != nil {
+				assert.Equal(t, u.err, err.Error())
+				return
+			}
+			assert.Equal(t, u.e, r)
+		})
+	}
+}
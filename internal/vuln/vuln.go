@@ -0,0 +1,154 @@
+package vuln
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/derailed/popeye/internal/image"
+)
+
+// Vulnerability severities.
+const (
+	Critical = "CRITICAL"
+	High     = "HIGH"
+	Medium   = "MEDIUM"
+	Low      = "LOW"
+	Unknown  = "UNKNOWN"
+)
+
+var severityRanks = map[string]int{
+	Unknown:  0,
+	Low:      1,
+	Medium:   2,
+	High:     3,
+	Critical: 4,
+}
+
+type (
+	// Vulnerability represents a vulnerability found in an image package.
+	Vulnerability struct {
+		ID        string
+		Package   string
+		Installed string
+		Fixed     string
+		Severity  string
+	}
+
+	// Report represents a scanner report for a given image.
+	Report struct {
+		Images          []string
+		Digests         []string
+		Vulnerabilities []Vulnerability
+	}
+
+	// Index tracks vulnerabilities by image reference and digest.
+	Index struct {
+		refs    map[string][]Vulnerability
+		digests map[string][]Vulnerability
+	}
+)
+
+// NewIndex returns a new index for the given reports.
+func NewIndex(rr ...Report) *Index {
+	idx := Index{
+		refs:    make(map[string][]Vulnerability),
+		digests: make(map[string][]Vulnerability),
+	}
+	for _, r := range rr {
+		for _, img := range r.Images {
+			ref := image.Parse(img)
+			if ref.Digest != "" {
+				idx.digests[ref.Digest] = append(idx.digests[ref.Digest], r.Vulnerabilities...)
+			}
+			if k := refKey(ref); k != "" {
+				idx.refs[k] = append(idx.refs[k], r.Vulnerabilities...)
+			}
+		}
+		for _, d := range r.Digests {
+			if d = digestOf(d); d != "" {
+				idx.digests[d] = append(idx.digests[d], r.Vulnerabilities...)
+			}
+		}
+	}
+
+	return &idx
+}
+
+// Lookup returns the vulnerabilities of a container image. The image digest or
+// the runtime image id are preferred over the image tag if they were scanned.
+func (i *Index) Lookup(img, imageID string) []Vulnerability {
+	if i == nil {
+		return nil
+	}
+	ref := image.Parse(img)
+	for _, d := range []string{ref.Digest, digestOf(imageID)} {
+		if vv, ok := i.digests[d]; ok && d != "" {
+			return dedup(vv)
+		}
+	}
+
+	return dedup(i.refs[refKey(ref)])
+}
+
+// NormalizeSeverity returns a scanner severity in upper case, defaulting to unknown.
+func NormalizeSeverity(s string) string {
+	s = strings.ToUpper(s)
+	if _, ok := severityRanks[s]; !ok {
+		return Unknown
+	}
+
+	return s
+}
+
+// AtLeast checks if a severity is at or above the given threshold.
+func AtLeast(sev, threshold string) bool {
+	return severityRanks[NormalizeSeverity(sev)] >= severityRanks[NormalizeSeverity(threshold)]
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func refKey(ref image.Ref) string {
+	if ref.Tag == "" {
+		return ""
+	}
+
+	return ref.Name() + ":" + ref.Tag
+}
+
+// DigestOf extracts the digest of an image reference or a runtime image id
+// ie docker-pullable://nginx@sha256:xxx or sha256:xxx.
+func digestOf(s string) string {
+	if i := strings.LastIndex(s, "@"); i >= 0 {
+		return s[i+1:]
+	}
+	if i := strings.Index(s, "://"); i >= 0 {
+		s = s[i+3:]
+	}
+	if strings.HasPrefix(s, "sha256:") {
+		return s
+	}
+
+	return ""
+}
+
+func dedup(vv []Vulnerability) []Vulnerability {
+	if len(vv) == 0 {
+		return nil
+	}
+	seen := make(map[string]struct{}, len(vv))
+	res := make([]Vulnerability, 0, len(vv))
+	for _, v := range vv {
+		k := v.ID + "/" + v.Package
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		res = append(res, v)
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].ID < res[j].ID
+	})
+
+	return res
+}
@@ -0,0 +1,88 @@
+package vuln
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexLookup(t *testing.T) {
+	idx := NewIndex(
+		Report{
+			Images:          []string{"nginx:1.21"},
+			Digests:         []string{"sha256:605c", "nginx@sha256:2834"},
+			Vulnerabilities: []Vulnerability{{ID: "CVE-2", Package: "p1"}, {ID: "CVE-1", Package: "p1"}, {ID: "CVE-2", Package: "p1"}},
+		},
+		Report{
+			Images:          []string{"docker.io/library/nginx:1.22"},
+			Vulnerabilities: []Vulnerability{{ID: "CVE-3", Package: "p2"}},
+		},
+	)
+
+	uu := map[string]struct {
+		image, imageID string
+		e              []Vulnerability
+	}{
+		"tag": {
+			image: "docker.io/library/nginx:1.22",
+			e:     []Vulnerability{{ID: "CVE-3", Package: "p2"}},
+		},
+		"shortTag": {
+			image: "nginx:1.22",
+			e:     []Vulnerability{{ID: "CVE-3", Package: "p2"}},
+		},
+		"repoDigest": {
+			image: "nginx@sha256:2834",
+			e:     []Vulnerability{{ID: "CVE-1", Package: "p1"}, {ID: "CVE-2", Package: "p1"}},
+		},
+		"imageID": {
+			image:   "nginx:latest",
+			imageID: "docker-pullable://nginx@sha256:2834",
+			e:       []Vulnerability{{ID: "CVE-1", Package: "p1"}, {ID: "CVE-2", Package: "p1"}},
+		},
+		"runtimeID": {
+			image:   "nginx:latest",
+			imageID: "docker://sha256:605c",
+			e:       []Vulnerability{{ID: "CVE-1", Package: "p1"}, {ID: "CVE-2", Package: "p1"}},
+		},
+		"digestOverTag": {
+			image:   "nginx:1.22",
+			imageID: "sha256:605c",
+			e:       []Vulnerability{{ID: "CVE-1", Package: "p1"}, {ID: "CVE-2", Package: "p1"}},
+		},
+		"unscanned": {
+			image:   "nginx:1.23",
+			imageID: "sha256:fred",
+		},
+		"untagged": {
+			image: "nginx",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, idx.Lookup(u.image, u.imageID))
+		})
+	}
+}
+
+func TestAtLeast(t *testing.T) {
+	uu := map[string]struct {
+		sev, threshold string
+		e              bool
+	}{
+		"equal":   {sev: High, threshold: High, e: true},
+		"above":   {sev: Critical, threshold: "high", e: true},
+		"below":   {sev: "Medium", threshold: High},
+		"unknown": {sev: "fred", threshold: Low},
+		"blank":   {sev: Low, threshold: "", e: true},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, AtLeast(u.sev, u.threshold))
+		})
+	}
+}
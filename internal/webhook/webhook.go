@@ -0,0 +1,222 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/derailed/popeye/internal"
+	"github.com/derailed/popeye/internal/cache"
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/internal/sanitize"
+	"github.com/derailed/popeye/pkg/config"
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	nv1 "k8s.io/api/networking/v1"
+	polv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	mv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+// DefaultCodes tracks the sanitizer codes evaluated at admission.
+var DefaultCodes = []config.ID{100, 101, 106, 302, 306}
+
+// Webhook validates workloads at admission time using the pod sanitizers.
+type Webhook struct {
+	config *config.Config
+	codes  *issues.Codes
+	level  config.Level
+	ids    map[config.ID]struct{}
+}
+
+// New returns a new instance. Issues at or above the given level deny the
+// admission, lower severity issues are returned as warnings.
+func New(cfg *config.Config, codes *issues.Codes, level config.Level, ids []config.ID) *Webhook {
+	if len(ids) == 0 {
+		ids = DefaultCodes
+	}
+	w := Webhook{
+		config: cfg,
+		codes:  codes,
+		level:  level,
+		ids:    make(map[config.ID]struct{}, len(ids)),
+	}
+	for _, id := range ids {
+		w.ids[id] = struct{}{}
+	}
+
+	return &w
+}
+
+// ServeHTTP handles an AdmissionReview request.
+func (w *Webhook) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		rw.Header().Set("Allow", http.MethodPost)
+		http.Error(rw, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var ar admissionv1.AdmissionReview
+	if err := json.NewDecoder(r.Body).Decode(&ar); err != nil {
+		http.Error(rw, fmt.Sprintf("Invalid admission review -- %v", err), http.StatusBadRequest)
+		return
+	}
+	if ar.Request == nil {
+		http.Error(rw, "Invalid admission review -- no request", http.StatusBadRequest)
+		return
+	}
+
+	ar.Response, ar.Request = w.Review(ar.Request), nil
+	rw.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(rw).Encode(ar)
+}
+
+// Review sanitizes the admitted resource.
+func (w *Webhook) Review(req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	resp := admissionv1.AdmissionResponse{UID: req.UID, Allowed: true}
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return &resp
+	}
+
+	gvr, po, err := toPod(req)
+	if err != nil {
+		resp.Allowed = false
+		resp.Result = &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Code:    http.StatusBadRequest,
+			Reason:  metav1.StatusReasonBadRequest,
+			Message: err.Error(),
+		}
+		return &resp
+	}
+	if po == nil {
+		return &resp
+	}
+
+	var denied []string
+	for _, i := range w.sanitize(gvr, po) {
+		msg := i.Message
+		if i.IsSubIssue() {
+			msg = i.Group + ": " + msg
+		}
+		if i.Level >= w.level {
+			denied = append(denied, msg)
+			continue
+		}
+		resp.Warnings = append(resp.Warnings, msg)
+	}
+	if len(denied) > 0 {
+		resp.Allowed = false
+		resp.Result = &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Code:    http.StatusForbidden,
+			Reason:  metav1.StatusReasonForbidden,
+			Message: fmt.Sprintf("Popeye denied %s %s -- %v", req.Kind.Kind, cache.FQN(po.Namespace, po.Name), denied),
+		}
+	}
+
+	return &resp
+}
+
+func (w *Webhook) sanitize(gvr client.GVR, po *v1.Pod) issues.Issues {
+	fqn := cache.FQN(po.Namespace, po.Name)
+	ctx := context.WithValue(context.Background(), internal.KeyRunInfo, internal.RunInfo{Section: gvr.R(), SectionGVR: gvr})
+	l := lister{
+		Pod:                 cache.NewPod(map[string]*v1.Pod{fqn: po}),
+		PodsMetrics:         cache.NewPodsMetrics(map[string]*mv1beta1.PodMetrics{}),
+		Config:              w.config,
+		PodDisruptionBudget: cache.NewPodDisruptionBudget(map[string]*polv1beta1.PodDisruptionBudget{}),
+		ServiceAccount:      cache.NewServiceAccount(map[string]*v1.ServiceAccount{}),
+		Namespace:           cache.NewNamespace(map[string]*v1.Namespace{}),
+		NetworkPolicy:       cache.NewNetworkPolicy(map[string]*nv1.NetworkPolicy{}),
+	}
+	p := sanitize.NewPod(issues.NewCollector(w.codes, w.config), &l)
+	p.SanitizeSpec(ctx, fqn, po)
+
+	var ii issues.Issues
+	for _, i := range p.Outcome()[fqn] {
+		if id, ok := i.Code(); ok {
+			if _, ok := w.ids[id]; ok {
+				ii = append(ii, i)
+			}
+		}
+	}
+	sort.SliceStable(ii, func(a, b int) bool {
+		return ii[a].Level > ii[b].Level
+	})
+
+	return ii
+}
+
+// lister serves the admitted pod to the pod sanitizer.
+type lister struct {
+	*cache.Pod
+	*cache.PodsMetrics
+	*config.Config
+	*cache.PodDisruptionBudget
+	*cache.ServiceAccount
+	*cache.Namespace
+	*cache.NetworkPolicy
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+// toPod extracts the pod template of a supported workload or nil otherwise.
+func toPod(req *admissionv1.AdmissionRequest) (client.GVR, *v1.Pod, error) {
+	var (
+		gvr  client.GVR
+		meta metav1.ObjectMeta
+		spec v1.PodSpec
+		refs []metav1.OwnerReference
+	)
+	switch req.Kind.Group + "/" + req.Kind.Kind {
+	case "/Pod":
+		var o v1.Pod
+		if err := json.Unmarshal(req.Object.Raw, &o); err != nil {
+			return gvr, nil, err
+		}
+		gvr, meta, spec, refs = client.NewGVR("v1/pods"), o.ObjectMeta, o.Spec, o.OwnerReferences
+	case "apps/Deployment":
+		var o appsv1.Deployment
+		if err := json.Unmarshal(req.Object.Raw, &o); err != nil {
+			return gvr, nil, err
+		}
+		gvr, meta, spec = client.NewGVR("apps/v1/deployments"), o.ObjectMeta, o.Spec.Template.Spec
+	case "apps/StatefulSet":
+		var o appsv1.StatefulSet
+		if err := json.Unmarshal(req.Object.Raw, &o); err != nil {
+			return gvr, nil, err
+		}
+		gvr, meta, spec = client.NewGVR("apps/v1/statefulsets"), o.ObjectMeta, o.Spec.Template.Spec
+	case "apps/DaemonSet":
+		var o appsv1.DaemonSet
+		if err := json.Unmarshal(req.Object.Raw, &o); err != nil {
+			return gvr, nil, err
+		}
+		gvr, meta, spec = client.NewGVR("apps/v1/daemonsets"), o.ObjectMeta, o.Spec.Template.Spec
+	default:
+		return gvr, nil, nil
+	}
+
+	name := meta.Name
+	if name == "" {
+		name = req.Name
+	}
+	if name == "" {
+		name = meta.GenerateName
+	}
+	ns := meta.Namespace
+	if ns == "" {
+		ns = req.Namespace
+	}
+
+	return gvr, &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns, Labels: meta.Labels, OwnerReferences: refs},
+		Spec:       spec,
+	}, nil
+}
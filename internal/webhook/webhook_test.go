@@ -0,0 +1,223 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/pkg/config"
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestWebhookReview(t *testing.T) {
+	uu := map[string]struct {
+		op       admissionv1.Operation
+		kind     metav1.GroupVersionKind
+		o        runtime.Object
+		level    config.Level
+		spinach  string
+		allowed  bool
+		warnings []string
+		code     int32
+	}{
+		"cool": {
+			kind:    podKind,
+			o:       makePod("nginx:1.0", true, true),
+			level:   config.ErrorLevel,
+			allowed: true,
+		},
+		"untagged": {
+			kind:  podKind,
+			o:     makePod("nginx", true, true),
+			level: config.ErrorLevel,
+			code:  http.StatusForbidden,
+		},
+		"latest-warn": {
+			kind:     podKind,
+			o:        makePod("nginx:latest", true, true),
+			level:    config.ErrorLevel,
+			allowed:  true,
+			warnings: []string{`c1: [POP-101] Image tagged "latest" in use`},
+		},
+		"latest-deny": {
+			kind:  podKind,
+			o:     makePod("nginx:latest", true, true),
+			level: config.WarnLevel,
+			code:  http.StatusForbidden,
+		},
+		"root": {
+			kind:     podKind,
+			o:        makePod("nginx:1.0", true, false),
+			level:    config.ErrorLevel,
+			allowed:  true,
+			warnings: []string{"c1: [POP-306] Container could be running as root user. Check SecurityContext/Image", "[POP-302] Pod could be running as root user. Check SecurityContext/Image"},
+		},
+		"deployment": {
+			kind:     deployKind,
+			o:        makeDeployment("nginx:1.0", false),
+			level:    config.ErrorLevel,
+			allowed:  true,
+			warnings: []string{"c1: [POP-106] No resources requests/limits defined"},
+		},
+		"deployment-excluded": {
+			kind:    deployKind,
+			o:       makeDeployment("nginx:1.0", false),
+			level:   config.WarnLevel,
+			spinach: "popeye:\n  excludes:\n    apps/v1/deployments:\n      - name: fred/blee\n        codes:\n          - 106\n",
+			allowed: true,
+		},
+		"deployment-severity": {
+			kind:    deployKind,
+			o:       makeDeployment("nginx:1.0", false),
+			level:   config.ErrorLevel,
+			spinach: "popeye:\n  codes:\n    106:\n      severity: 3\n",
+			code:    http.StatusForbidden,
+		},
+		"delete": {
+			op:      admissionv1.Delete,
+			kind:    podKind,
+			o:       makePod("nginx", false, false),
+			level:   config.ErrorLevel,
+			allowed: true,
+		},
+		"unsupported": {
+			kind:    metav1.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+			o:       &v1.ConfigMap{},
+			level:   config.ErrorLevel,
+			allowed: true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			srv := httptest.NewServer(makeWebhook(t, u.level, u.spinach))
+			defer srv.Close()
+
+			op := u.op
+			if op == "" {
+				op = admissionv1.Create
+			}
+			raw, err := json.Marshal(u.o)
+			assert.Nil(t, err)
+			ar := admissionv1.AdmissionReview{
+				TypeMeta: metav1.TypeMeta{APIVersion: "admission.k8s.io/v1", Kind: "AdmissionReview"},
+				Request: &admissionv1.AdmissionRequest{
+					UID:       "uid-1",
+					Kind:      u.kind,
+					Namespace: "fred",
+					Operation: op,
+					Object:    runtime.RawExtension{Raw: raw},
+				},
+			}
+			body, err := json.Marshal(ar)
+			assert.Nil(t, err)
+
+			resp, err := http.Post(srv.URL, "application/json", bytes.NewReader(body))
+			assert.Nil(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+			var res admissionv1.AdmissionReview
+			assert.Nil(t, json.NewDecoder(resp.Body).Decode(&res))
+			assert.Equal(t, "AdmissionReview", res.Kind)
+			assert.Equal(t, "uid-1", string(res.Response.UID))
+			assert.Equal(t, u.allowed, res.Response.Allowed)
+			assert.Equal(t, u.warnings, res.Response.Warnings)
+			if !u.allowed {
+				assert.Equal(t, u.code, res.Response.Result.Code)
+			}
+		})
+	}
+}
+
+func TestWebhookBadRequest(t *testing.T) {
+	srv := httptest.NewServer(makeWebhook(t, config.ErrorLevel, ""))
+	defer srv.Close()
+
+	uu := map[string]struct {
+		method, body string
+		status       int
+	}{
+		"bad-json":   {method: http.MethodPost, body: "{", status: http.StatusBadRequest},
+		"no-request": {method: http.MethodPost, body: "{}", status: http.StatusBadRequest},
+		"bad-method": {method: http.MethodGet, status: http.StatusMethodNotAllowed},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			req, err := http.NewRequest(u.method, srv.URL, bytes.NewBufferString(u.body))
+			assert.Nil(t, err)
+			resp, err := http.DefaultClient.Do(req)
+			assert.Nil(t, err)
+			resp.Body.Close()
+			assert.Equal(t, u.status, resp.StatusCode)
+		})
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+var (
+	podKind    = metav1.GroupVersionKind{Version: "v1", Kind: "Pod"}
+	deployKind = metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+)
+
+func makeWebhook(t *testing.T, level config.Level, spinach string) *Webhook {
+	cfg, err := config.NewConfig(config.NewFlags())
+	assert.Nil(t, err)
+	if spinach != "" {
+		assert.Nil(t, cfg.Override([]byte(spinach)))
+	}
+	codes, err := issues.LoadCodes()
+	assert.Nil(t, err)
+	codes.Refine(cfg.Codes)
+
+	return New(cfg, codes, level, nil)
+}
+
+func makePod(image string, res, nonRoot bool) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "p1", Namespace: "fred"},
+		Spec:       makePodSpec(image, res, nonRoot),
+	}
+}
+
+func makeDeployment(image string, res bool) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "blee", Namespace: "fred"},
+		Spec: appsv1.DeploymentSpec{
+			Template: v1.PodTemplateSpec{Spec: makePodSpec(image, res, true)},
+		},
+	}
+}
+
+func makePodSpec(image string, res, nonRoot bool) v1.PodSpec {
+	spec := v1.PodSpec{
+		SecurityContext: &v1.PodSecurityContext{RunAsNonRoot: &nonRoot},
+		Containers: []v1.Container{
+			{Name: "c1", Image: image},
+		},
+	}
+	if res {
+		spec.Containers[0].Resources = v1.ResourceRequirements{
+			Limits: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("100m"),
+				v1.ResourceMemory: resource.MustParse("10Mi"),
+			},
+		}
+	}
+
+	return spec
+}
@@ -0,0 +1,44 @@
+package pkg
+
+import (
+	"github.com/derailed/popeye/internal/authz"
+	"github.com/derailed/popeye/internal/scrub"
+)
+
+// WhoCan returns the subjects allowed to perform a verb on a resource in the given namespace.
+func (p *Popeye) WhoCan(verb, resource, ns string) ([]authz.Grant, error) {
+	r, err := p.resolver()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.WhoCan(verb, resource, ns), nil
+}
+
+// CanISubject returns the rules granted to a subject ie user:fred, group:devs or sa:ns/name.
+func (p *Popeye) CanISubject(subject string) ([]authz.Grant, error) {
+	s, err := authz.ParseSubject(subject)
+	if err != nil {
+		return nil, err
+	}
+	r, err := p.resolver()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.SubjectRules(s), nil
+}
+
+func (p *Popeye) resolver() (*authz.Resolver, error) {
+	if p.factory == nil {
+		if err := p.initFactory(); err != nil {
+			return nil, err
+		}
+	}
+	l, err := scrub.NewCache(p.factory, p.config).RBAC()
+	if err != nil {
+		return nil, err
+	}
+
+	return authz.NewResolver(l), nil
+}
@@ -5,6 +5,8 @@ import (
 	"io/ioutil"
 
 	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/deprecation"
+	"github.com/derailed/popeye/internal/vuln"
 	"gopkg.in/yaml.v2"
 )
 
@@ -15,6 +17,8 @@ type Config struct {
 	Popeye    `yaml:"popeye"`
 	Flags     *Flags
 	LintLevel int
+
+	vulns *vuln.Index
 }
 
 // NewConfig create a new Popeye configuration.
@@ -40,15 +44,54 @@ func NewConfig(flags *Flags) (*Config, error) {
 		flags.Namespace = &all
 	}
 	cfg.LintLevel = int(ToIssueLevel(flags.LintLevel))
+	if isSet(flags.TargetVersion) {
+		if _, err := deprecation.ParseVersion(*flags.TargetVersion); err != nil {
+			return nil, fmt.Errorf("Invalid target version -- %w", err)
+		}
+	}
+	if isSet(flags.VulnReports) {
+		idx, err := vuln.Load(*flags.VulnReports)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid vulnerability reports -- %w", err)
+		}
+		cfg.vulns = idx
+	}
 
 	return &cfg, nil
 }
 
+// Override merges spinach YAML overrides into the configuration.
+func (c *Config) Override(raw []byte) error {
+	o := struct {
+		Popeye `yaml:"popeye"`
+	}{Popeye: c.Popeye}
+	if err := yaml.Unmarshal(raw, &o); err != nil {
+		return fmt.Errorf("Invalid spinach overrides -- %w", err)
+	}
+	c.Popeye = o.Popeye
+
+	return nil
+}
+
 // LinterLevel returns the current lint level.
 func (c *Config) LinterLevel() int {
 	return c.LintLevel
 }
 
+// Offline returns true if sanitizing static manifests rather than a live cluster.
+func (c *Config) Offline() bool {
+	return c != nil && c.Flags != nil && isSet(c.Flags.Manifests)
+}
+
+// TargetVersion returns the Kubernetes version to check api removals against if any.
+func (c *Config) TargetVersion() string {
+	if c == nil || c.Flags == nil || !isSet(c.Flags.TargetVersion) {
+		return ""
+	}
+
+	return *c.Flags.TargetVersion
+}
+
 // Sections returns a collection of sanitizers categories.
 func (c *Config) Sections() []string {
 	if c.Flags.Sections != nil {
@@ -95,6 +138,15 @@ func (c *Config) RestartsLimit() int {
 	return l
 }
 
+// StalePodDays returns how many days a terminated pod may linger.
+func (c *Config) StalePodDays() int {
+	l := c.Pod.StaleDays
+	if l == 0 {
+		return defaultStaleDays
+	}
+	return l
+}
+
 // PodMEMLimit returns the pod mem threshold if set otherwise the default.
 func (c *Config) PodMEMLimit() float64 {
 	l := c.Pod.Limits.Memory
@@ -117,6 +169,33 @@ func (c *Config) AllowedRegistries() []string {
 	return c.Registries
 }
 
+// ImagePolicy returns the container images policy.
+func (c *Config) ImagePolicy() Images {
+	return c.Images
+}
+
+// AllowedEnv checks if an env var is excluded from hard-coded secrets detection.
+func (c *Config) AllowedEnv(name string) bool {
+	return c.Secrets.AllowedEnv(name)
+}
+
+// SetVulnIndex sets the scanned images vulnerabilities.
+func (c *Config) SetVulnIndex(idx *vuln.Index) {
+	c.vulns = idx
+}
+
+// ImageVulnerabilities returns the reported vulnerabilities of a container image if it was scanned.
+func (c *Config) ImageVulnerabilities(image, imageID string) []vuln.Vulnerability {
+	var vv []vuln.Vulnerability
+	for _, v := range c.vulns.Lookup(image, imageID) {
+		if c.Vulnerabilities.Reported(v) {
+			vv = append(vv, v)
+		}
+	}
+
+	return vv
+}
+
 // ----------------------------------------------------------------------------
 // Helpers...
 
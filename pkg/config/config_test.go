@@ -18,6 +18,7 @@ func TestNewConfig(t *testing.T) {
 	assert.False(t, cfg.ShouldExclude("namespace", "kube-public", 100))
 	assert.False(t, cfg.ShouldExclude("service", "default/kubernetes", 100))
 	assert.Equal(t, 5, cfg.RestartsLimit())
+	assert.Equal(t, 7, cfg.StalePodDays())
 	assert.Equal(t, Allocations{UnderPerc: 200, OverPerc: 50}, cfg.CPUResourceLimits())
 	assert.Equal(t, Allocations{UnderPerc: 200, OverPerc: 50}, cfg.MEMResourceLimits())
 	assert.Equal(t, 0, cfg.LinterLevel())
@@ -38,6 +39,7 @@ func TestNewConfigWithFile(t *testing.T) {
 	assert.Nil(t, err)
 
 	assert.Equal(t, 3, cfg.RestartsLimit())
+	assert.Equal(t, 2, cfg.StalePodDays())
 	assert.True(t, cfg.ShouldExclude("node", "n1", 100))
 	assert.False(t, cfg.ShouldExclude("pod", "default/fred", 100))
 	assert.True(t, cfg.ShouldExclude("service", "default/dictionary", 100))
@@ -90,3 +92,49 @@ func TestNewConfigFileNoExists(t *testing.T) {
 	_, err := NewConfig(f)
 	assert.NotNil(t, err)
 }
+
+func TestConfigOverride(t *testing.T) {
+	var (
+		dir = "testdata/sp1.yml"
+		f   = NewFlags()
+	)
+	f.Spinach = &dir
+	cfg, err := NewConfig(f)
+	assert.Nil(t, err)
+
+	assert.Nil(t, cfg.Override([]byte("popeye:\n  allocations:\n    cpu:\n      underPercUtilization: 10\n  pod:\n    restarts: 10\n")))
+	assert.Equal(t, 10, cfg.RestartsLimit())
+	assert.Equal(t, 10, cfg.CPUResourceLimits().UnderPerc)
+	assert.Equal(t, 90.0, cfg.NodeCPULimit())
+	assert.Equal(t, []string{"docker.io"}, cfg.Registries)
+
+	assert.NotNil(t, cfg.Override([]byte("popeye: [")))
+}
+
+func TestNewConfigTargetVersion(t *testing.T) {
+	uu := map[string]struct {
+		target string
+		err    bool
+	}{
+		"none":  {},
+		"plain": {target: "1.25"},
+		"full":  {target: "v1.25.3"},
+		"toast": {target: "fred", err: true},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			f := NewFlags()
+			f.TargetVersion = &u.target
+
+			cfg, err := NewConfig(f)
+			if u.err {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, u.target, cfg.TargetVersion())
+		})
+	}
+}
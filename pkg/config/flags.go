@@ -23,6 +23,9 @@ func newPushGateway() *PushGateway {
 	}
 }
 
+// DefaultMaxConcurrency tracks the default number of clusters sanitized in parallel.
+const DefaultMaxConcurrency = 4
+
 // Flags represents Popeye CLI flags.
 type Flags struct {
 	*genericclioptions.ConfigFlags
@@ -45,6 +48,13 @@ type Flags struct {
 	ActiveNamespace *string
 	ForceExitZero   *bool
 	MinScore        *int
+	Manifests       *string
+	FromSnapshot    *string
+	AllContexts     *bool
+	Contexts        *[]string
+	MaxConcurrency  *int
+	TargetVersion   *string
+	VulnReports     *string
 }
 
 // NewFlags returns new configuration flags.
@@ -67,6 +77,13 @@ func NewFlags() *Flags {
 		PushGateway:     newPushGateway(),
 		ForceExitZero:   boolPtr(false),
 		MinScore:        intPtr(0),
+		Manifests:       strPtr(""),
+		FromSnapshot:    strPtr(""),
+		AllContexts:     boolPtr(false),
+		Contexts:        &[]string{},
+		MaxConcurrency:  intPtr(DefaultMaxConcurrency),
+		TargetVersion:   strPtr(""),
+		VulnReports:     strPtr(""),
 	}
 }
 
@@ -79,6 +96,42 @@ func (f *Flags) OutputFormat() string {
 	return "cool"
 }
 
+// IsFleet returns true if multiple contexts are to be sanitized.
+func (f *Flags) IsFleet() bool {
+	return (f.AllContexts != nil && *f.AllContexts) || (f.Contexts != nil && len(*f.Contexts) > 0)
+}
+
+// ForContext returns a copy of the flags targeting the given kubeconfig context.
+func (f *Flags) ForContext(ctx string) *Flags {
+	ff := f.Clone()
+	// The context dictates the cluster.
+	ff.ConfigFlags.Context, ff.ConfigFlags.ClusterName = &ctx, strPtr("")
+	ff.AllContexts, ff.Contexts = boolPtr(false), &[]string{}
+
+	return ff
+}
+
+// Clone returns a copy of the flags with their own connection flags.
+func (f *Flags) Clone() *Flags {
+	ff := *f
+	ff.ConfigFlags = genericclioptions.NewConfigFlags(false)
+	ff.ConfigFlags.KubeConfig = f.ConfigFlags.KubeConfig
+	ff.ConfigFlags.Context = f.ConfigFlags.Context
+	ff.ConfigFlags.ClusterName = f.ConfigFlags.ClusterName
+	ff.ConfigFlags.AuthInfoName = f.ConfigFlags.AuthInfoName
+	ff.ConfigFlags.Impersonate = f.ConfigFlags.Impersonate
+	ff.ConfigFlags.ImpersonateGroup = f.ConfigFlags.ImpersonateGroup
+	ff.ConfigFlags.Namespace = f.ConfigFlags.Namespace
+	ff.ConfigFlags.Timeout = f.ConfigFlags.Timeout
+	ff.ConfigFlags.Insecure = f.ConfigFlags.Insecure
+	ff.ConfigFlags.CAFile = f.ConfigFlags.CAFile
+	ff.ConfigFlags.KeyFile = f.ConfigFlags.KeyFile
+	ff.ConfigFlags.CertFile = f.ConfigFlags.CertFile
+	ff.ConfigFlags.BearerToken = f.ConfigFlags.BearerToken
+
+	return &ff
+}
+
 // ----------------------------------------------------------------------------
 // Helpers...
 
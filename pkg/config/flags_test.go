@@ -24,3 +24,36 @@ func TestOutputFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestIsFleet(t *testing.T) {
+	uu := map[string]struct {
+		f Flags
+		e bool
+	}{
+		"none":     {Flags{}, false},
+		"all":      {Flags{AllContexts: boolPtr(true)}, true},
+		"contexts": {Flags{Contexts: &[]string{"a", "b"}}, true},
+		"empty":    {Flags{AllContexts: boolPtr(false), Contexts: &[]string{}}, false},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, u.f.IsFleet())
+		})
+	}
+}
+
+func TestForContext(t *testing.T) {
+	f := NewFlags()
+	f.AllContexts = boolPtr(true)
+	f.ConfigFlags.Namespace = strPtr("blee")
+
+	ff := f.ForContext("fred")
+
+	assert.False(t, ff.IsFleet())
+	assert.Equal(t, "fred", *ff.ConfigFlags.Context)
+	assert.Equal(t, "blee", *ff.ConfigFlags.Namespace)
+	assert.True(t, f.IsFleet())
+	assert.NotEqual(t, "fred", *f.ConfigFlags.Context)
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"path"
+	"strings"
+)
+
+type (
+	// Patterns represents a collection of image patterns.
+	// A pattern can be a straight string match, a glob or a regex using an rx: prefix.
+	Patterns []string
+
+	// ImageRules tracks allowed and denied image patterns.
+	ImageRules struct {
+		Allow Patterns `yaml:"allow"`
+		Deny  Patterns `yaml:"deny"`
+	}
+
+	// DigestRules tracks namespaces requiring images pinned by digest.
+	DigestRules struct {
+		Namespaces Patterns `yaml:"namespaces"`
+	}
+
+	// TagRules tracks banned image tags.
+	TagRules struct {
+		Deny Patterns `yaml:"deny"`
+	}
+
+	// Images tracks container images policy.
+	Images struct {
+		Registries   ImageRules  `yaml:"registries"`
+		Repositories ImageRules  `yaml:"repositories"`
+		Digests      DigestRules `yaml:"digests"`
+		Tags         TagRules    `yaml:"tags"`
+	}
+)
+
+// Match checks if any of the patterns matches the given string.
+func (pp Patterns) Match(s string) bool {
+	for _, p := range pp {
+		switch {
+		case isRegex(p):
+			if rxMatch(p, s) {
+				return true
+			}
+		case strings.ContainsAny(p, "*?["):
+			if ok, err := path.Match(p, s); err == nil && ok {
+				return true
+			}
+		case p == s:
+			return true
+		}
+	}
+
+	return false
+}
+
+// Allowed checks if a value is allowed. All values are allowed when no allow patterns are set.
+func (r ImageRules) Allowed(s string) bool {
+	return len(r.Allow) == 0 || r.Allow.Match(s)
+}
+
+// Denied checks if a value is denied.
+func (r ImageRules) Denied(s string) bool {
+	return r.Deny.Match(s)
+}
+
+// RequireDigest checks if images in the given namespace must be pinned by digest.
+func (i Images) RequireDigest(ns string) bool {
+	return i.Digests.Namespaces.Match(ns)
+}
+
+// DeniedTag checks if an image tag is banned.
+func (i Images) DeniedTag(tag string) bool {
+	return i.Tags.Deny.Match(tag)
+}
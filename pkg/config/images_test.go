@@ -0,0 +1,60 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatternsMatch(t *testing.T) {
+	uu := map[string]struct {
+		pp Patterns
+		s  string
+		e  bool
+	}{
+		"empty": {
+			s: "docker.io",
+		},
+		"exact": {
+			pp: Patterns{"quay.io", "docker.io"},
+			s:  "docker.io",
+			e:  true,
+		},
+		"glob": {
+			pp: Patterns{"*.gcr.io"},
+			s:  "us.gcr.io",
+			e:  true,
+		},
+		"globNoCrossPath": {
+			pp: Patterns{"docker.io/*"},
+			s:  "docker.io/library/nginx",
+		},
+		"rx": {
+			pp: Patterns{`rx:^docker\.io/(library|bitnami)/`},
+			s:  "docker.io/bitnami/redis",
+			e:  true,
+		},
+		"noMatch": {
+			pp: Patterns{"quay.io", "*.gcr.io", "rx:^ghcr"},
+			s:  "docker.io",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, u.pp.Match(u.s))
+		})
+	}
+}
+
+func TestImageRules(t *testing.T) {
+	r := ImageRules{}
+	assert.True(t, r.Allowed("docker.io"))
+	assert.False(t, r.Denied("docker.io"))
+
+	r = ImageRules{Allow: Patterns{"quay.io"}, Deny: Patterns{"docker.io"}}
+	assert.True(t, r.Allowed("quay.io"))
+	assert.False(t, r.Allowed("docker.io"))
+	assert.True(t, r.Denied("docker.io"))
+}
@@ -1,18 +1,25 @@
 package config
 
-const defaultRestarts = 5
+const (
+	defaultRestarts = 5
+
+	// DefaultStaleDays tracks how long a terminated pod may linger.
+	defaultStaleDays = 7
+)
 
 // Pod tracks pod configurations.
 type Pod struct {
-	Restarts int    `yaml:"restarts"`
-	Limits   Limits `yaml:"limits"`
-	Excludes `yaml:"exclude"`
+	Restarts  int    `yaml:"restarts"`
+	StaleDays int    `yaml:"staleDays"`
+	Limits    Limits `yaml:"limits"`
+	Excludes  `yaml:"exclude"`
 }
 
 // NewPod create a new pod configuration.
 func newPod() Pod {
 	return Pod{
-		Restarts: defaultRestarts,
+		Restarts:  defaultRestarts,
+		StaleDays: defaultStaleDays,
 		Limits: Limits{
 			CPU:    defaultCPULimit,
 			Memory: defaultMEMLimit,
@@ -46,6 +46,10 @@ type (
 		Pod        Pod      `yaml:"pod"`
 		Codes      Glossary `yaml:"codes"`
 		Registries []string `yaml:"registries"`
+		Images     Images   `yaml:"images"`
+		Secrets    Secrets  `yaml:"secrets"`
+
+		Vulnerabilities Vulnerabilities `yaml:"vulnerabilities"`
 	}
 )
 
@@ -0,0 +1,25 @@
+package config
+
+// Secrets tracks hard-coded secrets detection configurations.
+type Secrets struct {
+	// AllowedEnvs lists env var names excluded from the detection.
+	// This can be a straight string match or a regex using an rx: prefix.
+	AllowedEnvs []string `yaml:"allowedEnvs"`
+}
+
+// AllowedEnv checks if an env var is excluded from hard-coded secrets detection.
+func (s Secrets) AllowedEnv(name string) bool {
+	for _, e := range s.AllowedEnvs {
+		if isRegex(e) {
+			if rxMatch(e, name) {
+				return true
+			}
+			continue
+		}
+		if e == name {
+			return true
+		}
+	}
+
+	return false
+}
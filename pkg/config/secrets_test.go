@@ -0,0 +1,44 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecretsAllowedEnv(t *testing.T) {
+	uu := map[string]struct {
+		allowed []string
+		name    string
+		e       bool
+	}{
+		"none": {
+			name: "DB_PASSWORD",
+		},
+		"match": {
+			allowed: []string{"GIT_SHA", "DB_PASSWORD"},
+			name:    "DB_PASSWORD",
+			e:       true,
+		},
+		"noMatch": {
+			allowed: []string{"GIT_SHA"},
+			name:    "DB_PASSWORD",
+		},
+		"rx": {
+			allowed: []string{"rx:^TEST_.*_TOKEN$"},
+			name:    "TEST_API_TOKEN",
+			e:       true,
+		},
+		"rxNoMatch": {
+			allowed: []string{"rx:^TEST_"},
+			name:    "API_TOKEN",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, Secrets{AllowedEnvs: u.allowed}.AllowedEnv(u.name))
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package config
+
+import "github.com/derailed/popeye/internal/vuln"
+
+// Vulnerabilities tracks image vulnerabilities reporting configurations.
+type Vulnerabilities struct {
+	// Severity tracks the minimal severity of reported vulnerabilities. Defaults to HIGH.
+	Severity string `yaml:"severity"`
+
+	// Ignore lists vulnerability ids that are not reported.
+	Ignore Patterns `yaml:"ignore"`
+}
+
+// Reported checks if a vulnerability should be reported. Only vulnerabilities
+// with a fixed version available are reported.
+func (v Vulnerabilities) Reported(vv vuln.Vulnerability) bool {
+	if vv.Fixed == "" || v.Ignore.Match(vv.ID) {
+		return false
+	}
+	threshold := v.Severity
+	if threshold == "" {
+		threshold = vuln.High
+	}
+
+	return vuln.AtLeast(vv.Severity, threshold)
+}
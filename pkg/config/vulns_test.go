@@ -0,0 +1,58 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/derailed/popeye/internal/vuln"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVulnerabilitiesReported(t *testing.T) {
+	uu := map[string]struct {
+		policy Vulnerabilities
+		vuln   vuln.Vulnerability
+		e      bool
+	}{
+		"default": {
+			vuln: vuln.Vulnerability{ID: "CVE-1", Fixed: "1.1", Severity: vuln.High},
+			e:    true,
+		},
+		"belowDefault": {
+			vuln: vuln.Vulnerability{ID: "CVE-1", Fixed: "1.1", Severity: vuln.Medium},
+		},
+		"notFixed": {
+			vuln: vuln.Vulnerability{ID: "CVE-1", Severity: vuln.Critical},
+		},
+		"threshold": {
+			policy: Vulnerabilities{Severity: "medium"},
+			vuln:   vuln.Vulnerability{ID: "CVE-1", Fixed: "1.1", Severity: vuln.Medium},
+			e:      true,
+		},
+		"ignored": {
+			policy: Vulnerabilities{Ignore: Patterns{"rx:^CVE-2022-"}},
+			vuln:   vuln.Vulnerability{ID: "CVE-2022-0778", Fixed: "1.1", Severity: vuln.Critical},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, u.policy.Reported(u.vuln))
+		})
+	}
+}
+
+func TestConfigVulnReports(t *testing.T) {
+	f := NewFlags()
+	dir := "../../internal/vuln/testdata/reports"
+	f.VulnReports = &dir
+	cfg, err := NewConfig(f)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(cfg.ImageVulnerabilities("nginx:1.21", "")))
+	assert.Equal(t, 1, len(cfg.ImageVulnerabilities("bitnami/redis@sha256:c9f0a3d1e7b5", "")))
+
+	toast := "testdata/toast"
+	f.VulnReports = &toast
+	_, err = NewConfig(f)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,21 @@
+package pkg
+
+import (
+	"github.com/derailed/popeye/internal/drain"
+	"github.com/derailed/popeye/internal/scrub"
+)
+
+// DrainSim simulates draining a node and returns the impact on each evicted workload.
+func (p *Popeye) DrainSim(node string) ([]drain.Outcome, error) {
+	if p.factory == nil {
+		if err := p.initFactory(); err != nil {
+			return nil, err
+		}
+	}
+	l, err := scrub.NewCache(p.factory, p.config).Drain()
+	if err != nil {
+		return nil, err
+	}
+
+	return drain.NewSimulator(l).Drain(node)
+}
@@ -0,0 +1,168 @@
+package pkg
+
+import (
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/report"
+	"github.com/derailed/popeye/pkg/config"
+	"github.com/prometheus/common/expfmt"
+)
+
+const fleetClusterName = "fleet"
+
+func (p *Popeye) initFleet() error {
+	cfg := client.NewConfig(p.flags.ConfigFlags)
+	cc := *p.flags.Contexts
+	if isSet(p.flags.AllContexts) {
+		var err error
+		if cc, err = cfg.ContextNames(); err != nil {
+			return err
+		}
+	}
+	if len(cc) == 0 {
+		return errors.New("No kubeconfig contexts to sanitize")
+	}
+	sort.Strings(cc)
+
+	p.contexts, p.fleet = cc, report.NewFleet()
+	for _, ctx := range cc {
+		n, err := cfg.ClusterNameFromContext(ctx)
+		if err != nil {
+			return err
+		}
+		p.clusters[ctx] = n
+	}
+
+	return nil
+}
+
+// sanitizeFleet scans all fleet contexts with bounded parallelism.
+func (p *Popeye) sanitizeFleet() (int, int, error) {
+	max := *p.flags.MaxConcurrency
+	if max <= 0 {
+		max = config.DefaultMaxConcurrency
+	}
+
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, max)
+	)
+	for _, ctx := range p.contexts {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(ctx string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := p.sanitizeContext(ctx); err != nil {
+				p.log.Warn().Err(err).Msgf("Sanitize context %q failed", ctx)
+				p.fleet.AddFailure(ctx, p.clusters[ctx], err)
+			}
+		}(ctx)
+	}
+	wg.Wait()
+
+	score, _ := p.fleet.ToScore()
+
+	return p.fleet.ErrCount(), score, nil
+}
+
+func (p *Popeye) sanitizeContext(ctx string) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("Popeye CHOKED! %v", e)
+		}
+	}()
+
+	c, err := NewPopeye(p.flags.ForContext(ctx), p.log)
+	if err != nil {
+		return err
+	}
+	if err := c.initScan(); err != nil {
+		return err
+	}
+	if err := client.Load(c.factory); err != nil {
+		return err
+	}
+	if _, _, err := c.sanitize(); err != nil {
+		return err
+	}
+	p.fleet.AddCluster(ctx, p.clusters[ctx], c.factory.Client().HasMetrics(), c.builder)
+
+	return nil
+}
+
+func (p *Popeye) dumpFleet(printHeader bool) error {
+	if !p.fleet.HasContent() {
+		return errors.New("Nothing to report, check contexts")
+	}
+
+	var (
+		res string
+		err error
+	)
+	switch p.flags.OutputFormat() {
+	case report.JunitFormat:
+		if res, err = p.fleet.ToJunit(config.Level(p.config.LinterLevel())); err == nil {
+			_, err = p.outputTarget.Write([]byte(xml.Header))
+		}
+	case report.YAMLFormat:
+		res, err = p.fleet.ToYAML()
+	case report.JSONFormat:
+		res, err = p.fleet.ToJSON()
+	case report.HTMLFormat:
+		res, err = p.fleet.ToHTML()
+	case report.PrometheusFormat:
+		return p.dumpFleetPrometheus()
+	case report.ScoreFormat:
+		var score int
+		score, err = p.fleet.ToScore()
+		res = fmt.Sprintf("%d", score)
+	default:
+		return p.dumpFleetStd(p.flags.OutputFormat() == report.JurassicFormat, printHeader)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(p.outputTarget, "%v\n", res)
+
+	return nil
+}
+
+func (p *Popeye) dumpFleetStd(mode, header bool) error {
+	var (
+		w = bufio.NewWriter(p.outputTarget)
+		s = report.NewSanitizer(w, mode)
+	)
+
+	if header {
+		p.builder.PrintHeader(s)
+	}
+	p.fleet.PrintReport(config.Level(p.config.LinterLevel()), s)
+	p.fleet.PrintSummary(s)
+
+	return w.Flush()
+}
+
+func (p *Popeye) dumpFleetPrometheus() error {
+	ns := client.AllNamespaces
+	if p.flags.ConfigFlags.Namespace != nil {
+		ns = *p.flags.ConfigFlags.Namespace
+	}
+	pusher := p.fleet.ToPrometheus(p.flags.PushGateway, ns)
+
+	// Enable saving to file
+	if isSet(p.flags.Save) || isSetStr(p.flags.S3Bucket) {
+		pusher.Client(p)
+		pusher.Format(expfmt.FmtText)
+	}
+
+	return pusher.Add()
+}
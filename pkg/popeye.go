@@ -23,8 +23,11 @@ import (
 	"github.com/derailed/popeye/internal"
 	"github.com/derailed/popeye/internal/client"
 	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/internal/offline"
 	"github.com/derailed/popeye/internal/report"
+	"github.com/derailed/popeye/internal/sanitize"
 	"github.com/derailed/popeye/internal/scrub"
+	"github.com/derailed/popeye/internal/snapshot"
 	"github.com/derailed/popeye/pkg/config"
 	"github.com/derailed/popeye/types"
 	"github.com/prometheus/common/expfmt"
@@ -48,6 +51,7 @@ type scrubFn func(context.Context, *scrub.Cache, *issues.Codes) scrub.Sanitizer
 type run struct {
 	outcome issues.Outcome
 	gvr     client.GVR
+	garbage []sanitize.Garbage
 }
 
 // Popeye represents a kubernetes linter/sanitizer.
@@ -59,6 +63,9 @@ type Popeye struct {
 	flags        *config.Flags
 	builder      *report.Builder
 	aliases      *internal.Aliases
+	fleet        *report.Fleet
+	contexts     []string
+	clusters     map[string]string
 }
 
 // NewPopeye returns a new instance.
@@ -69,28 +76,22 @@ func NewPopeye(flags *config.Flags, log *zerolog.Logger) (*Popeye, error) {
 	}
 
 	p := Popeye{
-		config:  cfg,
-		log:     log,
-		flags:   flags,
-		builder: report.NewBuilder(),
+		config:   cfg,
+		log:      log,
+		flags:    flags,
+		builder:  report.NewBuilder(),
+		clusters: make(map[string]string),
 	}
 	return &p, nil
 }
 
 // Init configures popeye prior to sanitization.
 func (p *Popeye) Init() error {
-	if p.factory == nil {
-		if err := p.initFactory(); err != nil {
+	if p.flags.IsFleet() {
+		if err := p.initFleet(); err != nil {
 			return err
 		}
-	}
-	rev, err := p.revision()
-	if err != nil {
-		return err
-	}
-
-	p.aliases = internal.NewAliases()
-	if err := p.aliases.Init(p.factory, p.scannedGVRs(rev)); err != nil {
+	} else if err := p.initScan(); err != nil {
 		return err
 	}
 
@@ -104,6 +105,21 @@ func (p *Popeye) Init() error {
 	return p.ensureOutput()
 }
 
+func (p *Popeye) initScan() error {
+	if p.factory == nil {
+		if err := p.initFactory(); err != nil {
+			return err
+		}
+	}
+	rev, err := p.revision()
+	if err != nil {
+		return err
+	}
+	p.aliases = internal.NewAliases()
+
+	return p.aliases.Init(p.factory, p.scannedGVRs(rev))
+}
+
 // SetFactory sets the resource factory.
 func (p *Popeye) SetFactory(f types.Factory) {
 	p.factory = f
@@ -112,6 +128,7 @@ func (p *Popeye) SetFactory(f types.Factory) {
 func (p *Popeye) scannedGVRs(rev *client.Revision) []string {
 	mm := []string{
 		"v1/limitranges",
+		"v1/resourcequotas",
 		"v1/services",
 		"v1/endpoints",
 		"v1/nodes",
@@ -126,21 +143,23 @@ func (p *Popeye) scannedGVRs(rev *client.Revision) []string {
 		"apps/v1/replicasets",
 		"apps/v1/daemonsets",
 		"apps/v1/statefulsets",
+		"batch/v1/jobs",
 		//"policy/v1beta1/podsecuritypolicies",
 		"networking.k8s.io/v1/networkpolicies",
 		"rbac.authorization.k8s.io/v1/clusterroles",
 		"rbac.authorization.k8s.io/v1/clusterrolebindings",
 		"rbac.authorization.k8s.io/v1/roles",
 		"rbac.authorization.k8s.io/v1/rolebindings",
+		"apiextensions.k8s.io/v1/customresourcedefinitions",
 	}
 
 	if rev.Minor <= 18 {
 		mm = append(mm, "networking.k8s.io/v1beta1/ingresses")
 	} else {
-		mm = append(mm, "networking.k8s.io/v1/ingresses")
+		mm = append(mm, "networking.k8s.io/v1/ingresses", "networking.k8s.io/v1/ingressclasses")
 	}
 	if rev.Minor >= 21 {
-		mm = append(mm, "policy/v1/poddisruptionbudgets")
+		mm = append(mm, "policy/v1/poddisruptionbudgets", "batch/v1/cronjobs")
 	} else {
 		mm = append(mm, "policy/v1beta1/poddisruptionbudgets")
 	}
@@ -154,6 +173,13 @@ func (p *Popeye) scannedGVRs(rev *client.Revision) []string {
 }
 
 func (p *Popeye) initFactory() error {
+	if p.config.Offline() {
+		return p.initManifestFactory()
+	}
+	if isSetStr(p.flags.FromSnapshot) {
+		return p.initSnapshotFactory()
+	}
+
 	clt, err := client.InitConnectionOrDie(client.NewConfig(p.flags.ConfigFlags))
 	if err != nil {
 		return err
@@ -194,6 +220,52 @@ func (p *Popeye) initFactory() error {
 	return nil
 }
 
+func (p *Popeye) initManifestFactory() error {
+	f, err := offline.NewManifestFactory(p.flags.ConfigFlags, *p.flags.Manifests)
+	if err != nil {
+		return err
+	}
+	p.factory = f
+	// Manifests are served from the factory store not the api server.
+	p.flags.StandAlone = false
+
+	return nil
+}
+
+func (p *Popeye) initSnapshotFactory() error {
+	s, err := snapshot.Load(*p.flags.FromSnapshot)
+	if err != nil {
+		return err
+	}
+	f, err := snapshot.NewFactory(p.flags.ConfigFlags, s)
+	if err != nil {
+		return err
+	}
+	p.factory = f
+	// Snapshot resources are served from the factory store not the api server.
+	p.flags.StandAlone = false
+
+	return nil
+}
+
+// Snapshot captures the scanned resources of a live cluster into an archive.
+func (p *Popeye) Snapshot(path string) error {
+	if err := p.initFactory(); err != nil {
+		return err
+	}
+	rev, err := p.revision()
+	if err != nil {
+		return err
+	}
+
+	s, err := snapshot.Capture(context.Background(), p.factory.Client(), p.scannedGVRs(rev))
+	if err != nil {
+		return err
+	}
+
+	return snapshot.Save(path, s)
+}
+
 func (p *Popeye) revision() (*client.Revision, error) {
 	info, err := p.factory.Client().ServerVersion()
 	if err != nil {
@@ -207,11 +279,13 @@ func (p *Popeye) sanitizers(rev *client.Revision) map[string]scrubFn {
 	mm := map[string]scrubFn{
 		"cluster":                                   scrub.NewCluster,
 		"v1/configmaps":                             scrub.NewConfigMap,
+		"v1/limitranges":                            scrub.NewLimitRange,
 		"v1/namespaces":                             scrub.NewNamespace,
 		"v1/nodes":                                  scrub.NewNode,
 		"v1/pods":                                   scrub.NewPod,
 		"v1/persistentvolumes":                      scrub.NewPersistentVolume,
 		"v1/persistentvolumeclaims":                 scrub.NewPersistentVolumeClaim,
+		"v1/resourcequotas":                         scrub.NewResourceQuota,
 		"v1/secrets":                                scrub.NewSecret,
 		"v1/services":                               scrub.NewService,
 		"v1/serviceaccounts":                        scrub.NewServiceAccount,
@@ -219,6 +293,7 @@ func (p *Popeye) sanitizers(rev *client.Revision) map[string]scrubFn {
 		"apps/v1/deployments":                       scrub.NewDeployment,
 		"apps/v1/replicasets":                       scrub.NewReplicaSet,
 		"apps/v1/statefulsets":                      scrub.NewStatefulSet,
+		"batch/v1/jobs":                             scrub.NewJob,
 		"networking.k8s.io/v1/networkpolicies":      scrub.NewNetworkPolicy,
 		"networking.k8s.io/v1/ingresses":            scrub.NewIngress,
 		//"policy/v1beta1/podsecuritypolicies":        scrub.NewPodSecurityPolicy,
@@ -226,6 +301,7 @@ func (p *Popeye) sanitizers(rev *client.Revision) map[string]scrubFn {
 		"rbac.authorization.k8s.io/v1/clusterrolebindings": scrub.NewClusterRoleBinding,
 		"rbac.authorization.k8s.io/v1/roles":               scrub.NewRole,
 		"rbac.authorization.k8s.io/v1/rolebindings":        scrub.NewRoleBinding,
+		"apiextensions.k8s.io/v1/customresourcedefinitions": scrub.NewCustomResourceDefinition,
 	}
 
 	if rev.Minor <= 18 {
@@ -233,6 +309,7 @@ func (p *Popeye) sanitizers(rev *client.Revision) map[string]scrubFn {
 	}
 	if rev.Minor >= 21 {
 		mm["policy/v1/poddisruptionbudgets"] = scrub.NewPodDisruptionBudget
+		mm["batch/v1/cronjobs"] = scrub.NewCronJob
 	} else {
 		mm["policy/v1beta1/poddisruptionbudgets"] = scrub.NewPodDisruptionBudget
 	}
@@ -290,6 +367,14 @@ func (p *Popeye) Sanitize() (int, int, error) {
 		}
 	}()
 
+	if p.fleet != nil {
+		errCount, score, err := p.sanitizeFleet()
+		if err != nil {
+			return 0, 0, err
+		}
+		return errCount, score, p.dumpFleet(true)
+	}
+
 	if err := client.Load(p.factory); err != nil {
 		return 0, 0, err
 	}
@@ -303,7 +388,12 @@ func (p *Popeye) Sanitize() (int, int, error) {
 }
 
 func (p *Popeye) sanitize() (int, int, error) {
-	ctx, cancel := context.WithCancel(context.Background())
+	return p.sanitizeOnly(context.Background(), nil)
+}
+
+// sanitizeOnly runs the sanitizers matching the given filter or all of them if nil.
+func (p *Popeye) sanitizeOnly(ctx context.Context, keep func(client.GVR) bool) (int, int, error) {
+	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 	ctx = context.WithValue(ctx, internal.KeyOverAllocs, *p.flags.CheckOverAllocs)
 	ctx = context.WithValue(ctx, internal.KeyFactory, p.factory)
@@ -319,7 +409,15 @@ func (p *Popeye) sanitize() (int, int, error) {
 
 	c := make(chan run, 2)
 	var total, errCount int
-	var nodeGVR = client.NewGVR("v1/nodes")
+	var (
+		nodeGVR    = client.NewGVR("v1/nodes")
+		clusterGVR = client.NewGVR("cluster")
+		npGVR      = client.NewGVR("networking.k8s.io/v1/networkpolicies")
+		isolation  bool
+	)
+	if p.config.Offline() {
+		p.builder.AddSkip("live state", "Metrics, pod status and endpoints checks are not evaluated offline")
+	}
 	cache := scrub.NewCache(p.factory, p.config)
 
 	rev, err := p.revision()
@@ -331,10 +429,21 @@ func (p *Popeye) sanitize() (int, int, error) {
 		if p.aliases.Exclude(gvr, p.config.Sections()) {
 			continue
 		}
+		if keep != nil && !keep(gvr) {
+			continue
+		}
 		// Skip node sanitizer if active namespace is set.
 		if gvr == nodeGVR && p.factory.Client().ActiveNamespace() != client.AllNamespaces {
 			continue
 		}
+		// Skip cluster and node sanitizers as they require a live cluster.
+		if p.config.Offline() && (gvr == nodeGVR || gvr == clusterGVR) {
+			p.builder.AddSkip(gvr.R(), "Requires a live cluster")
+			continue
+		}
+		if gvr == npGVR {
+			isolation = true
+		}
 		total++
 		ctx = context.WithValue(ctx, internal.KeyRunInfo, internal.RunInfo{Section: gvr.R(), SectionGVR: gvr})
 		go p.sanitizer(ctx, gvr, fn, c, cache, codes)
@@ -351,11 +460,15 @@ func (p *Popeye) sanitize() (int, int, error) {
 		tally.Rollup(run.outcome)
 		score, errCount = score+tally.Score(), errCount+tally.ErrCount()
 		p.builder.AddSection(run.gvr, p.aliases.Singular(run.gvr), run.outcome, tally)
+		p.addGarbage(run.garbage)
 		total--
 		if total == 0 {
 			close(c)
 		}
 	}
+	if isolation {
+		p.addIsolation(cache)
+	}
 	if count == 0 {
 		return errCount, 0, nil
 	}
@@ -363,6 +476,37 @@ func (p *Popeye) sanitize() (int, int, error) {
 	return errCount, score / count, nil
 }
 
+// addIsolation records each namespace network isolation in the report.
+func (p *Popeye) addIsolation(c *scrub.Cache) {
+	ii, err := c.NetworkIsolation()
+	if err != nil {
+		p.builder.AddError(err)
+		return
+	}
+	for _, i := range ii {
+		p.builder.AddIsolation(report.Isolation{
+			Namespace:   i.Namespace,
+			Policies:    i.Policies,
+			DenyIngress: i.DenyIngress,
+			DenyEgress:  i.DenyEgress,
+			Pods:        i.Pods,
+			Selected:    i.Selected,
+		})
+	}
+}
+
+// addGarbage records unused resources in the report.
+func (p *Popeye) addGarbage(gg []sanitize.Garbage) {
+	for _, g := range gg {
+		p.builder.AddGarbage(report.Garbage{
+			GVR:      g.GVR.String(),
+			Resource: g.FQN,
+			Reason:   g.Reason,
+			Storage:  g.Storage,
+		})
+	}
+}
+
 func (p *Popeye) sanitizer(ctx context.Context, gvr client.GVR, f scrubFn, c chan run, cache *scrub.Cache, codes *issues.Codes) {
 	defer func() {
 		if e := recover(); e != nil {
@@ -376,7 +520,11 @@ func (p *Popeye) sanitizer(ctx context.Context, gvr client.GVR, f scrubFn, c cha
 		p.builder.AddError(err)
 	}
 	o := resource.Outcome().Filter(config.Level(p.config.LinterLevel()))
-	c <- run{gvr: gvr, outcome: o}
+	gg, err := cache.Garbage(gvr, resource.Outcome())
+	if err != nil {
+		p.builder.AddError(err)
+	}
+	c <- run{gvr: gvr, outcome: o, garbage: gg}
 }
 
 func (p *Popeye) dumpJunit() error {
@@ -442,7 +590,10 @@ func (p *Popeye) dumpStd(mode, header bool) error {
 		p.builder.PrintHeader(s)
 	}
 	p.builder.PrintClusterInfo(s, p.factory.Client().ActiveCluster(), p.factory.Client().HasMetrics())
+	p.builder.PrintSkips(s)
 	p.builder.PrintReport(config.Level(p.config.LinterLevel()), s)
+	p.builder.PrintIsolation(s)
+	p.builder.PrintGarbage(s)
 	p.builder.PrintSummary(s)
 
 	return w.Flush()
@@ -553,11 +704,19 @@ func (p *Popeye) ensureOutput() error {
 
 func (p *Popeye) fileName() string {
 	if *p.flags.OutputFile == "" {
-		return fmt.Sprintf(outFmt, p.factory.Client().ActiveCluster(), time.Now().UnixNano(), p.fileExt())
+		return fmt.Sprintf(outFmt, p.activeCluster(), time.Now().UnixNano(), p.fileExt())
 	}
 	return fmt.Sprintf(*p.flags.OutputFile)
 }
 
+func (p *Popeye) activeCluster() string {
+	if p.fleet != nil {
+		return fleetClusterName
+	}
+
+	return p.factory.Client().ActiveCluster()
+}
+
 func (p *Popeye) fileExt() string {
 	switch *p.flags.Output {
 	case "json":
@@ -0,0 +1,190 @@
+package pkg
+
+import (
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/internal/report"
+	"github.com/derailed/popeye/pkg/config"
+)
+
+// Report represents the outcome of a programmatic scan.
+type Report struct {
+	ClusterName string      `json:"clusterName" yaml:"clusterName"`
+	Score       int         `json:"score" yaml:"score"`
+	Grade       string      `json:"grade" yaml:"grade"`
+	Sections    []Section   `json:"sanitizers,omitempty" yaml:"sanitizers,omitempty"`
+	Errors      []string    `json:"errors,omitempty" yaml:"errors,omitempty"`
+	Skips       []Skip      `json:"skipped,omitempty" yaml:"skipped,omitempty"`
+	Isolation   []Isolation `json:"isolation,omitempty" yaml:"isolation,omitempty"`
+	Garbage     []Garbage   `json:"garbage,omitempty" yaml:"garbage,omitempty"`
+	Reclaimable int64       `json:"reclaimableStorageBytes,omitempty" yaml:"reclaimableStorageBytes,omitempty"`
+}
+
+// Section represents the outcome of a sanitizer.
+type Section struct {
+	Title  string             `json:"sanitizer" yaml:"sanitizer"`
+	GVR    string             `json:"gvr" yaml:"gvr"`
+	Tally  Tally              `json:"tally" yaml:"tally"`
+	Issues map[string][]Issue `json:"issues,omitempty" yaml:"issues,omitempty"`
+}
+
+// Tally represents a sanitizer resources count per severity and its score.
+type Tally struct {
+	OK      int `json:"ok" yaml:"ok"`
+	Info    int `json:"info" yaml:"info"`
+	Warning int `json:"warning" yaml:"warning"`
+	Error   int `json:"error" yaml:"error"`
+	Score   int `json:"score" yaml:"score"`
+}
+
+// Issue represents a resource sanitizer issue.
+type Issue struct {
+	// Group tracks the resource sub component ie a container name or empty for the resource itself.
+	Group   string       `json:"group,omitempty" yaml:"group,omitempty"`
+	GVR     string       `json:"gvr" yaml:"gvr"`
+	Code    config.ID    `json:"code,omitempty" yaml:"code,omitempty"`
+	Level   config.Level `json:"level" yaml:"level"`
+	Message string       `json:"message" yaml:"message"`
+}
+
+// Skip represents a check that was not evaluated.
+type Skip struct {
+	Section string `json:"section" yaml:"section"`
+	Reason  string `json:"reason" yaml:"reason"`
+}
+
+// Isolation represents a namespace network isolation summary.
+type Isolation struct {
+	Namespace   string `json:"namespace" yaml:"namespace"`
+	Policies    int    `json:"policies" yaml:"policies"`
+	DenyIngress bool   `json:"denyIngress" yaml:"denyIngress"`
+	DenyEgress  bool   `json:"denyEgress" yaml:"denyEgress"`
+	Pods        int    `json:"pods" yaml:"pods"`
+	Selected    int    `json:"selectedPods" yaml:"selectedPods"`
+}
+
+// Garbage represents an unused resource.
+type Garbage struct {
+	GVR      string `json:"gvr" yaml:"gvr"`
+	Resource string `json:"resource" yaml:"resource"`
+	Reason   string `json:"reason" yaml:"reason"`
+	Storage  int64  `json:"storageBytes,omitempty" yaml:"storageBytes,omitempty"`
+}
+
+// newReport converts a sanitizer report, retaining issues in the given namespaces if any.
+func newReport(b *report.Builder, nss []string) *Report {
+	keep := make(map[string]struct{}, len(nss))
+	for _, ns := range nss {
+		keep[ns] = struct{}{}
+	}
+
+	r := Report{ClusterName: b.ClusterName()}
+	var total, count int
+	for _, sec := range b.Report.Sections {
+		gvr := client.NewGVR(sec.GVR)
+		o, t := sec.Outcome, sec.Tally
+		if len(keep) > 0 {
+			o = filterNamespaces(gvr, o, keep)
+			t = report.NewTally().Rollup(o)
+		}
+		if t.IsValid() {
+			total, count = total+t.Score(), count+1
+		}
+		r.Sections = append(r.Sections, Section{
+			Title:  sec.Title,
+			GVR:    sec.GVR,
+			Tally:  toTally(t),
+			Issues: toIssues(o),
+		})
+	}
+	if count > 0 {
+		r.Score = total / count
+	}
+	r.Grade = report.Grade(r.Score)
+	for _, err := range b.Report.Errors {
+		r.Errors = append(r.Errors, err.Error())
+	}
+	for _, s := range b.Report.Skips {
+		r.Skips = append(r.Skips, Skip{Section: s.Section, Reason: s.Reason})
+	}
+	for _, i := range b.Report.Isolation {
+		if _, ok := keep[i.Namespace]; len(keep) == 0 || ok {
+			r.Isolation = append(r.Isolation, Isolation(i))
+		}
+	}
+	for _, g := range b.Report.Garbage {
+		if ns, _ := client.Namespaced(g.Resource); ns != "" && len(keep) > 0 {
+			if _, ok := keep[ns]; !ok {
+				continue
+			}
+		}
+		r.Garbage, r.Reclaimable = append(r.Garbage, Garbage(g)), r.Reclaimable+g.Storage
+	}
+
+	return &r
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+// filterNamespaces retains namespaced resources living in the given namespaces.
+// Cluster scoped resources are retained, except for namespaces.
+func filterNamespaces(gvr client.GVR, o issues.Outcome, keep map[string]struct{}) issues.Outcome {
+	nsGVR := client.NewGVR("v1/namespaces")
+	res := make(issues.Outcome, len(o))
+	for fqn, ii := range o {
+		ns, n := client.Namespaced(fqn)
+		if gvr == nsGVR {
+			ns = n
+		}
+		if ns != "" {
+			if _, ok := keep[ns]; !ok {
+				continue
+			}
+		}
+		res[fqn] = ii
+	}
+
+	return res
+}
+
+func toTally(t *report.Tally) Tally {
+	if t == nil {
+		return Tally{}
+	}
+
+	return Tally{
+		OK:      t.OkCount(),
+		Info:    t.InfoCount(),
+		Warning: t.WarnCount(),
+		Error:   t.ErrCount(),
+		Score:   t.Score(),
+	}
+}
+
+func toIssues(o issues.Outcome) map[string][]Issue {
+	if len(o) == 0 {
+		return nil
+	}
+	mm := make(map[string][]Issue, len(o))
+	for fqn, ii := range o {
+		res := make([]Issue, 0, len(ii))
+		for _, i := range ii {
+			code, _ := i.Code()
+			group := i.Group
+			if !i.IsSubIssue() {
+				group = ""
+			}
+			res = append(res, Issue{
+				Group:   group,
+				GVR:     i.GVR,
+				Code:    code,
+				Level:   i.Level,
+				Message: i.Message,
+			})
+		}
+		mm[fqn] = res
+	}
+
+	return mm
+}
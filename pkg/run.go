@@ -0,0 +1,123 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/pkg/config"
+	"github.com/derailed/popeye/types"
+	"github.com/rs/zerolog"
+)
+
+// Options represents a programmatic scan configuration.
+type Options struct {
+	// Factory serves the scanned resources. When not set, Popeye connects
+	// to the cluster using the KubeConfig and Context options.
+	Factory types.Factory
+
+	// KubeConfig tracks a kubeconfig file path. Defaults to the standard kubeconfig resolution.
+	KubeConfig string
+
+	// Context tracks a kubeconfig context. Defaults to the current context.
+	Context string
+
+	// Sections lists the resources to scan ie po, svc. Defaults to all resources.
+	Sections []string
+
+	// Namespaces restricts the report to these namespaces. Defaults to all namespaces.
+	Namespaces []string
+
+	// Level reports issues at or above this severity.
+	Level config.Level
+
+	// Config tracks the spinach configuration. Defaults to config.NewPopeye().
+	Config *config.Popeye
+
+	// CheckOverAllocs checks for cpu/memory over allocations.
+	CheckOverAllocs bool
+
+	// ClusterName overrides the reported cluster name.
+	ClusterName string
+
+	// TargetVersion reports resources using apis removed in the given Kubernetes version ie 1.25.
+	TargetVersion string
+
+	// Logger tracks the scan logger. Defaults to a no-op logger.
+	Logger *zerolog.Logger
+}
+
+// Run scans a cluster and returns the sanitizer report.
+func Run(ctx context.Context, opts Options) (*Report, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	log := opts.Logger
+	if log == nil {
+		l := zerolog.Nop()
+		log = &l
+	}
+	p, err := NewPopeye(opts.flags(), log)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Config != nil {
+		p.config.Popeye = *opts.Config
+	}
+	if opts.Factory != nil {
+		p.SetFactory(opts.Factory)
+	}
+	if err := p.initScan(); err != nil {
+		return nil, err
+	}
+	if err := client.Load(p.factory); err != nil {
+		return nil, err
+	}
+	if _, _, err := p.sanitizeOnly(ctx, nil); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if !p.builder.HasContent() {
+		return nil, errors.New("Nothing to report, check section name or permissions")
+	}
+	name := opts.ClusterName
+	if name == "" {
+		name = p.fetchClusterName()
+	}
+	p.builder.SetClusterName(name)
+
+	return newReport(p.builder, opts.Namespaces), nil
+}
+
+func (o Options) flags() *config.Flags {
+	flags := config.NewFlags()
+	// A caller supplied factory serves resources from its own store.
+	flags.StandAlone = o.Factory == nil
+
+	level := issues.LevelToStr(o.Level)
+	flags.LintLevel = &level
+	sections := append([]string{}, o.Sections...)
+	flags.Sections = &sections
+	overAllocs := o.CheckOverAllocs
+	flags.CheckOverAllocs = &overAllocs
+	targetVersion := o.TargetVersion
+	flags.TargetVersion = &targetVersion
+	if o.KubeConfig != "" {
+		kubeConfig := o.KubeConfig
+		flags.KubeConfig = &kubeConfig
+	}
+	if o.Context != "" {
+		kubeContext := o.Context
+		flags.Context = &kubeContext
+	}
+	if len(o.Namespaces) == 1 {
+		ns := o.Namespaces[0]
+		flags.Namespace = &ns
+	}
+
+	return flags
+}
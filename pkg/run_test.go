@@ -0,0 +1,137 @@
+package pkg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/derailed/popeye/internal/offline"
+	"github.com/derailed/popeye/internal/snapshot"
+	"github.com/derailed/popeye/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestRun(t *testing.T) {
+	excludes := config.NewPopeye()
+	excludes.Excludes = config.Excludes{
+		"apps/v1/deployments": config.Exclusions{{Name: "fred/blee", Codes: []config.ID{106}}},
+	}
+
+	uu := map[string]struct {
+		opts   Options
+		score  int
+		grade  string
+		issues map[string]map[string][]config.ID
+	}{
+		"all": {
+			opts:  Options{Sections: []string{"deploy", "svc"}},
+			score: 0,
+			grade: "F",
+			issues: map[string]map[string][]config.ID{
+				"apps/v1/deployments": {"fred/blee": {501, 106, 308, 310, 311, 315, 316}},
+				"v1/services":         {"default/blee": {1100, 1105}},
+			},
+		},
+		"namespaces": {
+			opts:  Options{Sections: []string{"deploy", "svc"}, Namespaces: []string{"fred"}},
+			score: 50,
+			grade: "E",
+			issues: map[string]map[string][]config.ID{
+				"apps/v1/deployments": {"fred/blee": {501, 106, 308, 310, 311, 315, 316}},
+				"v1/services":         {},
+			},
+		},
+		"level": {
+			opts:  Options{Sections: []string{"deploy"}, Level: config.ErrorLevel},
+			score: 0,
+			grade: "F",
+			issues: map[string]map[string][]config.ID{
+				"apps/v1/deployments": {"fred/blee": {501}},
+			},
+		},
+		"config": {
+			opts:  Options{Sections: []string{"deploy"}, Config: &excludes},
+			score: 0,
+			grade: "F",
+			issues: map[string]map[string][]config.ID{
+				"apps/v1/deployments": {"fred/blee": {501, 308, 310, 311, 315, 316}},
+			},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			u.opts.Factory, u.opts.ClusterName = makeFactory(t), "fred"
+			r, err := Run(context.Background(), u.opts)
+			assert.Nil(t, err)
+			assert.Equal(t, "fred", r.ClusterName)
+			assert.Equal(t, u.score, r.Score)
+			assert.Equal(t, u.grade, r.Grade)
+			assert.Equal(t, len(u.issues), len(r.Sections))
+			for _, sec := range r.Sections {
+				ee, ok := u.issues[sec.GVR]
+				assert.True(t, ok, sec.GVR)
+				assert.Equal(t, len(ee), len(sec.Issues))
+				for fqn, ii := range sec.Issues {
+					codes := make([]config.ID, 0, len(ii))
+					for _, i := range ii {
+						codes = append(codes, i.Code)
+					}
+					assert.Equal(t, ee[fqn], codes)
+				}
+			}
+		})
+	}
+}
+
+func TestRunCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := Run(ctx, Options{Factory: makeFactory(t)})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRunTally(t *testing.T) {
+	r, err := Run(context.Background(), Options{Factory: makeFactory(t), Sections: []string{"deploy"}})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(r.Sections))
+	assert.Equal(t, Tally{Error: 1}, r.Sections[0].Tally)
+	assert.Equal(t, "", r.Sections[0].Issues["fred/blee"][0].Group)
+	assert.Equal(t, "c1", r.Sections[0].Issues["fred/blee"][1].Group)
+}
+
+func TestRunSnapshotScales(t *testing.T) {
+	s := snapshot.New(snapshot.Meta{Cluster: "fred"})
+	var hpa unstructured.Unstructured
+	hpa.SetUnstructuredContent(map[string]interface{}{
+		"apiVersion": "autoscaling/v2",
+		"kind":       "HorizontalPodAutoscaler",
+		"metadata":   map[string]interface{}{"name": "h1", "namespace": "default"},
+		"spec": map[string]interface{}{
+			"scaleTargetRef": map[string]interface{}{"apiVersion": "argoproj.io/v1alpha1", "kind": "Rollout", "name": "r1"},
+			"minReplicas":    int64(1),
+			"maxReplicas":    int64(2),
+		},
+	})
+	s.Objects["autoscaling/v2/horizontalpodautoscalers"] = []*unstructured.Unstructured{&hpa}
+	f, err := snapshot.NewFactory(genericclioptions.NewConfigFlags(false), s)
+	assert.Nil(t, err)
+
+	r, err := Run(context.Background(), Options{Factory: f, Sections: []string{"hpa"}})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(r.Sections))
+	assert.Equal(t, map[string][]Issue{"default/h1": {}}, r.Sections[0].Issues)
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func makeFactory(t *testing.T) *offline.Factory {
+	f, err := offline.NewManifestFactory(genericclioptions.NewConfigFlags(false), "testdata/manifests.yaml")
+	assert.Nil(t, err)
+
+	return f
+}
@@ -0,0 +1,353 @@
+package pkg
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/report"
+	"github.com/derailed/popeye/pkg/config"
+	"github.com/rs/zerolog"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+const (
+	// DefaultMaxScans tracks the default number of scans running concurrently.
+	DefaultMaxScans = 2
+
+	// DefaultScansRetention tracks the default number of scans kept in memory.
+	DefaultScansRetention = 100
+)
+
+// Scan states.
+const (
+	ScanPending = "pending"
+	ScanRunning = "running"
+	ScanDone    = "done"
+	ScanFailed  = "failed"
+)
+
+// ErrScanNotFound indicates an unknown or evicted scan.
+var ErrScanNotFound = errors.New("scan not found")
+
+// ScanRequest represents an on demand scan request.
+type ScanRequest struct {
+	Namespace string   `json:"namespace,omitempty"`
+	Sections  []string `json:"sections,omitempty"`
+	LintLevel string   `json:"lint,omitempty"`
+	Spinach   string   `json:"spinach,omitempty"`
+}
+
+// Scan represents an on demand scan.
+type Scan struct {
+	ID          string      `json:"id"`
+	Status      string      `json:"status"`
+	Request     ScanRequest `json:"request"`
+	Score       int         `json:"score"`
+	Grade       string      `json:"grade,omitempty"`
+	Error       string      `json:"error,omitempty"`
+	SubmittedAt time.Time   `json:"submittedAt"`
+	StartedAt   *time.Time  `json:"startedAt,omitempty"`
+	CompletedAt *time.Time  `json:"completedAt,omitempty"`
+
+	reports map[string]string
+}
+
+func (s *Scan) finished() bool {
+	return s.Status == ScanDone || s.Status == ScanFailed
+}
+
+type scanFn func(ScanRequest) (*report.Builder, config.Level, error)
+
+// Scans runs on demand scans and keeps track of the most recent results.
+type Scans struct {
+	run       scanFn
+	log       *zerolog.Logger
+	sem       chan struct{}
+	retention int
+
+	mx    sync.RWMutex
+	scans map[string]*Scan
+	order []string
+}
+
+// NewScans returns a new instance.
+func NewScans(flags *config.Flags, log *zerolog.Logger, max, retention int) *Scans {
+	if max <= 0 {
+		max = DefaultMaxScans
+	}
+	if retention <= 0 {
+		retention = DefaultScansRetention
+	}
+	s := Scans{
+		log:       log,
+		sem:       make(chan struct{}, max),
+		retention: retention,
+		scans:     make(map[string]*Scan),
+	}
+	s.run = func(req ScanRequest) (*report.Builder, config.Level, error) {
+		return scan(flags, log, req)
+	}
+
+	return &s
+}
+
+// Submit queues a new scan.
+func (s *Scans) Submit(req ScanRequest) Scan {
+	sc := Scan{
+		ID:          string(uuid.NewUUID()),
+		Status:      ScanPending,
+		Request:     req,
+		SubmittedAt: time.Now(),
+	}
+
+	s.mx.Lock()
+	ret := sc
+	s.scans[sc.ID] = &sc
+	s.order = append(s.order, sc.ID)
+	s.evict()
+	s.mx.Unlock()
+
+	go s.exec(ret.ID, req)
+
+	return ret
+}
+
+// Get returns a scan status.
+func (s *Scans) Get(id string) (Scan, error) {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	sc, ok := s.scans[id]
+	if !ok {
+		return Scan{}, ErrScanNotFound
+	}
+
+	return *sc, nil
+}
+
+// Report returns a scan report in the given format.
+func (s *Scans) Report(id, format string) (string, error) {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	sc, ok := s.scans[id]
+	if !ok {
+		return "", ErrScanNotFound
+	}
+	if sc.Status != ScanDone {
+		return "", fmt.Errorf("scan %s is %s", id, sc.Status)
+	}
+	res, ok := sc.reports[format]
+	if !ok {
+		return "", fmt.Errorf("unsupported report format %q", format)
+	}
+
+	return res, nil
+}
+
+func (s *Scans) exec(id string, req ScanRequest) {
+	s.sem <- struct{}{}
+	defer func() { <-s.sem }()
+
+	s.update(id, func(sc *Scan) {
+		now := time.Now()
+		sc.Status, sc.StartedAt = ScanRunning, &now
+	})
+
+	reports, b, err := s.render(req)
+	s.update(id, func(sc *Scan) {
+		now := time.Now()
+		sc.CompletedAt = &now
+		if err != nil {
+			s.log.Warn().Err(err).Msgf("Scan %s failed", id)
+			sc.Status, sc.Error = ScanFailed, err.Error()
+			return
+		}
+		sc.Status, sc.reports = ScanDone, reports
+		sc.Score, sc.Grade = b.Report.Score, b.Report.Grade
+	})
+}
+
+func (s *Scans) render(req ScanRequest) (rr map[string]string, b *report.Builder, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("Popeye CHOKED! %v", e)
+		}
+	}()
+
+	b, level, err := s.run(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !b.HasContent() {
+		return nil, nil, errors.New("Nothing to report, check section name or permissions")
+	}
+
+	rr = make(map[string]string, 4)
+	if rr[report.JSONFormat], err = b.ToJSON(); err != nil {
+		return nil, nil, err
+	}
+	if rr[report.YAMLFormat], err = b.ToYAML(); err != nil {
+		return nil, nil, err
+	}
+	if rr[report.HTMLFormat], err = b.ToHTML(); err != nil {
+		return nil, nil, err
+	}
+	res, err := b.ToJunit(level)
+	if err != nil {
+		return nil, nil, err
+	}
+	rr[report.JunitFormat] = xml.Header + res
+
+	return rr, b, nil
+}
+
+func (s *Scans) update(id string, f func(*Scan)) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if sc, ok := s.scans[id]; ok {
+		f(sc)
+	}
+}
+
+// evict drops the oldest finished scans past the retention limit.
+func (s *Scans) evict() {
+	for i := 0; len(s.order) > s.retention && i < len(s.order); {
+		id := s.order[i]
+		if !s.scans[id].finished() {
+			i++
+			continue
+		}
+		delete(s.scans, id)
+		s.order = append(s.order[:i], s.order[i+1:]...)
+	}
+}
+
+// Mount registers the scans routes.
+func (s *Scans) Mount(mux *http.ServeMux) {
+	mux.HandleFunc("/scans", s.submitHandler)
+	mux.HandleFunc("/scans/", s.scanHandler)
+}
+
+func (s *Scans) submitHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req ScanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid scan request -- %v", err), http.StatusBadRequest)
+		return
+	}
+	sc := s.Submit(req)
+	w.Header().Set("Location", "/scans/"+sc.ID)
+	writeJSON(w, http.StatusAccepted, sc)
+}
+
+func (s *Scans) scanHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	tokens := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/scans/"), "/"), "/")
+	switch {
+	case len(tokens) == 1 && tokens[0] != "":
+		sc, err := s.Get(tokens[0])
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		writeJSON(w, http.StatusOK, sc)
+	case len(tokens) == 2 && tokens[1] == "report":
+		s.reportHandler(w, r, tokens[0])
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Scans) reportHandler(w http.ResponseWriter, r *http.Request, id string) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = report.JSONFormat
+	}
+	ct, ok := reportContentTypes[format]
+	if !ok {
+		http.Error(w, fmt.Sprintf("Unsupported report format %q", format), http.StatusBadRequest)
+		return
+	}
+
+	res, err := s.Report(id, format)
+	switch {
+	case errors.Is(err, ErrScanNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		w.Header().Set("Content-Type", ct)
+		_, _ = w.Write([]byte(res))
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+var reportContentTypes = map[string]string{
+	report.JSONFormat:  "application/json",
+	report.YAMLFormat:  "application/yaml",
+	report.HTMLFormat:  "text/html; charset=utf-8",
+	report.JunitFormat: "application/xml",
+}
+
+func scan(flags *config.Flags, log *zerolog.Logger, req ScanRequest) (*report.Builder, config.Level, error) {
+	ff := flags.Clone()
+	ff.StandAlone = true
+	if req.Namespace != "" {
+		ff.ConfigFlags.Namespace = &req.Namespace
+	}
+	if len(req.Sections) > 0 {
+		ff.Sections = &req.Sections
+	}
+	if req.LintLevel != "" {
+		ff.LintLevel = &req.LintLevel
+	}
+
+	p, err := NewPopeye(ff, log)
+	if err != nil {
+		return nil, 0, err
+	}
+	if req.Spinach != "" {
+		if err := p.config.Override([]byte(req.Spinach)); err != nil {
+			return nil, 0, err
+		}
+	}
+	if err := p.initScan(); err != nil {
+		return nil, 0, err
+	}
+	if err := client.Load(p.factory); err != nil {
+		return nil, 0, err
+	}
+	if _, _, err := p.sanitize(); err != nil {
+		return nil, 0, err
+	}
+	p.builder.SetClusterName(p.fetchClusterName())
+
+	return p.builder, config.Level(p.config.LinterLevel()), nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, o interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(o)
+}
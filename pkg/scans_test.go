@@ -0,0 +1,253 @@
+package pkg
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/internal/report"
+	"github.com/derailed/popeye/pkg/config"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScansLifecycle(t *testing.T) {
+	s := makeScans(1, 10)
+	release := make(chan struct{})
+	s.run = func(req ScanRequest) (*report.Builder, config.Level, error) {
+		<-release
+		if req.Namespace == "toast" {
+			return nil, 0, errors.New("boom")
+		}
+		return makeReport(), config.OkLevel, nil
+	}
+
+	sc1, sc2 := s.Submit(ScanRequest{Namespace: "fred"}), s.Submit(ScanRequest{Namespace: "toast"})
+	running, pending := waitRunning(t, s, sc1.ID, sc2.ID)
+	sc, err := s.Get(pending)
+	assert.Nil(t, err)
+	assert.Equal(t, ScanPending, sc.Status)
+	_, err = s.Report(running, report.JSONFormat)
+	assert.Equal(t, "scan "+running+" is running", err.Error())
+
+	close(release)
+	sc = waitStatus(t, s, sc1.ID, ScanDone)
+	assert.Equal(t, 100, sc.Score)
+	assert.Equal(t, "A", sc.Grade)
+	sc = waitStatus(t, s, sc2.ID, ScanFailed)
+	assert.Equal(t, "boom", sc.Error)
+
+	_, err = s.Get("zorg")
+	assert.Equal(t, ErrScanNotFound, err)
+}
+
+func TestScansRetention(t *testing.T) {
+	s := makeScans(2, 2)
+	s.run = func(ScanRequest) (*report.Builder, config.Level, error) {
+		return makeReport(), config.OkLevel, nil
+	}
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		sc := s.Submit(ScanRequest{})
+		waitStatus(t, s, sc.ID, ScanDone)
+		ids = append(ids, sc.ID)
+	}
+	_, err := s.Get(ids[0])
+	assert.Equal(t, ErrScanNotFound, err)
+	for _, id := range ids[1:] {
+		_, err := s.Get(id)
+		assert.Nil(t, err)
+	}
+}
+
+func TestScansHandler(t *testing.T) {
+	s := makeScans(1, 10)
+	s.run = func(ScanRequest) (*report.Builder, config.Level, error) {
+		return makeReport(), config.OkLevel, nil
+	}
+	mux := http.NewServeMux()
+	s.Mount(mux)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	resp, err := http.Post(srv.URL+"/scans", "application/json", strings.NewReader(`{"namespace":"fred","sections":["po"],"lint":"warn"}`))
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	loc := resp.Header.Get("Location")
+	id := strings.TrimPrefix(loc, "/scans/")
+	waitStatus(t, s, id, ScanDone)
+
+	uu := map[string]struct {
+		method, path, body string
+		status             int
+		contentType, e     string
+	}{
+		"status": {
+			method:      http.MethodGet,
+			path:        loc,
+			status:      http.StatusOK,
+			contentType: "application/json",
+			e:           `"status":"done"`,
+		},
+		"default-report": {
+			method:      http.MethodGet,
+			path:        loc + "/report",
+			status:      http.StatusOK,
+			contentType: "application/json",
+			e:           `"sanitizer":"fred"`,
+		},
+		"yaml": {
+			method:      http.MethodGet,
+			path:        loc + "/report?format=yaml",
+			status:      http.StatusOK,
+			contentType: "application/yaml",
+			e:           "sanitizer: fred",
+		},
+		"html": {
+			method:      http.MethodGet,
+			path:        loc + "/report?format=html",
+			status:      http.StatusOK,
+			contentType: "text/html; charset=utf-8",
+			e:           "FRED (1 SCANNED)",
+		},
+		"junit": {
+			method:      http.MethodGet,
+			path:        loc + "/report?format=junit",
+			status:      http.StatusOK,
+			contentType: "application/xml",
+			e:           `<?xml version="1.0" encoding="UTF-8"?>`,
+		},
+		"bad-format": {
+			method: http.MethodGet,
+			path:   loc + "/report?format=toast",
+			status: http.StatusBadRequest,
+		},
+		"unknown": {
+			method: http.MethodGet,
+			path:   "/scans/zorg",
+			status: http.StatusNotFound,
+		},
+		"unknown-report": {
+			method: http.MethodGet,
+			path:   "/scans/zorg/report",
+			status: http.StatusNotFound,
+		},
+		"bad-path": {
+			method: http.MethodGet,
+			path:   loc + "/blee",
+			status: http.StatusNotFound,
+		},
+		"bad-request": {
+			method: http.MethodPost,
+			path:   "/scans",
+			body:   "{",
+			status: http.StatusBadRequest,
+		},
+		"bad-method": {
+			method: http.MethodGet,
+			path:   "/scans",
+			status: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			req, err := http.NewRequest(u.method, srv.URL+u.path, bytes.NewBufferString(u.body))
+			assert.Nil(t, err)
+			resp, err := http.DefaultClient.Do(req)
+			assert.Nil(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, u.status, resp.StatusCode)
+			if u.contentType != "" {
+				assert.Equal(t, u.contentType, resp.Header.Get("Content-Type"))
+			}
+			b, _ := io.ReadAll(resp.Body)
+			assert.Contains(t, string(b), u.e)
+		})
+	}
+}
+
+func TestScanManifests(t *testing.T) {
+	flags := config.NewFlags()
+	path := "testdata/manifests.yaml"
+	flags.Manifests = &path
+	l := zerolog.Nop()
+
+	b, level, err := scan(flags, &l, ScanRequest{
+		Sections:  []string{"deploy"},
+		LintLevel: "warn",
+		Spinach:   "popeye:\n  pod:\n    restarts: 10\n",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, config.WarnLevel, level)
+	assert.Equal(t, 1, len(b.Report.Sections))
+	assert.Equal(t, "deployments", b.Report.Sections[0].Title)
+	assert.Equal(t, "ok", *flags.LintLevel)
+
+	_, _, err = scan(flags, &l, ScanRequest{Spinach: "popeye: ["})
+	assert.NotNil(t, err)
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func makeScans(max, retention int) *Scans {
+	l := zerolog.Nop()
+	return NewScans(config.NewFlags(), &l, max, retention)
+}
+
+func makeReport() *report.Builder {
+	b, ta := report.NewBuilder(), report.NewTally()
+	o := issues.Outcome{
+		"blee": issues.Issues{
+			issues.New(client.NewGVR("fred"), issues.Root, config.OkLevel, "Blah"),
+		},
+	}
+	ta.Rollup(o)
+	b.AddSection(client.NewGVR("fred"), "fred", o, ta)
+
+	return b
+}
+
+// waitRunning waits for one of the two scans to run and returns the running and pending ids.
+func waitRunning(t *testing.T, s *Scans, id1, id2 string) (string, string) {
+	for i := 0; i < 200; i++ {
+		sc1, _ := s.Get(id1)
+		sc2, _ := s.Get(id2)
+		switch {
+		case sc1.Status == ScanRunning:
+			return id1, id2
+		case sc2.Status == ScanRunning:
+			return id2, id1
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("scans never started")
+
+	return "", ""
+}
+
+func waitStatus(t *testing.T, s *Scans, id, status string) Scan {
+	for i := 0; i < 200; i++ {
+		sc, err := s.Get(id)
+		assert.Nil(t, err)
+		if sc.Status == status {
+			return sc
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("scan %s never reached status %s", id, status)
+
+	return Scan{}
+}
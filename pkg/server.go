@@ -0,0 +1,351 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/internal/report"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// DefaultServeAddr tracks the default server listen address.
+	DefaultServeAddr = ":8080"
+
+	// DefaultDebounce tracks the default delay between a change and a sanitization pass.
+	DefaultDebounce = 10 * time.Second
+
+	shutdownTimeout = 5 * time.Second
+)
+
+// sanitizerDeps tracks the resources each sanitizer reads from. A change
+// on any of these resources re-runs the sanitizer. Sanitizers that are not
+// listed here are re-run on every change.
+var sanitizerDeps = map[string][]string{
+	"cluster":                   {},
+	"configmaps":                {"configmaps", "pods"},
+	"limitranges":               {"limitranges", "pods"},
+	"namespaces":                {"namespaces", "pods", "limitranges", "resourcequotas", "networkpolicies"},
+	"nodes":                     {"nodes", "pods"},
+	"pods":                      {"pods", "poddisruptionbudgets", "serviceaccounts", "namespaces", "networkpolicies"},
+	"persistentvolumes":         {"persistentvolumes", "pods"},
+	"persistentvolumeclaims":    {"persistentvolumeclaims", "pods"},
+	"resourcequotas":            {"resourcequotas"},
+	"secrets":                   {"secrets", "pods", "serviceaccounts", "ingresses"},
+	"services":                  {"services", "endpoints", "pods"},
+	"serviceaccounts":           {"serviceaccounts", "pods", "secrets", "ingresses", "rolebindings", "clusterrolebindings"},
+	"daemonsets":                {"daemonsets", "pods", "serviceaccounts", "namespaces"},
+	"deployments":               {"deployments", "pods", "serviceaccounts", "namespaces", "nodes"},
+	"replicasets":               {"replicasets", "pods", "deployments"},
+	"statefulsets":              {"statefulsets", "pods", "serviceaccounts", "namespaces", "nodes"},
+	"jobs":                      {"jobs", "namespaces"},
+	"cronjobs":                  {"cronjobs", "jobs", "namespaces"},
+	"networkpolicies":           {"networkpolicies", "namespaces", "pods"},
+	"ingresses":                 {"ingresses", "ingressclasses", "services", "endpoints", "secrets"},
+	"clusterroles":              {"clusterroles", "clusterrolebindings", "rolebindings"},
+	"clusterrolebindings":       {"clusterrolebindings", "clusterroles", "roles"},
+	"roles":                     {"roles", "rolebindings", "clusterrolebindings"},
+	"rolebindings":              {"rolebindings", "roles", "clusterroles"},
+	"poddisruptionbudgets":      {"poddisruptionbudgets", "pods"},
+	"customresourcedefinitions": {"customresourcedefinitions"},
+	"horizontalpodautoscalers":  {"horizontalpodautoscalers", "deployments", "statefulsets", "nodes", "pods", "serviceaccounts"},
+}
+
+// Server continuously sanitizes a live cluster and serves the latest report.
+type Server struct {
+	popeye   *Popeye
+	scans    *Scans
+	debounce time.Duration
+	registry *prometheus.Registry
+	started  time.Time
+
+	mx        sync.RWMutex
+	json      string
+	html      string
+	sections  map[string]report.Section
+	garbage   map[string][]report.Garbage
+	isolation []report.Isolation
+
+	dirtyMX sync.Mutex
+	dirty   map[string]struct{}
+	timer   *time.Timer
+
+	passMX sync.Mutex
+}
+
+// NewServer returns a new instance.
+func NewServer(p *Popeye, debounce time.Duration) *Server {
+	if debounce <= 0 {
+		debounce = DefaultDebounce
+	}
+
+	return &Server{
+		popeye:   p,
+		debounce: debounce,
+		registry: report.NewRegistry(),
+		sections: make(map[string]report.Section),
+		garbage:  make(map[string][]report.Garbage),
+		dirty:    make(map[string]struct{}),
+	}
+}
+
+// SetScans enables the on demand scans api.
+func (s *Server) SetScans(sc *Scans) {
+	s.scans = sc
+}
+
+// Handler returns the server http routes.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", s.healthz)
+	mux.Handle("/metrics", promhttp.HandlerFor(s.registry, promhttp.HandlerOpts{}))
+	mux.HandleFunc("/report.json", s.reportJSON)
+	mux.HandleFunc("/report.html", s.reportHTML)
+	if s.scans != nil {
+		s.scans.Mount(mux)
+	}
+
+	return mux
+}
+
+// Serve sanitizes the cluster and serves the latest report until the context is canceled.
+func (s *Server) Serve(ctx context.Context, addr string) error {
+	if err := s.popeye.initScan(); err != nil {
+		return err
+	}
+	if err := client.Load(s.popeye.factory); err != nil {
+		return err
+	}
+	if err := s.watch(); err != nil {
+		return err
+	}
+	if err := s.sanitize(nil); err != nil {
+		return err
+	}
+
+	srv := http.Server{Addr: addr, Handler: s.Handler()}
+	errChan := make(chan error, 1)
+	go func() {
+		s.popeye.log.Info().Msgf("Popeye serving on %s", addr)
+		errChan <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-ctx.Done():
+	}
+	s.dirtyMX.Lock()
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	s.dirtyMX.Unlock()
+
+	tctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(tctx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Server) watch() error {
+	rev, err := s.popeye.revision()
+	if err != nil {
+		return err
+	}
+
+	s.started = time.Now()
+	for _, gvr := range s.popeye.scannedGVRs(rev) {
+		inf, err := s.popeye.factory.ForResource(client.AllNamespaces, gvr)
+		if err != nil {
+			return err
+		}
+		if inf == nil {
+			continue
+		}
+		res := client.NewGVR(gvr).R()
+		inf.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(o interface{}) {
+				// Skip resources replayed from the informer cache.
+				if m, err := meta.Accessor(o); err == nil && m.GetCreationTimestamp().Time.Before(s.started) {
+					return
+				}
+				s.touch(res)
+			},
+			UpdateFunc: func(o, n interface{}) {
+				// Skip informer resyncs.
+				om, err1 := meta.Accessor(o)
+				nm, err2 := meta.Accessor(n)
+				if err1 == nil && err2 == nil && om.GetResourceVersion() == nm.GetResourceVersion() {
+					return
+				}
+				s.touch(res)
+			},
+			DeleteFunc: func(interface{}) {
+				s.touch(res)
+			},
+		})
+	}
+
+	return nil
+}
+
+// touch marks a resource as changed and schedules a sanitization pass.
+func (s *Server) touch(res string) {
+	s.dirtyMX.Lock()
+	defer s.dirtyMX.Unlock()
+
+	s.dirty[res] = struct{}{}
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	s.timer = time.AfterFunc(s.debounce, s.resanitize)
+}
+
+func (s *Server) resanitize() {
+	s.dirtyMX.Lock()
+	dirty := s.dirty
+	s.dirty = make(map[string]struct{})
+	s.dirtyMX.Unlock()
+
+	if len(dirty) == 0 {
+		return
+	}
+	if err := s.sanitize(dirty); err != nil {
+		s.popeye.log.Error().Err(err).Msg("Sanitization pass failed")
+	}
+}
+
+// sanitize re-runs the sanitizers affected by the changed resources or all of them if nil.
+func (s *Server) sanitize(dirty map[string]struct{}) error {
+	s.passMX.Lock()
+	defer s.passMX.Unlock()
+
+	var keep func(client.GVR) bool
+	if dirty != nil {
+		keep = func(gvr client.GVR) bool {
+			return isAffected(gvr.R(), dirty)
+		}
+		s.popeye.log.Debug().Msgf("Resanitizing changed resources %v", keys(dirty))
+	}
+
+	p := s.popeye
+	p.builder = report.NewBuilder()
+	if _, _, err := p.sanitizeOnly(context.Background(), keep); err != nil {
+		return err
+	}
+	fresh := make(map[string]struct{}, len(p.builder.Report.Sections))
+	for _, sec := range p.builder.Report.Sections {
+		fresh[sec.GVR] = struct{}{}
+	}
+	for k, sec := range s.sections {
+		if _, ok := fresh[k]; ok {
+			continue
+		}
+		gvr := client.NewGVR(sec.GVR)
+		p.builder.AddSection(gvr, p.aliases.Singular(gvr), sec.Outcome, sec.Tally)
+		for _, g := range s.garbage[k] {
+			p.builder.AddGarbage(g)
+		}
+	}
+	// Isolation is only recomputed when the NetworkPolicy sanitizer re-runs.
+	if _, ok := fresh["networking.k8s.io/v1/networkpolicies"]; !ok {
+		for _, i := range s.isolation {
+			p.builder.AddIsolation(i)
+		}
+	}
+	if !p.builder.HasContent() {
+		return errors.New("Nothing to report, check section name or permissions")
+	}
+
+	return s.publish(p.builder)
+}
+
+func (s *Server) publish(b *report.Builder) error {
+	b.SetClusterName(s.popeye.fetchClusterName())
+	j, err := b.ToJSON()
+	if err != nil {
+		return err
+	}
+	h, err := b.ToHTML()
+	if err != nil {
+		return err
+	}
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.json, s.html = j, h
+	s.sections = make(map[string]report.Section, len(b.Report.Sections))
+	for _, sec := range b.Report.Sections {
+		s.sections[sec.GVR] = sec
+	}
+	s.garbage = make(map[string][]report.Garbage, len(b.Report.Sections))
+	for _, g := range b.Report.Garbage {
+		s.garbage[g.GVR] = append(s.garbage[g.GVR], g)
+	}
+	s.isolation = b.Report.Isolation
+	b.ToMetrics(s.popeye.factory.Client().ActiveNamespace())
+
+	return nil
+}
+
+func (s *Server) healthz(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok"))
+}
+
+func (s *Server) reportJSON(w http.ResponseWriter, _ *http.Request) {
+	s.serveReport(w, "application/json", func() string { return s.json })
+}
+
+func (s *Server) reportHTML(w http.ResponseWriter, _ *http.Request) {
+	s.serveReport(w, "text/html; charset=utf-8", func() string { return s.html })
+}
+
+func (s *Server) serveReport(w http.ResponseWriter, contentType string, body func() string) {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	res := body()
+	if res == "" {
+		http.Error(w, "Sanitizer report not ready", http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	_, _ = w.Write([]byte(res))
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func isAffected(res string, dirty map[string]struct{}) bool {
+	deps, ok := sanitizerDeps[res]
+	if !ok {
+		return true
+	}
+	for _, d := range deps {
+		if _, ok := dirty[d]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func keys(m map[string]struct{}) []string {
+	kk := make([]string, 0, len(m))
+	for k := range m {
+		kk = append(kk, k)
+	}
+
+	return kk
+}
@@ -0,0 +1,139 @@
+package pkg
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/derailed/popeye/internal/client"
+	"github.com/derailed/popeye/pkg/config"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsAffected(t *testing.T) {
+	uu := map[string]struct {
+		res   string
+		dirty []string
+		e     bool
+	}{
+		"self":      {res: "deployments", dirty: []string{"deployments"}, e: true},
+		"dep":       {res: "deployments", dirty: []string{"pods"}, e: true},
+		"unrelated": {res: "deployments", dirty: []string{"configmaps"}},
+		"cluster":   {res: "cluster", dirty: []string{"pods"}},
+		"podSec":    {res: "jobs", dirty: []string{"namespaces"}, e: true},
+		"unknown":   {res: "blee", dirty: []string{"configmaps"}, e: true},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			dirty := make(map[string]struct{}, len(u.dirty))
+			for _, d := range u.dirty {
+				dirty[d] = struct{}{}
+			}
+			assert.Equal(t, u.e, isAffected(u.res, dirty))
+		})
+	}
+}
+
+func TestServerHandler(t *testing.T) {
+	s := makeServer(t)
+	srv := httptest.NewServer(s.Handler())
+	defer srv.Close()
+
+	uu := map[string]struct {
+		path, contentType, body string
+		status                  int
+		ready                   bool
+	}{
+		"healthz": {
+			path:   "/healthz",
+			status: http.StatusOK,
+			body:   "ok",
+		},
+		"json-not-ready": {
+			path:   "/report.json",
+			status: http.StatusServiceUnavailable,
+		},
+		"json": {
+			path:        "/report.json",
+			ready:       true,
+			status:      http.StatusOK,
+			contentType: "application/json",
+			body:        `"sanitizer":"deployments"`,
+		},
+		"html": {
+			path:        "/report.html",
+			ready:       true,
+			status:      http.StatusOK,
+			contentType: "text/html; charset=utf-8",
+			body:        "Popeye K8s Sanitizer Report",
+		},
+		"metrics": {
+			path:   "/metrics",
+			ready:  true,
+			status: http.StatusOK,
+			body:   `popeye_sanitizer_score_total{cluster="manifests",namespace="all",resource="deployments"}`,
+		},
+	}
+
+	for _, k := range []string{"healthz", "json-not-ready", "json", "html", "metrics"} {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			if u.ready && s.json == "" {
+				assert.Nil(t, s.sanitize(nil))
+			}
+			resp, err := http.Get(srv.URL + u.path)
+			assert.Nil(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, u.status, resp.StatusCode)
+			if u.contentType != "" {
+				assert.Equal(t, u.contentType, resp.Header.Get("Content-Type"))
+			}
+			var b strings.Builder
+			_, _ = io.Copy(&b, resp.Body)
+			assert.Contains(t, b.String(), u.body)
+		})
+	}
+}
+
+func TestServerSanitizeAffected(t *testing.T) {
+	s := makeServer(t)
+	assert.Nil(t, s.sanitize(nil))
+	all, iso := len(s.sections), s.isolation
+	dp := s.sections["apps/v1/deployments"]
+	gg, reclaim := s.popeye.builder.Report.Garbage, s.popeye.builder.Report.Reclaimable
+	assert.NotEmpty(t, iso)
+	assert.NotEmpty(t, gg)
+
+	assert.Nil(t, s.sanitize(map[string]struct{}{"configmaps": {}}))
+	assert.Equal(t, all, len(s.sections))
+	assert.Equal(t, iso, s.isolation)
+	assert.Equal(t, gg, s.popeye.builder.Report.Garbage)
+	assert.Equal(t, reclaim, s.popeye.builder.Report.Reclaimable)
+	assert.Same(t, dp.Tally, s.sections["apps/v1/deployments"].Tally)
+
+	assert.Nil(t, s.sanitize(map[string]struct{}{"pods": {}}))
+	assert.Equal(t, all, len(s.sections))
+	assert.NotSame(t, dp.Tally, s.sections["apps/v1/deployments"].Tally)
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func makeServer(t *testing.T) *Server {
+	flags := config.NewFlags()
+	path := "testdata/manifests.yaml"
+	flags.Manifests = &path
+	l := zerolog.Nop()
+	p, err := NewPopeye(flags, &l)
+	assert.Nil(t, err)
+	assert.Nil(t, p.initScan())
+	assert.Nil(t, client.Load(p.factory))
+
+	return NewServer(p, 0)
+}
@@ -0,0 +1,21 @@
+package pkg
+
+import (
+	"net/http"
+
+	"github.com/derailed/popeye/internal/issues"
+	"github.com/derailed/popeye/internal/webhook"
+	"github.com/derailed/popeye/pkg/config"
+)
+
+// Webhook returns an admission webhook sanitizing incoming workloads.
+// Issues at or above the given level deny the admission.
+func (p *Popeye) Webhook(level config.Level, ids []config.ID) (http.Handler, error) {
+	codes, err := issues.LoadCodes()
+	if err != nil {
+		return nil, err
+	}
+	codes.Refine(p.config.Codes)
+
+	return webhook.New(p.config, codes, level, ids), nil
+}
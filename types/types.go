@@ -7,7 +7,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
-	"k8s.io/client-go/discovery/cached/disk"
+	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
@@ -75,13 +75,13 @@ type Connection interface {
 	Dial() (kubernetes.Interface, error)
 
 	// CachedDiscovery connects to discovery client.
-	CachedDiscovery() (*disk.CachedDiscoveryClient, error)
+	CachedDiscovery() (discovery.CachedDiscoveryInterface, error)
 
 	// RestConfig connects to rest client.
 	RestConfig() (*restclient.Config, error)
 
 	// MXDial connects to metrics server.
-	MXDial() (*versioned.Clientset, error)
+	MXDial() (versioned.Interface, error)
 
 	// DynDial connects to dynamic client.
 	DynDial() (dynamic.Interface, error)